@@ -1,13 +1,40 @@
 package zabbix
 
 import (
+	"encoding/json"
+
 	"github.com/NexonSU/go-zabbix/types"
 )
 
+// ActionStatus is whether an Action is enabled.
+type ActionStatus int
+
+// ActionEvalType is how the conditions of an Action filter or operation are
+// combined.
+type ActionEvalType int
+
+// ActionConditionType is the property of an event checked by an
+// ActionCondition.
+type ActionConditionType int
+
+// ActionConditionOperator is how an ActionCondition compares its value.
+type ActionConditionOperator int
+
+// ActionOperationType is the type of an ActionOperation.
+type ActionOperationType int
+
+const (
+	// ActionStatusEnabled indicates that an Action is enabled.
+	ActionStatusEnabled ActionStatus = 0
+
+	// ActionStatusDisabled indicates that an Action is disabled.
+	ActionStatusDisabled ActionStatus = 1
+)
+
 const (
 	// ActionEvalTypeAndOr indicated that an Action will evaluate its conditions
 	// using AND/OR bitwise logic.
-	ActionEvalTypeAndOr = iota
+	ActionEvalTypeAndOr ActionEvalType = iota
 
 	// ActionEvalTypeAnd indicated that an Action will evaluate its conditions
 	// using AND bitwise logic.
@@ -16,85 +43,772 @@ const (
 	// ActionEvalTypeOr indicated that an Action will evaluate its conditions
 	// using OR bitwise logic.
 	ActionEvalTypeOr
+
+	// ActionEvalTypeCustom indicates that an Action will evaluate its
+	// conditions using the custom ActionFilter.Formula. It is only supported
+	// by filters.
+	ActionEvalTypeCustom
 )
 
+const (
+	// ActionConditionTypeHostGroup matches the host groups of the event.
+	ActionConditionTypeHostGroup ActionConditionType = 0
+
+	// ActionConditionTypeHost matches the host of the event.
+	ActionConditionTypeHost ActionConditionType = 1
+
+	// ActionConditionTypeTrigger matches the trigger of the event.
+	ActionConditionTypeTrigger ActionConditionType = 2
+
+	// ActionConditionTypeEventName matches the name of the event.
+	ActionConditionTypeEventName ActionConditionType = 3
+
+	// ActionConditionTypeTriggerSeverity matches the severity of the trigger.
+	ActionConditionTypeTriggerSeverity ActionConditionType = 4
+
+	// ActionConditionTypeTimePeriod matches events in a time period.
+	ActionConditionTypeTimePeriod ActionConditionType = 6
+
+	// ActionConditionTypeHostIP matches the IP address of a discovered host.
+	ActionConditionTypeHostIP ActionConditionType = 7
+
+	// ActionConditionTypeDiscoveredServiceType matches the type of a discovered
+	// service.
+	ActionConditionTypeDiscoveredServiceType ActionConditionType = 8
+
+	// ActionConditionTypeDiscoveredServicePort matches the port of a discovered
+	// service.
+	ActionConditionTypeDiscoveredServicePort ActionConditionType = 9
+
+	// ActionConditionTypeDiscoveryStatus matches the status of a discovered host
+	// or service.
+	ActionConditionTypeDiscoveryStatus ActionConditionType = 10
+
+	// ActionConditionTypeUptimeDowntime matches the uptime or downtime of a
+	// discovered host or service.
+	ActionConditionTypeUptimeDowntime ActionConditionType = 11
+
+	// ActionConditionTypeReceivedValue matches the value received by a discovery
+	// check.
+	ActionConditionTypeReceivedValue ActionConditionType = 12
+
+	// ActionConditionTypeHostTemplate matches the templates of the host of the
+	// event.
+	ActionConditionTypeHostTemplate ActionConditionType = 13
+
+	// ActionConditionTypeEventAcknowledged is only supported by the
+	// conditions of an ActionOperation.
+	ActionConditionTypeEventAcknowledged ActionConditionType = 14
+
+	// ActionConditionTypeProblemSuppressed matches suppressed problems.
+	ActionConditionTypeProblemSuppressed ActionConditionType = 16
+
+	// ActionConditionTypeDiscoveryRule matches the network discovery rule.
+	ActionConditionTypeDiscoveryRule ActionConditionType = 18
+
+	// ActionConditionTypeDiscoveryCheck matches the network discovery check.
+	ActionConditionTypeDiscoveryCheck ActionConditionType = 19
+
+	// ActionConditionTypeProxy matches the proxy of the event.
+	ActionConditionTypeProxy ActionConditionType = 20
+
+	// ActionConditionTypeDiscoveryObject matches discovered hosts or services.
+	ActionConditionTypeDiscoveryObject ActionConditionType = 21
+
+	// ActionConditionTypeHostName matches the name of an autoregistered host.
+	ActionConditionTypeHostName ActionConditionType = 22
+
+	// ActionConditionTypeEventType matches the type of an internal event.
+	ActionConditionTypeEventType ActionConditionType = 23
+
+	// ActionConditionTypeHostMetadata matches the metadata of an autoregistered
+	// host.
+	ActionConditionTypeHostMetadata ActionConditionType = 24
+
+	// ActionConditionTypeEventTag matches the tag names of the event.
+	ActionConditionTypeEventTag ActionConditionType = 25
+
+	// ActionConditionTypeEventTagValue matches the value of a tag of the event,
+	// named by Value2.
+	ActionConditionTypeEventTagValue ActionConditionType = 26
+
+	// ActionConditionTypeService matches the service of the event.
+	ActionConditionTypeService ActionConditionType = 27
+
+	// ActionConditionTypeServiceName matches the name of the service of the event.
+	ActionConditionTypeServiceName ActionConditionType = 28
+)
+
+const (
+	// ActionConditionOperatorEqual matches values equal to the condition value.
+	ActionConditionOperatorEqual ActionConditionOperator = 0
+
+	// ActionConditionOperatorNotEqual matches values not equal to the condition
+	// value.
+	ActionConditionOperatorNotEqual ActionConditionOperator = 1
+
+	// ActionConditionOperatorContains matches values containing the condition
+	// value.
+	ActionConditionOperatorContains ActionConditionOperator = 2
+
+	// ActionConditionOperatorNotContains matches values not containing the
+	// condition value.
+	ActionConditionOperatorNotContains ActionConditionOperator = 3
+
+	// ActionConditionOperatorIn matches values in the condition value, such as a
+	// time period.
+	ActionConditionOperatorIn ActionConditionOperator = 4
+
+	// ActionConditionOperatorGreaterOrEqual matches values greater than or equal
+	// to the condition value.
+	ActionConditionOperatorGreaterOrEqual ActionConditionOperator = 5
+
+	// ActionConditionOperatorLessOrEqual matches values less than or equal to the
+	// condition value.
+	ActionConditionOperatorLessOrEqual ActionConditionOperator = 6
+
+	// ActionConditionOperatorNotIn matches values not in the condition value.
+	ActionConditionOperatorNotIn ActionConditionOperator = 7
+
+	// ActionConditionOperatorMatches matches values against a regular expression.
+	ActionConditionOperatorMatches ActionConditionOperator = 8
+
+	// ActionConditionOperatorNotMatches matches values not matching a regular
+	// expression.
+	ActionConditionOperatorNotMatches ActionConditionOperator = 9
+
+	// ActionConditionOperatorYes matches if the condition holds, such as for
+	// suppressed problems.
+	ActionConditionOperatorYes ActionConditionOperator = 10
+
+	// ActionConditionOperatorNo matches if the condition does not hold.
+	ActionConditionOperatorNo ActionConditionOperator = 11
+)
+
+const (
+	// ActionOperationTypeSendMessage sends ActionOperation.Message to the
+	// users and user groups of the operation.
+	ActionOperationTypeSendMessage ActionOperationType = 0
+
+	// ActionOperationTypeGlobalScript runs the script of
+	// ActionOperation.Command on the hosts and host groups of the operation.
+	ActionOperationTypeGlobalScript ActionOperationType = 1
+
+	// ActionOperationTypeAddHost adds a discovered host.
+	ActionOperationTypeAddHost ActionOperationType = 2
+
+	// ActionOperationTypeRemoveHost removes a discovered host.
+	ActionOperationTypeRemoveHost ActionOperationType = 3
+
+	// ActionOperationTypeAddToHostGroup adds a discovered host to the HostGroups
+	// of the operation.
+	ActionOperationTypeAddToHostGroup ActionOperationType = 4
+
+	// ActionOperationTypeRemoveFromHostGroup removes a discovered host from the
+	// HostGroups of the operation.
+	ActionOperationTypeRemoveFromHostGroup ActionOperationType = 5
+
+	// ActionOperationTypeLinkTemplate links the Templates of the operation to a
+	// discovered host.
+	ActionOperationTypeLinkTemplate ActionOperationType = 6
+
+	// ActionOperationTypeUnlinkTemplate unlinks the Templates of the operation
+	// from a discovered host.
+	ActionOperationTypeUnlinkTemplate ActionOperationType = 7
+
+	// ActionOperationTypeEnableHost enables a discovered host.
+	ActionOperationTypeEnableHost ActionOperationType = 8
+
+	// ActionOperationTypeDisableHost disables a discovered host.
+	ActionOperationTypeDisableHost ActionOperationType = 9
+
+	// ActionOperationTypeSetInventoryMode sets the inventory mode of a discovered
+	// host.
+	ActionOperationTypeSetInventoryMode ActionOperationType = 10
+
+	// ActionOperationTypeNotifyAllInvolved notifies all the users who
+	// received messages about the problem. It is only supported by recovery
+	// operations.
+	ActionOperationTypeNotifyAllInvolved ActionOperationType = 11
+
+	// ActionOperationTypeNotifyAllInvolvedUpdate notifies all the users who
+	// received messages about the problem. It is only supported by update
+	// operations.
+	ActionOperationTypeNotifyAllInvolvedUpdate ActionOperationType = 12
+
+	// ActionOperationTypeAddHostTags adds the Tags of the operation to a
+	// discovered host.
+	ActionOperationTypeAddHostTags ActionOperationType = 13
+
+	// ActionOperationTypeRemoveHostTags removes the Tags of the operation from a
+	// discovered host.
+	ActionOperationTypeRemoveHostTags ActionOperationType = 14
+)
+
+var (
+	actionStatuses = enum{"ActionStatus", map[int]string{
+		int(ActionStatusEnabled):  "enabled",
+		int(ActionStatusDisabled): "disabled",
+	}}
+
+	actionEvalTypes = enum{"ActionEvalType", map[int]string{
+		int(ActionEvalTypeAndOr):  "and_or",
+		int(ActionEvalTypeAnd):    "and",
+		int(ActionEvalTypeOr):     "or",
+		int(ActionEvalTypeCustom): "custom",
+	}}
+
+	actionConditionTypes = enum{"ActionConditionType", map[int]string{
+		int(ActionConditionTypeHostGroup):             "host_group",
+		int(ActionConditionTypeHost):                  "host",
+		int(ActionConditionTypeTrigger):               "trigger",
+		int(ActionConditionTypeEventName):             "event_name",
+		int(ActionConditionTypeTriggerSeverity):       "trigger_severity",
+		int(ActionConditionTypeTimePeriod):            "time_period",
+		int(ActionConditionTypeHostIP):                "host_ip",
+		int(ActionConditionTypeDiscoveredServiceType): "discovered_service_type",
+		int(ActionConditionTypeDiscoveredServicePort): "discovered_service_port",
+		int(ActionConditionTypeDiscoveryStatus):       "discovery_status",
+		int(ActionConditionTypeUptimeDowntime):        "uptime_downtime",
+		int(ActionConditionTypeReceivedValue):         "received_value",
+		int(ActionConditionTypeHostTemplate):          "host_template",
+		int(ActionConditionTypeEventAcknowledged):     "event_acknowledged",
+		int(ActionConditionTypeProblemSuppressed):     "problem_suppressed",
+		int(ActionConditionTypeDiscoveryRule):         "discovery_rule",
+		int(ActionConditionTypeDiscoveryCheck):        "discovery_check",
+		int(ActionConditionTypeProxy):                 "proxy",
+		int(ActionConditionTypeDiscoveryObject):       "discovery_object",
+		int(ActionConditionTypeHostName):              "host_name",
+		int(ActionConditionTypeEventType):             "event_type",
+		int(ActionConditionTypeHostMetadata):          "host_metadata",
+		int(ActionConditionTypeEventTag):              "event_tag",
+		int(ActionConditionTypeEventTagValue):         "event_tag_value",
+		int(ActionConditionTypeService):               "service",
+		int(ActionConditionTypeServiceName):           "service_name",
+	}}
+
+	actionConditionOperators = enum{"ActionConditionOperator", map[int]string{
+		int(ActionConditionOperatorEqual):          "equal",
+		int(ActionConditionOperatorNotEqual):       "not_equal",
+		int(ActionConditionOperatorContains):       "contains",
+		int(ActionConditionOperatorNotContains):    "not_contains",
+		int(ActionConditionOperatorIn):             "in",
+		int(ActionConditionOperatorGreaterOrEqual): "greater_or_equal",
+		int(ActionConditionOperatorLessOrEqual):    "less_or_equal",
+		int(ActionConditionOperatorNotIn):          "not_in",
+		int(ActionConditionOperatorMatches):        "matches",
+		int(ActionConditionOperatorNotMatches):     "not_matches",
+		int(ActionConditionOperatorYes):            "yes",
+		int(ActionConditionOperatorNo):             "no",
+	}}
+
+	actionOperationTypes = enum{"ActionOperationType", map[int]string{
+		int(ActionOperationTypeSendMessage):             "send_message",
+		int(ActionOperationTypeGlobalScript):            "global_script",
+		int(ActionOperationTypeAddHost):                 "add_host",
+		int(ActionOperationTypeRemoveHost):              "remove_host",
+		int(ActionOperationTypeAddToHostGroup):          "add_to_host_group",
+		int(ActionOperationTypeRemoveFromHostGroup):     "remove_from_host_group",
+		int(ActionOperationTypeLinkTemplate):            "link_template",
+		int(ActionOperationTypeUnlinkTemplate):          "unlink_template",
+		int(ActionOperationTypeEnableHost):              "enable_host",
+		int(ActionOperationTypeDisableHost):             "disable_host",
+		int(ActionOperationTypeSetInventoryMode):        "set_inventory_mode",
+		int(ActionOperationTypeNotifyAllInvolved):       "notify_all_involved",
+		int(ActionOperationTypeNotifyAllInvolvedUpdate): "notify_all_involved_update",
+		int(ActionOperationTypeAddHostTags):             "add_host_tags",
+		int(ActionOperationTypeRemoveHostTags):          "remove_host_tags",
+	}}
+)
+
+// String returns the name of the ActionStatus.
+func (s ActionStatus) String() string {
+	return actionStatuses.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ActionStatus) MarshalText() ([]byte, error) {
+	return actionStatuses.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *ActionStatus) UnmarshalText(text []byte) error {
+	return actionStatuses.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s ActionStatus) MarshalJSON() ([]byte, error) {
+	return actionStatuses.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *ActionStatus) UnmarshalJSON(data []byte) error {
+	return actionStatuses.unmarshalJSON(data, (*int)(s))
+}
+
+// String returns the name of the ActionEvalType.
+func (t ActionEvalType) String() string {
+	return actionEvalTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ActionEvalType) MarshalText() ([]byte, error) {
+	return actionEvalTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *ActionEvalType) UnmarshalText(text []byte) error {
+	return actionEvalTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t ActionEvalType) MarshalJSON() ([]byte, error) {
+	return actionEvalTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *ActionEvalType) UnmarshalJSON(data []byte) error {
+	return actionEvalTypes.unmarshalJSON(data, (*int)(t))
+}
+
+// String returns the name of the ActionConditionType.
+func (t ActionConditionType) String() string {
+	return actionConditionTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ActionConditionType) MarshalText() ([]byte, error) {
+	return actionConditionTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *ActionConditionType) UnmarshalText(text []byte) error {
+	return actionConditionTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t ActionConditionType) MarshalJSON() ([]byte, error) {
+	return actionConditionTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *ActionConditionType) UnmarshalJSON(data []byte) error {
+	return actionConditionTypes.unmarshalJSON(data, (*int)(t))
+}
+
+// String returns the name of the ActionConditionOperator.
+func (o ActionConditionOperator) String() string {
+	return actionConditionOperators.format(int(o))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (o ActionConditionOperator) MarshalText() ([]byte, error) {
+	return actionConditionOperators.marshalText(int(o))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (o *ActionConditionOperator) UnmarshalText(text []byte) error {
+	return actionConditionOperators.unmarshalText(text, (*int)(o))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (o ActionConditionOperator) MarshalJSON() ([]byte, error) {
+	return actionConditionOperators.marshalJSON(int(o))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (o *ActionConditionOperator) UnmarshalJSON(data []byte) error {
+	return actionConditionOperators.unmarshalJSON(data, (*int)(o))
+}
+
+// String returns the name of the ActionOperationType.
+func (t ActionOperationType) String() string {
+	return actionOperationTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ActionOperationType) MarshalText() ([]byte, error) {
+	return actionOperationTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *ActionOperationType) UnmarshalText(text []byte) error {
+	return actionOperationTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t ActionOperationType) MarshalJSON() ([]byte, error) {
+	return actionOperationTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *ActionOperationType) UnmarshalJSON(data []byte) error {
+	return actionOperationTypes.unmarshalJSON(data, (*int)(t))
+}
+
 // Action represents a Zabbix Action returned from the Zabbix API.
 //
-// See: https://www.zabbix.com/documentation/2.2/manual/config/notifications/action
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/action/object
 type Action struct {
 	// ActionID is the unique ID of the Action.
-	ActionID string `json:"actionid"`
+	ActionID string `json:"actionid,omitempty"`
 
-	// StepDuration is the interval in seconds between each operation step.
-	StepDuration types.ZBXDuration `json:"esc_period"`
+	// StepDuration is the default interval between each operation step. It
+	// is only supported by Actions of trigger, internal and service events.
+	StepDuration *types.ZBXDuration `json:"esc_period,omitempty"`
 
 	// EvaluationType determines the bitwise logic used to evaluate the Actions
 	// conditions.
 	//
-	// EvaluationType must be one of the ActionEvalType constants.
-	//
-	// EvaluationType is only supported up to Zabbix v2.2.
-	// EvaluationType Removed in Zabbix 5.0
-	EvaluationType int `json:"evaltype,string"`
+	// Deprecated: Removed in Zabbix 5.0, use Filter.EvalType.
+	EvaluationType ActionEvalType `json:"evaltype,omitempty"`
 
 	// EventType is the type of Events that this Action will handle.
 	//
 	// Source must be one of the EventSource constants.
-	EventType int `json:"eventsource,string"`
+	EventType EventSource `json:"eventsource"`
 
 	// Name is the name of the Action.
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
+
+	// Status is whether the Action is enabled. Actions are created enabled
+	// and keep their status on update if nil.
+	Status *ActionStatus `json:"status,omitempty"`
+
+	// Enabled determines whether the Action is enabled or disabled. It is
+	// set from Status when decoding, and sets Status when encoding if true
+	// and Status is nil.
+	//
+	// Deprecated: use Status, which can also disable an Action.
+	Enabled types.ZBXBoolean `json:"-"`
+
+	// PauseSymptoms is whether escalations are paused for symptom problems.
+	// It is only supported by Actions of trigger events since Zabbix 6.4.
+	PauseSymptoms *types.ZBXBoolean `json:"pause_symptoms,omitempty"`
+
+	// PauseSuppressed is whether escalations are paused during maintenance
+	// periods. It is only supported by Actions of trigger events.
+	PauseSuppressed *types.ZBXBoolean `json:"pause_suppressed,omitempty"`
+
+	// NotifyIfCanceled is whether to notify when escalations are canceled. It
+	// is only supported by Actions of trigger events.
+	NotifyIfCanceled *types.ZBXBoolean `json:"notify_if_canceled,omitempty"`
+
+	// Filter is the filter selecting the events handled by the Action. It is
+	// filled when SelectFilter is used on ActionGetParams.
+	Filter *ActionFilter `json:"filter,omitempty"`
+
+	// Operations are the operations which will be executed for this Action.
+	// They are filled when SelectOperations is used on ActionGetParams.
+	Operations []ActionOperation `json:"operations,omitempty"`
+
+	// RecoveryOperations are the operations executed when the problem is
+	// resolved. They are filled when SelectRecoveryOperations is used on
+	// ActionGetParams.
+	RecoveryOperations []ActionOperation `json:"recovery_operations,omitempty"`
+
+	// UpdateOperations are the operations executed when the problem is
+	// updated by an operator. They are filled when SelectUpdateOperations is
+	// used on ActionGetParams.
+	UpdateOperations []ActionOperation `json:"update_operations,omitempty"`
 
 	// ProblemMessageBody is the message body text to be submitted for this
 	// Action.
 	//
-	// ProblemMessageBody Removed in Zabbix 5.0
-	ProblemMessageBody string `json:"def_longdata"`
+	// Deprecated: Removed in Zabbix 5.0, use ActionOperation.Message.
+	ProblemMessageBody string `json:"def_longdata,omitempty"`
 
 	// ProblemMessageSubject is the short summary text to be submitted for this
 	// Action.
 	//
-	// ProblemMessageSubject Removed in Zabbix 5.0
-	ProblemMessageSubject string `json:"def_shortdata"`
+	// Deprecated: Removed in Zabbix 5.0, use ActionOperation.Message.
+	ProblemMessageSubject string `json:"def_shortdata,omitempty"`
 
 	// RecoveryMessageBody is the message body text to be submitted for this
 	// Action.
 	//
-	// RecoveryMessageBody Removed in Zabbix 5.0
-	RecoveryMessageBody string `json:"r_longdata"`
+	// Deprecated: Removed in Zabbix 5.0, use RecoveryOperations.
+	RecoveryMessageBody string `json:"r_longdata,omitempty"`
 
 	// RecoveryMessageSubject is the short summary text to be submitted for this
 	// Action.
 	//
-	// RecoveryMessageSubject Removed in Zabbix 5.0
-	RecoveryMessageSubject string `json:"r_shortdata"`
+	// Deprecated: Removed in Zabbix 5.0, use RecoveryOperations.
+	RecoveryMessageSubject string `json:"r_shortdata,omitempty"`
 
 	// RecoveryMessageEnabled determines whether recovery messages will be
 	// submitted for the Action when the source problem is resolved.
 	//
-	// RecoveryMessageEnabled Removed in Zabbix 5.0
-	RecoveryMessageEnabled types.ZBXBoolean `json:"recovery_msg,string"`
+	// Deprecated: Removed in Zabbix 5.0, use RecoveryOperations.
+	RecoveryMessageEnabled *types.ZBXBoolean `json:"recovery_msg,omitempty"`
+}
+
+// actionFields is an Action without methods, to encode and decode its fields.
+type actionFields Action
+
+// fields returns the fields of the Action to encode, with the status set from
+// the deprecated Enabled field if Status is nil.
+func (a Action) fields() actionFields {
+	if a.Status == nil && a.Enabled {
+		status := ActionStatusEnabled
+		a.Status = &status
+	}
+	return actionFields(a)
+}
+
+// MarshalJSON implements json.Marshaler. The deprecated Enabled field is
+// encoded as the status if Status is nil.
+func (a Action) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.fields())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The deprecated Enabled field is
+// set from the status.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*actionFields)(a)); err != nil {
+		return err
+	}
+	a.Enabled = a.Status != nil && *a.Status == ActionStatusEnabled
+	return nil
+}
+
+// ActionFilter is the filter of an Action.
+type ActionFilter struct {
+	// EvalType is the evaluation method of the filter conditions.
+	EvalType ActionEvalType `json:"evaltype"`
 
-	// Enabled determines whether the Action is enabled or disabled.
-	Enabled types.ZBXBoolean `json:"status,string"`
+	// Formula is the custom expression used with ActionEvalTypeCustom,
+	// referring to conditions by their FormulaID.
+	Formula string `json:"formula,omitempty"`
 
-	// Conditions are the conditions which must be met for this Action to
+	// EvalFormula is the generated expression used to evaluate the filter.
+	EvalFormula string `json:"eval_formula,omitempty"`
+
+	// Conditions are the conditions which must be met for the Action to
 	// execute.
-	Conditions []ActionCondition
+	Conditions []ActionCondition `json:"conditions"`
+}
+
+// ActionCondition is a condition of an ActionFilter.
+type ActionCondition struct {
+	// ConditionID is the unique ID of the condition.
+	ConditionID string `json:"conditionid,omitempty"`
+
+	ConditionType ActionConditionType `json:"conditiontype"`
+
+	// Value is the value to compare with, such as a host group ID for
+	// ActionConditionTypeHostGroup.
+	Value string `json:"value"`
+
+	// Value2 is the secondary value to compare with, such as the tag name
+	// for ActionConditionTypeEventTagValue.
+	Value2 string `json:"value2,omitempty"`
+
+	Operator ActionConditionOperator `json:"operator"`
+
+	// FormulaID is the ID of the condition in a custom filter Formula.
+	FormulaID string `json:"formulaid,omitempty"`
+}
+
+// ActionOperation is an operation, recovery operation or update operation of
+// an Action.
+//
+// Recovery and update operations only support the message and command
+// fields.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/action/object#action-operation
+type ActionOperation struct {
+	// OperationID is the unique ID of the operation.
+	OperationID string `json:"operationid,omitempty"`
+
+	OperationType ActionOperationType `json:"operationtype"`
+
+	// StepDuration is the interval of the escalation steps of the operation,
+	// overriding the default of the Action if set.
+	StepDuration *types.ZBXDuration `json:"esc_period,omitempty"`
+
+	// StepFrom is the first escalation step the operation is executed on.
+	StepFrom types.ZBXInt `json:"esc_step_from,omitempty"`
+
+	// StepTo is the last escalation step the operation is executed on. Zero
+	// means infinity.
+	StepTo types.ZBXInt `json:"esc_step_to,omitempty"`
+
+	// EvalType is the evaluation method of the operation Conditions.
+	// ActionEvalTypeCustom is not supported.
+	EvalType ActionEvalType `json:"evaltype,omitempty"`
+
+	// Conditions are the conditions for the operation to be executed.
+	Conditions []ActionOperationCondition `json:"opconditions,omitempty"`
+
+	// Message is the message sent by ActionOperationTypeSendMessage
+	// operations.
+	Message *ActionOperationMessage `json:"opmessage,omitempty"`
+
+	// MessageUserGroups are the user groups the Message is sent to.
+	MessageUserGroups []ActionOperationUserGroup `json:"opmessage_grp,omitempty"`
+
+	// MessageUsers are the users the Message is sent to.
+	MessageUsers []ActionOperationUser `json:"opmessage_usr,omitempty"`
 
-	// Operations are the operations which will be exectuted for this Action.
-	Operations []ActionOperation
+	// Command is the script run by ActionOperationTypeGlobalScript
+	// operations.
+	Command *ActionOperationCommand `json:"opcommand,omitempty"`
+
+	// CommandHostGroups are the host groups the Command is run on.
+	CommandHostGroups []ActionOperationHostGroup `json:"opcommand_grp,omitempty"`
+
+	// CommandHosts are the hosts the Command is run on. The host ID 0 is the
+	// host of the event.
+	CommandHosts []ActionOperationHost `json:"opcommand_hst,omitempty"`
+
+	// HostGroups are the host groups discovered hosts are added to or
+	// removed from.
+	HostGroups []ActionOperationHostGroup `json:"opgroup,omitempty"`
+
+	// Templates are the templates linked to or unlinked from discovered
+	// hosts.
+	Templates []ActionOperationTemplate `json:"optemplate,omitempty"`
+
+	// Inventory is the inventory mode set on discovered hosts.
+	Inventory *ActionOperationInventory `json:"opinventory,omitempty"`
+
+	// Tags are the tags added to or removed from discovered hosts.
+	Tags []ActionOperationTag `json:"optag,omitempty"`
+}
+
+// ActionOperationCondition is a condition of an ActionOperation. Only
+// ActionConditionTypeEventAcknowledged is supported.
+type ActionOperationCondition struct {
+	// ConditionID is the unique ID of the condition.
+	ConditionID string `json:"opconditionid,omitempty"`
+
+	ConditionType ActionConditionType `json:"conditiontype"`
+
+	Value string `json:"value"`
+
+	Operator ActionConditionOperator `json:"operator"`
+}
+
+// ActionOperationMessage is the message sent by an ActionOperation.
+type ActionOperationMessage struct {
+	// DefaultMessage is whether the subject and message of the media type
+	// are used instead of Subject and Message.
+	DefaultMessage types.ZBXBoolean `json:"default_msg"`
+
+	// MediaTypeID is the ID of the media type the message is sent with.
+	// Empty or 0 sends the message with all the media types.
+	MediaTypeID string `json:"mediatypeid,omitempty"`
+
+	Subject string `json:"subject,omitempty"`
+
+	Message string `json:"message,omitempty"`
+}
+
+// ActionOperationCommand is the script run by an ActionOperation.
+type ActionOperationCommand struct {
+	ScriptID string `json:"scriptid"`
+}
+
+// ActionOperationHostGroup is a host group of an ActionOperation.
+type ActionOperationHostGroup struct {
+	GroupID string `json:"groupid"`
+}
+
+// ActionOperationHost is a host of an ActionOperation.
+type ActionOperationHost struct {
+	HostID string `json:"hostid"`
+}
+
+// ActionOperationUserGroup is a user group notified by an ActionOperation.
+type ActionOperationUserGroup struct {
+	UserGroupID string `json:"usrgrpid"`
+}
+
+// ActionOperationUser is a user notified by an ActionOperation.
+type ActionOperationUser struct {
+	UserID string `json:"userid"`
+}
+
+// ActionOperationTemplate is a template of an ActionOperation.
+type ActionOperationTemplate struct {
+	TemplateID string `json:"templateid"`
 }
 
-// ActionCondition is action condition
-type ActionCondition struct{}
+// ActionOperationInventory is the inventory mode set by an ActionOperation.
+type ActionOperationInventory struct {
+	InventoryMode types.ZBXInt `json:"inventory_mode"`
+}
 
-// ActionOperation is action operation
-type ActionOperation struct{}
+// ActionOperationTag is a host tag added or removed by an ActionOperation.
+type ActionOperationTag struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value,omitempty"`
+}
 
 // ActionGetParams is query params for action.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/action/get
 type ActionGetParams struct {
 	GetParameters
+
+	// ActionIDs filters search results to Actions with the given IDs.
+	ActionIDs []string `json:"actionids,omitempty"`
+
+	// GroupIDs filters search results to Actions using the given host groups
+	// in their conditions or operations.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// HostIDs filters search results to Actions using the given hosts in
+	// their conditions or operations.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// TriggerIDs filters search results to Actions using the given triggers
+	// in their conditions.
+	TriggerIDs []string `json:"triggerids,omitempty"`
+
+	// MediaTypeIDs filters search results to Actions sending messages with
+	// the given media types.
+	MediaTypeIDs []string `json:"mediatypeids,omitempty"`
+
+	// UserGroupIDs filters search results to Actions sending messages to the
+	// given user groups.
+	UserGroupIDs []string `json:"usrgrpids,omitempty"`
+
+	// UserIDs filters search results to Actions sending messages to the
+	// given users.
+	UserIDs []string `json:"userids,omitempty"`
+
+	// ScriptIDs filters search results to Actions running the given scripts.
+	ScriptIDs []string `json:"scriptids,omitempty"`
+
+	// SelectFilter returns the filter of each Action.
+	SelectFilter SelectQuery `json:"selectFilter,omitempty"`
+
+	// SelectOperations returns the operations of each Action.
+	SelectOperations SelectQuery `json:"selectOperations,omitempty"`
+
+	// SelectRecoveryOperations returns the recovery operations of each
+	// Action.
+	SelectRecoveryOperations SelectQuery `json:"selectRecoveryOperations,omitempty"`
+
+	// SelectUpdateOperations returns the update operations of each Action.
+	SelectUpdateOperations SelectQuery `json:"selectUpdateOperations,omitempty"`
 }
 
 // GetActions queries the Zabbix API for Actions matching the given search
@@ -115,3 +829,40 @@ func (c *Session) GetActions(params ActionGetParams) ([]Action, error) {
 
 	return actions, nil
 }
+
+// CreateActions creates Actions.
+// Returns a list of IDs of the created Actions.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/action/create
+func (c *Session) CreateActions(actions ...Action) ([]string, error) {
+	return c.GetIDs("action.create", actions, "actionids")
+}
+
+// actionUpdate is an Action sent to action.update, which does not accept the
+// event source of Actions.
+type actionUpdate struct {
+	actionFields
+	EventType *EventSource `json:"eventsource,omitempty"`
+}
+
+// UpdateActions updates Actions. The operations and filter of an Action
+// replace the existing ones if set. The event source of Actions cannot be
+// changed and is ignored.
+// Returns a list of IDs of the updated Actions.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/action/update
+func (c *Session) UpdateActions(actions ...Action) ([]string, error) {
+	updates := make([]actionUpdate, len(actions))
+	for i, action := range actions {
+		updates[i] = actionUpdate{actionFields: action.fields()}
+	}
+	return c.GetIDs("action.update", updates, "actionids")
+}
+
+// DeleteActions deletes Actions.
+// Returns a list of IDs of the deleted Actions.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/action/delete
+func (c *Session) DeleteActions(actionIDs ...string) ([]string, error) {
+	return c.GetIDs("action.delete", actionIDs, "actionids")
+}
@@ -0,0 +1,194 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+func TestGetActions(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("action.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{
+			"actionid":"3","name":"Report problems to Zabbix administrators","eventsource":"0","status":"0",
+			"esc_period":"1h","pause_symptoms":"1","pause_suppressed":"1","notify_if_canceled":"1",
+			"filter":{"evaltype":"3","formula":"A and (B or C)","eval_formula":"A and (B or C)","conditions":[
+				{"conditiontype":"4","operator":"5","value":"3","value2":"","formulaid":"A"},
+				{"conditiontype":"0","operator":"0","value":"2","value2":"","formulaid":"B"},
+				{"conditiontype":"26","operator":"2","value":"mysql","value2":"service","formulaid":"C"}
+			]},
+			"operations":[
+				{"operationid":"3","actionid":"3","operationtype":"0","esc_period":"0","esc_step_from":"1","esc_step_to":"1","evaltype":"0",
+					"opconditions":[{"opconditionid":"1","operationid":"3","conditiontype":"14","operator":"0","value":"0"}],
+					"opmessage":{"default_msg":"1","subject":"","message":"","mediatypeid":"0"},
+					"opmessage_grp":[{"operationid":"3","usrgrpid":"7"}],"opmessage_usr":[]},
+				{"operationid":"4","actionid":"3","operationtype":"1","esc_period":"10m","esc_step_from":"2","esc_step_to":"0","evaltype":"0",
+					"opconditions":[],"opcommand":{"scriptid":"5"},"opcommand_hst":[{"hostid":"0"}],"opcommand_grp":[{"groupid":"4"}]}
+			],
+			"recovery_operations":[{"operationid":"5","actionid":"3","operationtype":"11","opmessage":{"default_msg":"0","subject":"Resolved","message":"{EVENT.NAME}","mediatypeid":"1"}}],
+			"update_operations":[{"operationid":"6","actionid":"3","operationtype":"12","opmessage":{"default_msg":"1","subject":"","message":"","mediatypeid":"0"}}]
+		}]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	actions, err := session.GetActions(zabbix.ActionGetParams{
+		ActionIDs:                []string{"3"},
+		SelectFilter:             zabbix.SelectExtendedOutput,
+		SelectOperations:         zabbix.SelectExtendedOutput,
+		SelectRecoveryOperations: zabbix.SelectExtendedOutput,
+		SelectUpdateOperations:   zabbix.SelectExtendedOutput,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"actionids":["3"],"selectFilter":"extend","selectOperations":"extend","selectRecoveryOperations":"extend","selectUpdateOperations":"extend"}`
+	if params := string(server.Calls()[0].Params.(json.RawMessage)); params != expected {
+		t.Errorf("Expected params %s but got %s", expected, params)
+	}
+
+	action := actions[0]
+	if action.EventType != zabbix.EventSourceTrigger || action.Status == nil || *action.Status != zabbix.ActionStatusEnabled || action.StepDuration == nil || action.StepDuration.Duration != time.Hour {
+		t.Errorf("Unexpected action: %+v", action)
+	}
+	if !action.Enabled {
+		t.Errorf("Expected the deprecated Enabled field to be set from the status")
+	}
+	if action.PauseSuppressed == nil || !bool(*action.PauseSuppressed) {
+		t.Errorf("Expected escalations to be paused during maintenance but got %v", action.PauseSuppressed)
+	}
+
+	filter := action.Filter
+	if filter == nil || filter.EvalType != zabbix.ActionEvalTypeCustom || filter.Formula != "A and (B or C)" || len(filter.Conditions) != 3 {
+		t.Fatalf("Unexpected filter: %+v", filter)
+	}
+	expectCondition := zabbix.ActionCondition{
+		ConditionType: zabbix.ActionConditionTypeEventTagValue,
+		Operator:      zabbix.ActionConditionOperatorContains,
+		Value:         "mysql",
+		Value2:        "service",
+		FormulaID:     "C",
+	}
+	if filter.Conditions[2] != expectCondition {
+		t.Errorf("Expected condition %+v but got %+v", expectCondition, filter.Conditions[2])
+	}
+
+	if len(action.Operations) != 2 {
+		t.Fatalf("Unexpected operations: %+v", action.Operations)
+	}
+	message := action.Operations[0]
+	if message.OperationType != zabbix.ActionOperationTypeSendMessage || message.Message == nil || !bool(message.Message.DefaultMessage) ||
+		!reflect.DeepEqual(message.MessageUserGroups, []zabbix.ActionOperationUserGroup{{UserGroupID: "7"}}) {
+		t.Errorf("Unexpected message operation: %+v", message)
+	}
+	if len(message.Conditions) != 1 || message.Conditions[0].ConditionType != zabbix.ActionConditionTypeEventAcknowledged {
+		t.Errorf("Unexpected operation conditions: %+v", message.Conditions)
+	}
+
+	command := action.Operations[1]
+	if command.OperationType != zabbix.ActionOperationTypeGlobalScript || command.Command == nil || command.Command.ScriptID != "5" ||
+		command.StepFrom != 2 || command.StepTo != 0 || command.StepDuration == nil || command.StepDuration.Duration != 10*time.Minute ||
+		!reflect.DeepEqual(command.CommandHosts, []zabbix.ActionOperationHost{{HostID: "0"}}) ||
+		!reflect.DeepEqual(command.CommandHostGroups, []zabbix.ActionOperationHostGroup{{GroupID: "4"}}) {
+		t.Errorf("Unexpected command operation: %+v", command)
+	}
+
+	if len(action.RecoveryOperations) != 1 || action.RecoveryOperations[0].OperationType != zabbix.ActionOperationTypeNotifyAllInvolved ||
+		action.RecoveryOperations[0].Message.Subject != "Resolved" {
+		t.Errorf("Unexpected recovery operations: %+v", action.RecoveryOperations)
+	}
+	if len(action.UpdateOperations) != 1 || action.UpdateOperations[0].OperationType != zabbix.ActionOperationTypeNotifyAllInvolvedUpdate {
+		t.Errorf("Unexpected update operations: %+v", action.UpdateOperations)
+	}
+}
+
+func TestActionMethods(t *testing.T) {
+	server := test.NewServer(t)
+	for _, method := range []string{"action.create", "action.update", "action.delete"} {
+		server.Handle(method, func(params json.RawMessage) (interface{}, error) {
+			return map[string][]string{"actionids": {"7"}}, nil
+		})
+	}
+	session := server.Session(t, "7.0.0")
+
+	ids, err := session.CreateActions(zabbix.Action{
+		Name:      "Add discovered Linux servers",
+		EventType: zabbix.EventSourceDiscoveryRule,
+		Filter: &zabbix.ActionFilter{
+			EvalType: zabbix.ActionEvalTypeAnd,
+			Conditions: []zabbix.ActionCondition{
+				{ConditionType: zabbix.ActionConditionTypeReceivedValue, Operator: zabbix.ActionConditionOperatorContains, Value: "Linux"},
+			},
+		},
+		Operations: []zabbix.ActionOperation{
+			{OperationType: zabbix.ActionOperationTypeAddHost},
+			{OperationType: zabbix.ActionOperationTypeAddToHostGroup, HostGroups: []zabbix.ActionOperationHostGroup{{GroupID: "2"}}},
+			{OperationType: zabbix.ActionOperationTypeLinkTemplate, Templates: []zabbix.ActionOperationTemplate{{TemplateID: "10001"}}},
+		},
+	})
+	if err != nil || !reflect.DeepEqual(ids, []string{"7"}) {
+		t.Fatalf("Unexpected create result %v: %v", ids, err)
+	}
+
+	expected := `[{"eventsource":"1","name":"Add discovered Linux servers",` +
+		`"filter":{"evaltype":"1","conditions":[{"conditiontype":"12","value":"Linux","operator":"2"}]},` +
+		`"operations":[{"operationtype":"2"},{"operationtype":"4","opgroup":[{"groupid":"2"}]},{"operationtype":"6","optemplate":[{"templateid":"10001"}]}]}]`
+	if params := string(server.Calls()[0].Params.(json.RawMessage)); params != expected {
+		t.Errorf("Expected create params %s but got %s", expected, params)
+	}
+
+	disabled := zabbix.ActionStatusDisabled
+	duration := types.ZBXDuration{Duration: 30 * time.Minute}
+	_, err = session.UpdateActions(zabbix.Action{
+		ActionID:     "7",
+		EventType:    zabbix.EventSourceTrigger,
+		Status:       &disabled,
+		StepDuration: &duration,
+		RecoveryOperations: []zabbix.ActionOperation{{
+			OperationType:     zabbix.ActionOperationTypeSendMessage,
+			Message:           &zabbix.ActionOperationMessage{DefaultMessage: true, MediaTypeID: "1"},
+			MessageUsers:      []zabbix.ActionOperationUser{{UserID: "1"}},
+			MessageUserGroups: []zabbix.ActionOperationUserGroup{{UserGroupID: "7"}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the event source cannot be updated
+	expected = `[{"actionid":"7","esc_period":"30m","status":"1","recovery_operations":[{"operationtype":"0",` +
+		`"opmessage":{"default_msg":"1","mediatypeid":"1"},"opmessage_grp":[{"usrgrpid":"7"}],"opmessage_usr":[{"userid":"1"}]}]}]`
+	if params := string(server.Calls()[1].Params.(json.RawMessage)); params != expected {
+		t.Errorf("Expected update params %s but got %s", expected, params)
+	}
+
+	// a partial update must not enable the disabled action
+	if _, err := session.UpdateActions(zabbix.Action{ActionID: "7", Name: "Notify admins"}); err != nil {
+		t.Fatal(err)
+	}
+	expected = `[{"actionid":"7","name":"Notify admins"}]`
+	if params := string(server.Calls()[2].Params.(json.RawMessage)); params != expected {
+		t.Errorf("Expected partial update params %s but got %s", expected, params)
+	}
+
+	// the deprecated Enabled field still enables the action
+	if _, err := session.UpdateActions(zabbix.Action{ActionID: "7", Enabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	expected = `[{"actionid":"7","status":"0"}]`
+	if params := string(server.Calls()[3].Params.(json.RawMessage)); params != expected {
+		t.Errorf("Expected enabling update params %s but got %s", expected, params)
+	}
+
+	if ids, err := session.DeleteActions("7"); err != nil || ids[0] != "7" {
+		t.Errorf("Unexpected delete result %v: %v", ids, err)
+	}
+	if params := string(server.Calls()[4].Params.(json.RawMessage)); params != `["7"]` {
+		t.Errorf("Unexpected delete params %s", params)
+	}
+}
@@ -0,0 +1,363 @@
+// Package active implements the active checks protocol of the Zabbix agent,
+// so a Go program can appear to a Zabbix server or proxy as an active agent:
+// it requests the list of active checks of a Host and sends the collected
+// values back.
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/appendix/protocols/active_agent
+package active
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/NexonSU/go-zabbix/sender"
+	"github.com/NexonSU/go-zabbix/zbxd"
+)
+
+const (
+	// DefaultVersion is the agent version reported to the server.
+	DefaultVersion = "7.0.0"
+
+	// VariantAgent2 is the agent variant of Zabbix agent 2.
+	VariantAgent2 = 2
+
+	// StateNotSupported marks a Value as an error message for an unsupported
+	// check.
+	StateNotSupported = 1
+)
+
+// Check is an active check assigned to the Host.
+type Check struct {
+	// Key is the item key with macros expanded.
+	Key string `json:"key"`
+
+	// KeyOrig is the item key as configured.
+	KeyOrig string `json:"key_orig,omitempty"`
+
+	// ItemID is the ID of the Item.
+	ItemID uint64 `json:"itemid"`
+
+	// Delay is the update interval of the Item.
+	Delay string `json:"delay"`
+
+	// LastLogSize is the last processed size of a log file.
+	LastLogSize int64 `json:"lastlogsize"`
+
+	// MTime is the last processed modification time of a log file.
+	MTime int64 `json:"mtime"`
+
+	// Timeout is the timeout of the check, sent by Zabbix 7.0 and later.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// Regexp is a global regular expression referenced by log checks.
+type Regexp struct {
+	Name           string `json:"name"`
+	Expression     string `json:"expression"`
+	ExpressionType int    `json:"expression_type"`
+	Delimiter      string `json:"exp_delimiter"`
+	CaseSensitive  int    `json:"case_sensitive"`
+}
+
+// Value is a collected value of an active check.
+type Value struct {
+	// ID is the sequence number of the value within the session. It is set
+	// by SendValues.
+	ID uint64 `json:"id"`
+
+	// ItemID is the ID of the Item, as given in Check.ItemID.
+	ItemID uint64 `json:"itemid,omitempty"`
+
+	// Host is the technical name of the Host. Client.Host is used if empty.
+	Host string `json:"host"`
+
+	// Key is the item key, as given in Check.Key.
+	Key string `json:"key"`
+
+	// Value is the collected value, or the error message if State is
+	// StateNotSupported.
+	Value string `json:"value"`
+
+	// State is StateNotSupported if the check failed.
+	State int `json:"state,omitempty"`
+
+	// LastLogSize is the processed size of a log file.
+	LastLogSize *int64 `json:"lastlogsize,omitempty"`
+
+	// MTime is the processed modification time of a log file.
+	MTime *int64 `json:"mtime,omitempty"`
+
+	// Clock is the Unix time of the value.
+	Clock int64 `json:"clock"`
+
+	// Nanoseconds is the nanoseconds part of the time of the value.
+	Nanoseconds int64 `json:"ns"`
+}
+
+// NewValue returns a Value of the given check, timestamped with the current
+// time.
+func NewValue(check Check, value string) Value {
+	now := time.Now()
+
+	return Value{
+		ItemID:      check.ItemID,
+		Key:         check.Key,
+		Value:       value,
+		Clock:       now.Unix(),
+		Nanoseconds: int64(now.Nanosecond()),
+	}
+}
+
+type activeChecksRequest struct {
+	Request        string `json:"request"`
+	Host           string `json:"host"`
+	HostMetadata   string `json:"host_metadata,omitempty"`
+	HostInterface  string `json:"hostinterface,omitempty"`
+	IP             string `json:"ip,omitempty"`
+	Port           int    `json:"port,omitempty"`
+	Version        string `json:"version"`
+	Variant        int    `json:"variant"`
+	Session        string `json:"session"`
+	ConfigRevision int64  `json:"config_revision"`
+}
+
+type activeChecksResponse struct {
+	Response       string   `json:"response"`
+	Info           string   `json:"info"`
+	ConfigRevision int64    `json:"config_revision"`
+	Data           []Check  `json:"data"`
+	Regexp         []Regexp `json:"regexp"`
+}
+
+type agentDataRequest struct {
+	Request     string  `json:"request"`
+	Session     string  `json:"session"`
+	Data        []Value `json:"data"`
+	Clock       int64   `json:"clock"`
+	Nanoseconds int64   `json:"ns"`
+	Version     string  `json:"version"`
+	Variant     int     `json:"variant"`
+}
+
+type heartbeatRequest struct {
+	Request       string `json:"request"`
+	Host          string `json:"host"`
+	HeartbeatFreq int    `json:"heartbeat_freq"`
+	Version       string `json:"version"`
+	Variant       int    `json:"variant"`
+}
+
+// A Client is an emulated active agent of a single Host.
+type Client struct {
+	// Address is the host and port of the Zabbix server or proxy.
+	Address string
+
+	// Host is the technical name of the Host.
+	Host string
+
+	// HostMetadata is sent for active agent autoregistration.
+	HostMetadata string
+
+	// HostInterface is the DNS name sent for active agent autoregistration.
+	HostInterface string
+
+	// ListenIP and ListenPort are the passive interface sent for active
+	// agent autoregistration.
+	ListenIP   string
+	ListenPort int
+
+	// Version is the agent version reported to the server.
+	Version string
+
+	// Variant is the agent variant reported to the server.
+	Variant int
+
+	// Timeout is the timeout for connecting, sending and receiving.
+	Timeout time.Duration
+
+	// Compress enables compression of the sent data.
+	Compress bool
+
+	// Session identifies the agent instance to the server, which uses it
+	// with Value.ID to discard duplicate values.
+	Session string
+
+	mu             sync.Mutex
+	lastID         uint64
+	configRevision int64
+	checks         []Check
+	regexps        []Regexp
+}
+
+// New returns a Client for the given Host, connected to the given server or
+// proxy address. sender.DefaultPort is used if address has no port.
+func New(address, host string) *Client {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, sender.DefaultPort)
+	}
+
+	return &Client{
+		Address: address,
+		Host:    host,
+		Version: DefaultVersion,
+		Variant: VariantAgent2,
+		Timeout: sender.DefaultTimeout,
+		Session: newSessionID(),
+	}
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// do sends the given request and decodes the response into v, if v is not
+// nil.
+func (c *Client) do(req interface{}, v interface{}) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", c.Address, c.Timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if c.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(c.Timeout))
+	}
+
+	if err := zbxd.Write(conn, b, c.Compress); err != nil {
+		return fmt.Errorf("Error sending request: %v", err)
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	b, err = zbxd.Read(conn, 0)
+	if err != nil {
+		return fmt.Errorf("Error reading response: %v", err)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("Error decoding JSON response body: %v", err)
+	}
+
+	return nil
+}
+
+// ActiveChecks requests the active checks of the Host. The last received
+// list is returned if the configuration did not change on the server.
+func (c *Client) ActiveChecks() ([]Check, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	req := activeChecksRequest{
+		Request:        "active checks",
+		Host:           c.Host,
+		HostMetadata:   c.HostMetadata,
+		HostInterface:  c.HostInterface,
+		IP:             c.ListenIP,
+		Port:           c.ListenPort,
+		Version:        c.Version,
+		Variant:        c.Variant,
+		Session:        c.Session,
+		ConfigRevision: c.configRevision,
+	}
+
+	var resp activeChecksResponse
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Response != "success" {
+		return nil, fmt.Errorf("Active checks request failed: %s %s", resp.Response, resp.Info)
+	}
+
+	// data is omitted if the configuration revision did not change
+	if resp.Data != nil || resp.ConfigRevision != c.configRevision {
+		c.checks = resp.Data
+		c.regexps = resp.Regexp
+	}
+	c.configRevision = resp.ConfigRevision
+
+	return append([]Check{}, c.checks...), nil
+}
+
+// Regexps returns the global regular expressions received with the last
+// active checks.
+func (c *Client) Regexps() []Regexp {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Regexp{}, c.regexps...)
+}
+
+// SendValues sends collected values to the server and returns its response.
+// Values are numbered in the session in the order they are given.
+//
+// An error is returned if there was a network or protocol error, or if the
+// server did not respond with "success".
+func (c *Client) SendValues(values ...Value) (*sender.Response, error) {
+	c.mu.Lock()
+	data := make([]Value, len(values))
+	for i, value := range values {
+		c.lastID++
+		value.ID = c.lastID
+		if value.Host == "" {
+			value.Host = c.Host
+		}
+		data[i] = value
+	}
+	c.mu.Unlock()
+
+	now := time.Now()
+	req := agentDataRequest{
+		Request:     "agent data",
+		Session:     c.Session,
+		Data:        data,
+		Clock:       now.Unix(),
+		Nanoseconds: int64(now.Nanosecond()),
+		Version:     c.Version,
+		Variant:     c.Variant,
+	}
+
+	var resp sender.Response
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Response != "success" {
+		return &resp, fmt.Errorf("Agent data request failed: %s %s", resp.Response, resp.Info)
+	}
+
+	if err := resp.ParseInfo(); err != nil {
+		return &resp, err
+	}
+
+	return &resp, nil
+}
+
+// Heartbeat notifies the server that the agent is alive, so the Host stays
+// available. The server expects a heartbeat at the given frequency and does
+// not respond.
+func (c *Client) Heartbeat(freq time.Duration) error {
+	req := heartbeatRequest{
+		Request:       "active check heartbeat",
+		Host:          c.Host,
+		HeartbeatFreq: int(freq / time.Second),
+		Version:       c.Version,
+		Variant:       c.Variant,
+	}
+
+	return c.do(req, nil)
+}
@@ -0,0 +1,130 @@
+package active
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestActiveChecks(t *testing.T) {
+	var requests []activeChecksRequest
+	address := test.NewTCPServer(t, false, func(req []byte) []byte {
+		var r activeChecksRequest
+		if err := json.Unmarshal(req, &r); err != nil {
+			t.Errorf("Error decoding request: %v", err)
+		}
+		requests = append(requests, r)
+
+		if r.ConfigRevision == 2 {
+			return []byte(`{"response":"success","config_revision":2}`)
+		}
+
+		return []byte(`{"response":"success","config_revision":2,"data":[{"key":"custom.queue[orders]","key_orig":"custom.queue[{$QUEUE}]","itemid":1234,"delay":"30s","lastlogsize":0,"mtime":0,"timeout":"3s"}],"regexp":[{"name":"errors","expression":"^ERROR","expression_type":3,"exp_delimiter":",","case_sensitive":1}]}`)
+	})
+
+	client := New(address, "collector01")
+	client.HostMetadata = "go-collector"
+
+	for i := 0; i < 2; i++ {
+		checks, err := client.ActiveChecks()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(checks) != 1 || checks[0].ItemID != 1234 || checks[0].Key != "custom.queue[orders]" {
+			t.Errorf("Unexpected checks %+v", checks)
+		}
+	}
+
+	if requests[0].Request != "active checks" || requests[0].Host != "collector01" || requests[0].HostMetadata != "go-collector" {
+		t.Errorf("Unexpected request %+v", requests[0])
+	}
+
+	if requests[0].Session == "" || requests[0].Session != requests[1].Session {
+		t.Errorf("Expected a constant session, got %q and %q", requests[0].Session, requests[1].Session)
+	}
+
+	if requests[1].ConfigRevision != 2 {
+		t.Errorf("Expected config revision 2 on the second request, got %d", requests[1].ConfigRevision)
+	}
+
+	if regexps := client.Regexps(); len(regexps) != 1 || regexps[0].Name != "errors" {
+		t.Errorf("Unexpected regexps %+v", regexps)
+	}
+}
+
+func TestSendValues(t *testing.T) {
+	var requests []agentDataRequest
+	address := test.NewTCPServer(t, true, func(req []byte) []byte {
+		var r agentDataRequest
+		if err := json.Unmarshal(req, &r); err != nil {
+			t.Errorf("Error decoding request: %v", err)
+		}
+		requests = append(requests, r)
+
+		return []byte(`{"response":"success","info":"processed: 2; failed: 0; total: 2; seconds spent: 0.003534"}`)
+	})
+
+	client := New(address, "collector01")
+	client.Compress = true
+	check := Check{Key: "custom.queue[orders]", ItemID: 1234}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.SendValues(
+			NewValue(check, "17"),
+			Value{ItemID: 1235, Key: "custom.broken", Value: "Cannot connect", State: StateNotSupported},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.Processed != 2 {
+			t.Errorf("Expected 2 processed values, got %d", resp.Processed)
+		}
+	}
+
+	if requests[0].Request != "agent data" || requests[0].Session != client.Session {
+		t.Errorf("Unexpected request %+v", requests[0])
+	}
+
+	ids := []uint64{}
+	for _, r := range requests {
+		for _, value := range r.Data {
+			ids = append(ids, value.ID)
+			if value.Host != "collector01" {
+				t.Errorf("Expected value host to default to client host, got %q", value.Host)
+			}
+		}
+	}
+
+	for i, id := range ids {
+		if id != uint64(i+1) {
+			t.Errorf("Expected value IDs to be sequential, got %v", ids)
+			break
+		}
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	var received heartbeatRequest
+	address := test.NewTCPServer(t, false, func(req []byte) []byte {
+		defer wg.Done()
+		json.Unmarshal(req, &received)
+		return nil
+	})
+
+	if err := New(address, "collector01").Heartbeat(time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	if received.Request != "active check heartbeat" || received.HeartbeatFreq != 60 {
+		t.Errorf("Unexpected heartbeat %+v", received)
+	}
+}
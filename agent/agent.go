@@ -0,0 +1,186 @@
+// Package agent implements a client for the passive checks protocol of the
+// Zabbix agent, like zabbix_get.
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/appendix/items/activepassive#passive-checks
+package agent
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/zbxd"
+)
+
+const (
+	// DefaultPort is the default listening port of the Zabbix agent.
+	DefaultPort = "10050"
+
+	// DefaultTimeout is the default connection timeout.
+	DefaultTimeout = 30 * time.Second
+)
+
+// notSupported prefixes the value returned by the agent for unsupported keys,
+// followed by a null byte and the reason.
+var notSupported = []byte("ZBX_NOTSUPPORTED")
+
+var (
+	// ErrPSKUnsupported is returned when PSK encryption is requested without
+	// a PSKDialer, as crypto/tls does not implement TLS-PSK cipher suites.
+	ErrPSKUnsupported = errors.New("TLS-PSK requires a PSKDialer")
+
+	// ErrCertificateConfig is returned when certificate encryption is
+	// requested without a tls.Config.
+	ErrCertificateConfig = errors.New("Certificate encryption requires a tls.Config")
+)
+
+// NotSupportedError is returned when the agent reports an item key as not
+// supported.
+type NotSupportedError struct {
+	// Key is the requested item key.
+	Key string
+
+	// Reason is the reason given by the agent.
+	Reason string
+}
+
+func (e *NotSupportedError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("Item key %q is not supported", e.Key)
+	}
+
+	return fmt.Sprintf("Item key %q is not supported: %s", e.Key, e.Reason)
+}
+
+// PSKDialer connects to the agent using TLS with a pre-shared key. It must be
+// provided by the caller with a TLS-PSK capable implementation.
+type PSKDialer func(network, address, identity string, psk []byte) (net.Conn, error)
+
+// TLSSettings are the encryption settings of the connection to the agent,
+// mirroring the TLS fields of zabbix.Host.
+type TLSSettings struct {
+	// Connect is how to connect to the agent and must be one of the
+	// zabbix.HostTLSConnect constants. Zero means unencrypted.
+	Connect int
+
+	// PSKIdentity is the PSK identity, used with zabbix.HostTLSConnectPSK.
+	PSKIdentity string
+
+	// PSK is the hex encoded pre-shared key, used with
+	// zabbix.HostTLSConnectPSK.
+	PSK string
+
+	// Config is the TLS configuration used with
+	// zabbix.HostTLSConnectCertificate.
+	Config *tls.Config
+}
+
+// TLSSettingsFromHost returns the encryption settings of the given Host. The
+// tls.Config used for certificate encryption must be set by the caller.
+func TLSSettingsFromHost(host zabbix.Host) TLSSettings {
+	return TLSSettings{
+		Connect:     int(host.TLSConnect),
+		PSKIdentity: host.TLSPSKIdentity,
+		PSK:         host.TLSPSK,
+	}
+}
+
+// A Client polls a Zabbix agent for item values using passive checks.
+type Client struct {
+	// Address is the host and port of the agent.
+	Address string
+
+	// Timeout is the timeout for connecting, sending and receiving.
+	Timeout time.Duration
+
+	// TLS are the encryption settings of the connection.
+	TLS TLSSettings
+
+	// DialPSK is used to connect with zabbix.HostTLSConnectPSK.
+	DialPSK PSKDialer
+}
+
+// New returns a Client for the given agent address. DefaultPort is used if
+// address has no port.
+func New(address string) *Client {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, DefaultPort)
+	}
+
+	return &Client{
+		Address: address,
+		Timeout: DefaultTimeout,
+	}
+}
+
+func (c *Client) dial() (net.Conn, error) {
+	switch c.TLS.Connect {
+	case 0, zabbix.HostTLSConnectUnencryped:
+		return net.DialTimeout("tcp", c.Address, c.Timeout)
+
+	case zabbix.HostTLSConnectPSK:
+		if c.DialPSK == nil {
+			return nil, ErrPSKUnsupported
+		}
+
+		psk, err := hex.DecodeString(c.TLS.PSK)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid PSK: %v", err)
+		}
+
+		return c.DialPSK("tcp", c.Address, c.TLS.PSKIdentity, psk)
+
+	case zabbix.HostTLSConnectCertificate:
+		if c.TLS.Config == nil {
+			return nil, ErrCertificateConfig
+		}
+
+		dialer := &net.Dialer{Timeout: c.Timeout}
+		return tls.DialWithDialer(dialer, "tcp", c.Address, c.TLS.Config)
+	}
+
+	return nil, fmt.Errorf("Unsupported TLS connect type %d", c.TLS.Connect)
+}
+
+// Get returns the value of the given item key.
+//
+// A NotSupportedError is returned if the agent does not support the key.
+// An error is returned if there was a network or protocol error.
+func (c *Client) Get(key string) (string, error) {
+	conn, err := c.dial()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if c.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(c.Timeout))
+	}
+
+	if err := zbxd.Write(conn, []byte(key), false); err != nil {
+		return "", fmt.Errorf("Error sending request: %v", err)
+	}
+
+	value, err := zbxd.Read(conn, 0)
+	if err != nil {
+		return "", fmt.Errorf("Error reading response: %v", err)
+	}
+
+	if bytes.HasPrefix(value, notSupported) {
+		reason := bytes.TrimPrefix(value, notSupported)
+		reason = bytes.TrimLeft(reason, "\x00")
+		return "", &NotSupportedError{Key: key, Reason: string(reason)}
+	}
+
+	return string(value), nil
+}
+
+// GetItem returns the value of the key of the given Item.
+func (c *Client) GetItem(item zabbix.Item) (string, error) {
+	return c.Get(item.ItemKey)
+}
@@ -0,0 +1,94 @@
+package agent
+
+import (
+	"net"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func newTestAgent(t *testing.T) *Client {
+	address := test.NewTCPServer(t, false, func(req []byte) []byte {
+		switch string(req) {
+		case "agent.ping":
+			return []byte("1")
+		case "system.hostname":
+			return []byte("web01")
+		}
+
+		return []byte("ZBX_NOTSUPPORTED\x00Unsupported item key.")
+	})
+
+	return New(address)
+}
+
+func TestGet(t *testing.T) {
+	value, err := newTestAgent(t).Get("agent.ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "1" {
+		t.Errorf("Expected value %q but got %q", "1", value)
+	}
+}
+
+func TestGetItem(t *testing.T) {
+	value, err := newTestAgent(t).GetItem(zabbix.Item{ItemKey: "system.hostname"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "web01" {
+		t.Errorf("Expected value %q but got %q", "web01", value)
+	}
+}
+
+func TestGetNotSupported(t *testing.T) {
+	_, err := newTestAgent(t).Get("vfs.fs.size[/,free")
+
+	notSupported, ok := err.(*NotSupportedError)
+	if !ok {
+		t.Fatalf("Expected NotSupportedError but got %v", err)
+	}
+
+	if notSupported.Reason != "Unsupported item key." {
+		t.Errorf("Unexpected reason %q", notSupported.Reason)
+	}
+}
+
+func TestPSK(t *testing.T) {
+	client := newTestAgent(t)
+	client.TLS = TLSSettingsFromHost(zabbix.Host{
+		TLSConnect:     zabbix.HostTLSConnectPSK,
+		TLSPSKIdentity: "PSK 001",
+		TLSPSK:         "1f87b595725ac58dd977beef14b97461a7c1045b9a1c963065002c5473194952",
+	})
+
+	if _, err := client.Get("agent.ping"); err != ErrPSKUnsupported {
+		t.Fatalf("Expected ErrPSKUnsupported but got %v", err)
+	}
+
+	// a plain connection stands in for a TLS-PSK implementation
+	var identity string
+	var psk []byte
+	client.DialPSK = func(network, address, id string, key []byte) (net.Conn, error) {
+		identity, psk = id, key
+		return net.Dial(network, address)
+	}
+
+	if _, err := client.Get("agent.ping"); err != nil {
+		t.Fatal(err)
+	}
+
+	if identity != "PSK 001" || len(psk) != 32 {
+		t.Errorf("Unexpected PSK identity %q or key length %d", identity, len(psk))
+	}
+}
+
+func TestNew(t *testing.T) {
+	if c := New("web01"); c.Address != "web01:10050" {
+		t.Errorf("Expected default port, got %q", c.Address)
+	}
+}
@@ -48,10 +48,10 @@ type Alert struct {
 
 	// AlertType is the type of the Alert.
 	// AlertType must be one of the AlertType constants.
-	AlertType int `json:"alerttype,string"`
+	AlertType types.ZBXInt `json:"alerttype"`
 
 	// Timestamp is the UTC timestamp at which the Alert was generated.
-	Timestamp types.ZBXUnixTimestamp `json:"clock,string"`
+	Timestamp types.ZBXUnixTimestamp `json:"clock"`
 
 	// ErrorText is the error message if there was a problem sending a message
 	// or running a remote command.
@@ -59,7 +59,7 @@ type Alert struct {
 
 	// EscalationStep is the escalation step during which the Alert was
 	// generated.
-	EscalationStep int `json:"esc_step,string"`
+	EscalationStep types.ZBXInt `json:"esc_step"`
 
 	// EventID is the unique ID of the Event that triggered this Action that
 	// generated this Alert.
@@ -73,7 +73,7 @@ type Alert struct {
 	Message string `json:"message"`
 
 	// RetryCount is the number of times Zabbix tried to send a message.
-	RetryCount int `json:"retries,string"`
+	RetryCount types.ZBXInt `json:"retries"`
 
 	// Recipient is the end point address of a message if AlertType is
 	// AlertTypeMessage.
@@ -86,7 +86,7 @@ type Alert struct {
 	//
 	// If AlertType is AlertTypeRemoteCommand, Status must be one of the
 	// AlertCommandStatus constants.
-	Status int `json:"status,string"`
+	Status types.ZBXInt `json:"status"`
 
 	// Subject is the Alert message subject if AlertType is AlertTypeMessage.
 	Subject string `json:"subject"`
@@ -146,12 +146,12 @@ type AlertGetParams struct {
 	// Return only alerts generated by events related to objects of the given type.
 	//
 	// Default: EventObjectTypeTrigger
-	ObjectType int `json:"eventobject"`
+	ObjectType EventObjectType `json:"eventobject"`
 
 	// Return only alerts generated by events of the given type.
 	//
 	// Default: EventSourceTrigger
-	ObjectSource int `json:"eventsource"`
+	ObjectSource EventSource `json:"eventsource"`
 
 	// MinTime filters search results to Events with a timestamp lesser than or
 	// equal to the given timestamp.
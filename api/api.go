@@ -0,0 +1,53 @@
+// Package api holds Zabbix API objects, get parameters, enumerated types and
+// method wrappers generated from schema.json, the machine-readable description
+// of the objects in the Zabbix documentation of the versions it lists.
+//
+// Update schema.json and run go generate to change the generated code:
+//
+//	go generate ./api
+//
+// The fields note the Zabbix versions they were added, deprecated or removed
+// in. Fields returns the fields of an object supported by a given version.
+//
+// The hand-written types of the zabbix package, such as zabbix.Host, are kept
+// alongside the generated ones: the Session methods, the reconcile package and
+// existing users rely on their field types, which cannot be generated without
+// breaking them. New code should prefer the generated types, which follow the
+// documentation, only send the fields set and can be used with the same
+// Session.
+package api
+
+import "github.com/NexonSU/go-zabbix/types"
+
+//go:generate go run ../internal/cmd/apigen -schema schema.json -out zz_generated.go
+
+// field is a field of an API object with the versions it was added and
+// removed in, if any.
+type field struct {
+	name    string
+	since   string
+	removed string
+}
+
+// Fields returns the names of the fields of the given API object, such as
+// host, supported by the given Zabbix version. All the fields are returned if
+// version is nil, and none if the object is unknown.
+func Fields(object string, version *types.ZBXVersion) []string {
+	var names []string
+	for _, f := range fields[object] {
+		if version != nil && (f.since != "" && version.LessThan(mustVersion(f.since)) ||
+			f.removed != "" && !version.LessThan(mustVersion(f.removed))) {
+			continue
+		}
+		names = append(names, f.name)
+	}
+	return names
+}
+
+func mustVersion(s string) *types.ZBXVersion {
+	ver, err := types.NewZBXVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return ver
+}
@@ -0,0 +1,145 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix/api"
+	"github.com/NexonSU/go-zabbix/internal/apigen"
+	"github.com/NexonSU/go-zabbix/test"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+func TestGeneratedCodeUpToDate(t *testing.T) {
+	b, err := os.ReadFile("schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := apigen.Load(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := apigen.Generate(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated, err := os.ReadFile("zz_generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(src, generated) {
+		t.Error("zz_generated.go is out of date, run go generate ./api")
+	}
+}
+
+func TestFields(t *testing.T) {
+	v60, _ := types.NewZBXVersion("6.0.0")
+	v70, _ := types.NewZBXVersion("7.0.0")
+
+	contains := func(fields []string, name string) bool {
+		for _, f := range fields {
+			if f == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	if fields := api.Fields("host", v60); !contains(fields, "proxy_hostid") || contains(fields, "proxyid") {
+		t.Errorf("Unexpected host fields for v6.0: %v", fields)
+	}
+	if fields := api.Fields("host", v70); contains(fields, "proxy_hostid") || !contains(fields, "proxyid") {
+		t.Errorf("Unexpected host fields for v7.0: %v", fields)
+	}
+	if fields := api.Fields("host", nil); !contains(fields, "proxy_hostid") || !contains(fields, "proxyid") {
+		t.Errorf("Expected all host fields but got %v", fields)
+	}
+	if fields := api.Fields("unknown", v70); fields != nil {
+		t.Errorf("Expected no fields for an unknown object but got %v", fields)
+	}
+}
+
+func TestHostWrappers(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{"hostid":"10084","host":"web01","status":"1","inventory_mode":"-1","monitored_by":"1","proxyid":"3","interfaces":[{"interfaceid":"1","type":"1","useip":"1","ip":"127.0.0.1","port":"10050"}]}]`), nil
+	})
+	for _, method := range []string{"host.create", "host.update"} {
+		server.Handle(method, func(params json.RawMessage) (interface{}, error) {
+			return json.RawMessage(`{"hostids":["10085"]}`), nil
+		})
+	}
+	session := server.Session(t, "7.0.0")
+
+	hosts, err := api.GetHosts(session, api.HostGetParams{HostIDs: []string{"10084"}, SelectInterfaces: "extend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := api.HostStatusUnmonitored
+	inventoryMode := api.InventoryModeDisabled
+	monitoredBy := api.MonitoredByProxy
+	interfaceType := api.InterfaceTypeAgent
+	useIP := types.ZBXBoolean(true)
+	expected := api.Host{
+		HostID:        "10084",
+		Host:          "web01",
+		Status:        &status,
+		InventoryMode: &inventoryMode,
+		MonitoredBy:   &monitoredBy,
+		ProxyID:       "3",
+		ProxyHostID:   "3", // copied by the Session for older code
+		Interfaces: []api.HostInterface{
+			{InterfaceID: "1", Type: &interfaceType, UseIP: &useIP, IP: "127.0.0.1", Port: "10050"},
+		},
+	}
+	if !reflect.DeepEqual(hosts[0], expected) {
+		t.Errorf("Expected host %+v but got %+v", expected, hosts[0])
+	}
+
+	ids, err := api.CreateHosts(session, api.Host{Host: "web02", Groups: []api.GroupID{{GroupID: "2"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "10085" {
+		t.Errorf("Unexpected host IDs %v", ids)
+	}
+
+	// unset properties are left to the server defaults
+	params := string(server.Calls()[1].Params.(json.RawMessage))
+	if params != `[{"host":"web02","groups":[{"groupid":"2"}]}]` {
+		t.Errorf("Unexpected host.create params %s", params)
+	}
+
+	// a partial update only sends the properties set, including zero values
+	monitored := api.HostStatusMonitored
+	if _, err := api.UpdateHosts(session, api.Host{HostID: "10085", Status: &monitored}); err != nil {
+		t.Fatal(err)
+	}
+	params = string(server.Calls()[2].Params.(json.RawMessage))
+	if params != `[{"hostid":"10085","status":"0"}]` {
+		t.Errorf("Unexpected host.update params %s", params)
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	tests := map[string]interface{ String() string }{
+		"snmp_trap":     api.ItemTypeSNMPTrap,
+		"zabbix_agent":  api.ItemTypeZabbixAgent,
+		"not_supported": api.ItemStateNotSupported,
+		"ipmi":          api.InterfaceTypeIPMI,
+		"ValueType(9)":  api.ValueType(9),
+	}
+	for expected, v := range tests {
+		if s := v.String(); s != expected {
+			t.Errorf("Expected %q but got %q", expected, s)
+		}
+	}
+}
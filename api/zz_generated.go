@@ -0,0 +1,1448 @@
+// Code generated by apigen from schema.json. DO NOT EDIT.
+
+package api
+
+import (
+	"strconv"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/schedule"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// HostStatus is whether a Host is monitored.
+type HostStatus int
+
+const (
+	HostStatusMonitored   HostStatus = 0
+	HostStatusUnmonitored HostStatus = 1
+)
+
+// String returns the name of the HostStatus.
+func (v HostStatus) String() string {
+	switch v {
+	case HostStatusMonitored:
+		return "monitored"
+	case HostStatusUnmonitored:
+		return "unmonitored"
+	}
+	return "HostStatus(" + strconv.Itoa(int(v)) + ")"
+}
+
+// InventoryMode is how the inventory of a Host is populated.
+type InventoryMode int
+
+const (
+	InventoryModeDisabled  InventoryMode = -1
+	InventoryModeManual    InventoryMode = 0
+	InventoryModeAutomatic InventoryMode = 1
+)
+
+// String returns the name of the InventoryMode.
+func (v InventoryMode) String() string {
+	switch v {
+	case InventoryModeDisabled:
+		return "disabled"
+	case InventoryModeManual:
+		return "manual"
+	case InventoryModeAutomatic:
+		return "automatic"
+	}
+	return "InventoryMode(" + strconv.Itoa(int(v)) + ")"
+}
+
+// MonitoredBy is the source monitoring a Host.
+type MonitoredBy int
+
+const (
+	MonitoredByServer     MonitoredBy = 0
+	MonitoredByProxy      MonitoredBy = 1
+	MonitoredByProxyGroup MonitoredBy = 2
+)
+
+// String returns the name of the MonitoredBy.
+func (v MonitoredBy) String() string {
+	switch v {
+	case MonitoredByServer:
+		return "server"
+	case MonitoredByProxy:
+		return "proxy"
+	case MonitoredByProxyGroup:
+		return "proxy_group"
+	}
+	return "MonitoredBy(" + strconv.Itoa(int(v)) + ")"
+}
+
+// TLSConnection is a connection encryption mode, also used as a bitmask of
+// accepted modes.
+type TLSConnection int
+
+const (
+	TLSConnectionUnencrypted TLSConnection = 1
+	TLSConnectionPSK         TLSConnection = 2
+	TLSConnectionCertificate TLSConnection = 4
+)
+
+// String returns the name of the TLSConnection.
+func (v TLSConnection) String() string {
+	switch v {
+	case TLSConnectionUnencrypted:
+		return "unencrypted"
+	case TLSConnectionPSK:
+		return "psk"
+	case TLSConnectionCertificate:
+		return "certificate"
+	}
+	return "TLSConnection(" + strconv.Itoa(int(v)) + ")"
+}
+
+// InterfaceType is the type of a HostInterface.
+type InterfaceType int
+
+const (
+	InterfaceTypeAgent InterfaceType = 1
+	InterfaceTypeSNMP  InterfaceType = 2
+	InterfaceTypeIPMI  InterfaceType = 3
+	InterfaceTypeJMX   InterfaceType = 4
+)
+
+// String returns the name of the InterfaceType.
+func (v InterfaceType) String() string {
+	switch v {
+	case InterfaceTypeAgent:
+		return "agent"
+	case InterfaceTypeSNMP:
+		return "snmp"
+	case InterfaceTypeIPMI:
+		return "ipmi"
+	case InterfaceTypeJMX:
+		return "jmx"
+	}
+	return "InterfaceType(" + strconv.Itoa(int(v)) + ")"
+}
+
+// Availability is whether a HostInterface can be reached.
+type Availability int
+
+const (
+	AvailabilityUnknown     Availability = 0
+	AvailabilityAvailable   Availability = 1
+	AvailabilityUnavailable Availability = 2
+)
+
+// String returns the name of the Availability.
+func (v Availability) String() string {
+	switch v {
+	case AvailabilityUnknown:
+		return "unknown"
+	case AvailabilityAvailable:
+		return "available"
+	case AvailabilityUnavailable:
+		return "unavailable"
+	}
+	return "Availability(" + strconv.Itoa(int(v)) + ")"
+}
+
+// ItemType is the type of check of an Item.
+type ItemType int
+
+const (
+	ItemTypeZabbixAgent       ItemType = 0
+	ItemTypeZabbixTrapper     ItemType = 2
+	ItemTypeSimpleCheck       ItemType = 3
+	ItemTypeZabbixInternal    ItemType = 5
+	ItemTypeZabbixAgentActive ItemType = 7
+	ItemTypeWebItem           ItemType = 9
+	ItemTypeExternalCheck     ItemType = 10
+	ItemTypeDatabaseMonitor   ItemType = 11
+	ItemTypeIPMIAgent         ItemType = 12
+	ItemTypeSSHAgent          ItemType = 13
+	ItemTypeTelnetAgent       ItemType = 14
+	ItemTypeCalculated        ItemType = 15
+	ItemTypeJMXAgent          ItemType = 16
+	ItemTypeSNMPTrap          ItemType = 17
+	ItemTypeDependent         ItemType = 18
+	ItemTypeHTTPAgent         ItemType = 19
+	ItemTypeSNMPAgent         ItemType = 20
+	ItemTypeScript            ItemType = 21
+	// Available since Zabbix 7.0.
+	ItemTypeBrowser ItemType = 22
+)
+
+// String returns the name of the ItemType.
+func (v ItemType) String() string {
+	switch v {
+	case ItemTypeZabbixAgent:
+		return "zabbix_agent"
+	case ItemTypeZabbixTrapper:
+		return "zabbix_trapper"
+	case ItemTypeSimpleCheck:
+		return "simple_check"
+	case ItemTypeZabbixInternal:
+		return "zabbix_internal"
+	case ItemTypeZabbixAgentActive:
+		return "zabbix_agent_active"
+	case ItemTypeWebItem:
+		return "web_item"
+	case ItemTypeExternalCheck:
+		return "external_check"
+	case ItemTypeDatabaseMonitor:
+		return "database_monitor"
+	case ItemTypeIPMIAgent:
+		return "ipmi_agent"
+	case ItemTypeSSHAgent:
+		return "ssh_agent"
+	case ItemTypeTelnetAgent:
+		return "telnet_agent"
+	case ItemTypeCalculated:
+		return "calculated"
+	case ItemTypeJMXAgent:
+		return "jmx_agent"
+	case ItemTypeSNMPTrap:
+		return "snmp_trap"
+	case ItemTypeDependent:
+		return "dependent"
+	case ItemTypeHTTPAgent:
+		return "http_agent"
+	case ItemTypeSNMPAgent:
+		return "snmp_agent"
+	case ItemTypeScript:
+		return "script"
+	case ItemTypeBrowser:
+		return "browser"
+	}
+	return "ItemType(" + strconv.Itoa(int(v)) + ")"
+}
+
+// ValueType is the type of information of an Item.
+type ValueType int
+
+const (
+	ValueTypeFloat     ValueType = 0
+	ValueTypeCharacter ValueType = 1
+	ValueTypeLog       ValueType = 2
+	ValueTypeUnsigned  ValueType = 3
+	ValueTypeText      ValueType = 4
+	// Available since Zabbix 7.0.
+	ValueTypeBinary ValueType = 5
+)
+
+// String returns the name of the ValueType.
+func (v ValueType) String() string {
+	switch v {
+	case ValueTypeFloat:
+		return "float"
+	case ValueTypeCharacter:
+		return "character"
+	case ValueTypeLog:
+		return "log"
+	case ValueTypeUnsigned:
+		return "unsigned"
+	case ValueTypeText:
+		return "text"
+	case ValueTypeBinary:
+		return "binary"
+	}
+	return "ValueType(" + strconv.Itoa(int(v)) + ")"
+}
+
+// ItemStatus is whether an Item is enabled.
+type ItemStatus int
+
+const (
+	ItemStatusEnabled  ItemStatus = 0
+	ItemStatusDisabled ItemStatus = 1
+)
+
+// String returns the name of the ItemStatus.
+func (v ItemStatus) String() string {
+	switch v {
+	case ItemStatusEnabled:
+		return "enabled"
+	case ItemStatusDisabled:
+		return "disabled"
+	}
+	return "ItemStatus(" + strconv.Itoa(int(v)) + ")"
+}
+
+// ItemState is whether an Item is supported.
+type ItemState int
+
+const (
+	ItemStateNormal       ItemState = 0
+	ItemStateNotSupported ItemState = 1
+)
+
+// String returns the name of the ItemState.
+func (v ItemState) String() string {
+	switch v {
+	case ItemStateNormal:
+		return "normal"
+	case ItemStateNotSupported:
+		return "not_supported"
+	}
+	return "ItemState(" + strconv.Itoa(int(v)) + ")"
+}
+
+// MediaTypeType is the transport of a MediaType.
+type MediaTypeType int
+
+const (
+	MediaTypeTypeEmail   MediaTypeType = 0
+	MediaTypeTypeScript  MediaTypeType = 1
+	MediaTypeTypeSMS     MediaTypeType = 2
+	MediaTypeTypeWebhook MediaTypeType = 4
+)
+
+// String returns the name of the MediaTypeType.
+func (v MediaTypeType) String() string {
+	switch v {
+	case MediaTypeTypeEmail:
+		return "email"
+	case MediaTypeTypeScript:
+		return "script"
+	case MediaTypeTypeSMS:
+		return "sms"
+	case MediaTypeTypeWebhook:
+		return "webhook"
+	}
+	return "MediaTypeType(" + strconv.Itoa(int(v)) + ")"
+}
+
+// MediaTypeStatus is whether a MediaType is enabled.
+type MediaTypeStatus int
+
+const (
+	MediaTypeStatusEnabled  MediaTypeStatus = 0
+	MediaTypeStatusDisabled MediaTypeStatus = 1
+)
+
+// String returns the name of the MediaTypeStatus.
+func (v MediaTypeStatus) String() string {
+	switch v {
+	case MediaTypeStatusEnabled:
+		return "enabled"
+	case MediaTypeStatusDisabled:
+		return "disabled"
+	}
+	return "MediaTypeStatus(" + strconv.Itoa(int(v)) + ")"
+}
+
+// Tag is a tag of a Host or an Item.
+type Tag struct {
+	// Tag name.
+	Tag string `json:"tag,omitempty"`
+
+	// Tag value.
+	Value string `json:"value,omitempty"`
+}
+
+// GroupID references a host group.
+type GroupID struct {
+	// ID of the host group.
+	GroupID string `json:"groupid,omitempty"`
+}
+
+// TemplateID references a template.
+type TemplateID struct {
+	// ID of the template.
+	TemplateID string `json:"templateid,omitempty"`
+}
+
+// UserGroupID references a user group.
+type UserGroupID struct {
+	// ID of the user group.
+	UserGroupID string `json:"usrgrpid,omitempty"`
+}
+
+// Host is a Zabbix host.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/host/object
+type Host struct {
+	// ID of the host.
+	//
+	// Read-only.
+	HostID string `json:"hostid,omitempty"`
+
+	// Technical name of the host.
+	Host string `json:"host,omitempty"`
+
+	// Visible name of the host. Defaults to Host.
+	Name string `json:"name,omitempty"`
+
+	// Description of the host.
+	Description string `json:"description,omitempty"`
+
+	// Status of the host.
+	Status *HostStatus `json:"status,string,omitempty"`
+
+	// Host inventory population mode.
+	InventoryMode *InventoryMode `json:"inventory_mode,string,omitempty"`
+
+	// Source that monitors the host.
+	//
+	// Available since Zabbix 7.0.
+	MonitoredBy *MonitoredBy `json:"monitored_by,string,omitempty"`
+
+	// ID of the proxy monitoring the host.
+	//
+	// Available since Zabbix 7.0.
+	ProxyID string `json:"proxyid,omitempty"`
+
+	// ID of the proxy group monitoring the host.
+	//
+	// Available since Zabbix 7.0.
+	ProxyGroupID string `json:"proxy_groupid,omitempty"`
+
+	// ID of the proxy assigned by the proxy group.
+	//
+	// Read-only. Available since Zabbix 7.0.
+	AssignedProxyID string `json:"assigned_proxyid,omitempty"`
+
+	// ID of the proxy monitoring the host.
+	//
+	// Removed in Zabbix 7.0.
+	//
+	// Deprecated: Deprecated since Zabbix 6.4.
+	ProxyHostID string `json:"proxy_hostid,omitempty"`
+
+	// Availability of active checks.
+	//
+	// Read-only. Available since Zabbix 6.4.
+	ActiveAvailable Availability `json:"active_available,string,omitempty"`
+
+	// Origin of the host: 0 for a plain host, 4 for a discovered host.
+	//
+	// Read-only.
+	Flags types.ZBXInt `json:"flags,omitempty"`
+
+	// ID of the maintenance in effect on the host.
+	//
+	// Read-only.
+	MaintenanceID string `json:"maintenanceid,omitempty"`
+
+	// Whether the host is in maintenance.
+	//
+	// Read-only.
+	MaintenanceStatus types.ZBXInt `json:"maintenance_status,omitempty"`
+
+	// Type of the maintenance in effect on the host.
+	//
+	// Read-only.
+	MaintenanceType types.ZBXInt `json:"maintenance_type,omitempty"`
+
+	// Starting time of the maintenance in effect on the host.
+	//
+	// Read-only.
+	MaintenanceFrom *types.ZBXUnixTimestamp `json:"maintenance_from,omitempty"`
+
+	// IPMI authentication algorithm.
+	IPMIAuthType *types.ZBXInt `json:"ipmi_authtype,omitempty"`
+
+	// IPMI privilege level.
+	IPMIPrivilege *types.ZBXInt `json:"ipmi_privilege,omitempty"`
+
+	// IPMI username.
+	IPMIUsername string `json:"ipmi_username,omitempty"`
+
+	// IPMI password.
+	IPMIPassword string `json:"ipmi_password,omitempty"`
+
+	// Connections to the host.
+	TLSConnect *TLSConnection `json:"tls_connect,string,omitempty"`
+
+	// Connections from the host, as a bitmask.
+	TLSAccept *TLSConnection `json:"tls_accept,string,omitempty"`
+
+	// Certificate issuer.
+	TLSIssuer string `json:"tls_issuer,omitempty"`
+
+	// Certificate subject.
+	TLSSubject string `json:"tls_subject,omitempty"`
+
+	// PSK identity.
+	TLSPSKIdentity string `json:"tls_psk_identity,omitempty"`
+
+	// Pre-shared key of at least 32 hex digits.
+	TLSPSK string `json:"tls_psk,omitempty"`
+
+	// Host groups to add the host to.
+	Groups []GroupID `json:"groups,omitempty"`
+
+	// Templates to link to the host.
+	Templates []TemplateID `json:"templates,omitempty"`
+
+	// Interfaces of the host.
+	Interfaces []HostInterface `json:"interfaces,omitempty"`
+
+	// Tags of the host.
+	Tags []Tag `json:"tags,omitempty"`
+
+	// Inventory properties of the host.
+	Inventory map[string]interface{} `json:"inventory,omitempty"`
+}
+
+// HostGetParams are the parameters of host.get.
+type HostGetParams struct {
+	zabbix.GetParameters
+
+	// Return only hosts that belong to the given groups.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// Return only hosts with the given host IDs.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// Return only hosts that have the given items.
+	ItemIDs []string `json:"itemids,omitempty"`
+
+	// Return only hosts that are monitored by the given proxies.
+	ProxyIDs []string `json:"proxyids,omitempty"`
+
+	// Return only hosts linked to the given templates.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// Return only hosts affected by the given maintenances.
+	MaintenanceIDs []string `json:"maintenanceids,omitempty"`
+
+	// Return only monitored hosts.
+	MonitoredHosts bool `json:"monitored_hosts,omitempty"`
+
+	// Return only hosts that have items.
+	WithItems bool `json:"with_items,omitempty"`
+
+	// Return only hosts that have triggers.
+	WithTriggers bool `json:"with_triggers,omitempty"`
+
+	// Return the host groups of the host in the hostgroups property.
+	//
+	// Available since Zabbix 6.2.
+	SelectHostGroups zabbix.SelectQuery `json:"selectHostGroups,omitempty"`
+
+	// Return the host groups of the host in the groups property.
+	//
+	// Removed in Zabbix 7.2.
+	//
+	// Deprecated: Deprecated since Zabbix 6.2.
+	SelectGroups zabbix.SelectQuery `json:"selectGroups,omitempty"`
+
+	// Return the interfaces of the host in the interfaces property.
+	SelectInterfaces zabbix.SelectQuery `json:"selectInterfaces,omitempty"`
+
+	// Return the items of the host in the items property.
+	SelectItems zabbix.SelectQuery `json:"selectItems,omitempty"`
+
+	// Return the templates linked to the host in the parentTemplates property.
+	SelectParentTemplates zabbix.SelectQuery `json:"selectParentTemplates,omitempty"`
+
+	// Return the tags of the host in the tags property.
+	SelectTags zabbix.SelectQuery `json:"selectTags,omitempty"`
+
+	// Return the inventory of the host in the inventory property.
+	SelectInventory zabbix.SelectQuery `json:"selectInventory,omitempty"`
+}
+
+// GetHosts calls host.get with the given parameters.
+//
+// zabbix.ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func GetHosts(s *zabbix.Session, params HostGetParams) ([]Host, error) {
+	result := make([]Host, 0)
+	if err := s.Get("host.get", params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, zabbix.ErrNotFound
+	}
+
+	return result, nil
+}
+
+// CreateHosts calls host.create and returns the IDs of the created
+// objects.
+func CreateHosts(s *zabbix.Session, objects ...Host) ([]string, error) {
+	return s.GetIDs("host.create", objects, "hostids")
+}
+
+// UpdateHosts calls host.update and returns the IDs of the updated
+// objects.
+func UpdateHosts(s *zabbix.Session, objects ...Host) ([]string, error) {
+	return s.GetIDs("host.update", objects, "hostids")
+}
+
+// DeleteHosts calls host.delete and returns the IDs of the deleted
+// objects.
+func DeleteHosts(s *zabbix.Session, ids ...string) ([]string, error) {
+	return s.GetIDs("host.delete", ids, "hostids")
+}
+
+// HostInterface is an interface of a Host.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/hostinterface/object
+type HostInterface struct {
+	// ID of the interface.
+	//
+	// Read-only.
+	InterfaceID string `json:"interfaceid,omitempty"`
+
+	// ID of the host the interface belongs to.
+	HostID string `json:"hostid,omitempty"`
+
+	// Interface type.
+	Type *InterfaceType `json:"type,string,omitempty"`
+
+	// Whether the interface is the default one of its type: 1 if it is, 0
+	// otherwise.
+	Main *types.ZBXInt `json:"main,omitempty"`
+
+	// Whether the connection is made using IP rather than DNS.
+	UseIP *types.ZBXBoolean `json:"useip,omitempty"`
+
+	// IP address used by the interface.
+	IP string `json:"ip,omitempty"`
+
+	// DNS name used by the interface.
+	DNS string `json:"dns,omitempty"`
+
+	// Port number used by the interface. Can contain user macros.
+	Port string `json:"port,omitempty"`
+
+	// Additional details of SNMP interfaces.
+	Details map[string]interface{} `json:"details,omitempty"`
+
+	// Availability of the interface.
+	//
+	// Read-only.
+	Available Availability `json:"available,string,omitempty"`
+
+	// Error text if the interface is unavailable.
+	//
+	// Read-only.
+	Error string `json:"error,omitempty"`
+
+	// Time when the interface became unavailable.
+	//
+	// Read-only.
+	ErrorsFrom *types.ZBXUnixTimestamp `json:"errors_from,omitempty"`
+
+	// Time the interface is next checked when unavailable.
+	//
+	// Read-only.
+	DisableUntil *types.ZBXUnixTimestamp `json:"disable_until,omitempty"`
+}
+
+// HostInterfaceGetParams are the parameters of hostinterface.get.
+type HostInterfaceGetParams struct {
+	zabbix.GetParameters
+
+	// Return only interfaces of the given hosts.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// Return only interfaces with the given IDs.
+	InterfaceIDs []string `json:"interfaceids,omitempty"`
+
+	// Return only interfaces used by the given items.
+	ItemIDs []string `json:"itemids,omitempty"`
+
+	// Return only interfaces used by the items of the given triggers.
+	TriggerIDs []string `json:"triggerids,omitempty"`
+
+	// Return the host of the interface in the hosts property.
+	SelectHosts zabbix.SelectQuery `json:"selectHosts,omitempty"`
+
+	// Return the items using the interface in the items property.
+	SelectItems zabbix.SelectQuery `json:"selectItems,omitempty"`
+}
+
+// GetHostInterfaces calls hostinterface.get with the given parameters.
+//
+// zabbix.ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func GetHostInterfaces(s *zabbix.Session, params HostInterfaceGetParams) ([]HostInterface, error) {
+	result := make([]HostInterface, 0)
+	if err := s.Get("hostinterface.get", params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, zabbix.ErrNotFound
+	}
+
+	return result, nil
+}
+
+// CreateHostInterfaces calls hostinterface.create and returns the IDs of the created
+// objects.
+func CreateHostInterfaces(s *zabbix.Session, objects ...HostInterface) ([]string, error) {
+	return s.GetIDs("hostinterface.create", objects, "interfaceids")
+}
+
+// UpdateHostInterfaces calls hostinterface.update and returns the IDs of the updated
+// objects.
+func UpdateHostInterfaces(s *zabbix.Session, objects ...HostInterface) ([]string, error) {
+	return s.GetIDs("hostinterface.update", objects, "interfaceids")
+}
+
+// DeleteHostInterfaces calls hostinterface.delete and returns the IDs of the deleted
+// objects.
+func DeleteHostInterfaces(s *zabbix.Session, ids ...string) ([]string, error) {
+	return s.GetIDs("hostinterface.delete", ids, "interfaceids")
+}
+
+// ItemPreprocessing is a preprocessing step of an Item.
+type ItemPreprocessing struct {
+	// Preprocessing step type.
+	Type *types.ZBXInt `json:"type,omitempty"`
+
+	// Parameters of the step, separated by newlines.
+	Params string `json:"params,omitempty"`
+
+	// Action taken when the step fails.
+	ErrorHandler *types.ZBXInt `json:"error_handler,omitempty"`
+
+	// Parameters of the error handler.
+	ErrorHandlerParams string `json:"error_handler_params,omitempty"`
+}
+
+// ItemParameter is a parameter of a script or browser Item.
+type ItemParameter struct {
+	// Parameter name.
+	Name string `json:"name,omitempty"`
+
+	// Parameter value.
+	Value string `json:"value,omitempty"`
+}
+
+// Item is a Zabbix item.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/item/object
+type Item struct {
+	// ID of the item.
+	//
+	// Read-only.
+	ItemID string `json:"itemid,omitempty"`
+
+	// ID of the host or template the item belongs to.
+	HostID string `json:"hostid,omitempty"`
+
+	// ID of the host interface used by the item.
+	InterfaceID string `json:"interfaceid,omitempty"`
+
+	// Name of the item.
+	Name string `json:"name,omitempty"`
+
+	// Item key.
+	Key string `json:"key_,omitempty"`
+
+	// Type of the item.
+	Type *ItemType `json:"type,string,omitempty"`
+
+	// Type of information of the item.
+	ValueType *ValueType `json:"value_type,string,omitempty"`
+
+	// Update interval of the item, with flexible and scheduling intervals.
+	Delay *schedule.Delay `json:"delay,omitempty"`
+
+	// Time the history data is kept for, as a time period or a user macro.
+	History *types.ZBXDuration `json:"history,omitempty"`
+
+	// Time the trends data is kept for, as a time period or a user macro.
+	Trends *types.ZBXDuration `json:"trends,omitempty"`
+
+	// Value units.
+	Units string `json:"units,omitempty"`
+
+	// Description of the item.
+	Description string `json:"description,omitempty"`
+
+	// Status of the item.
+	Status *ItemStatus `json:"status,string,omitempty"`
+
+	// Whether the item is supported.
+	//
+	// Read-only.
+	State ItemState `json:"state,string,omitempty"`
+
+	// Error text if the item is not supported.
+	//
+	// Read-only.
+	Error string `json:"error,omitempty"`
+
+	// Origin of the item: 0 for a plain item, 4 for a discovered item.
+	//
+	// Read-only.
+	Flags types.ZBXInt `json:"flags,omitempty"`
+
+	// ID of the parent template item.
+	//
+	// Read-only.
+	TemplateID string `json:"templateid,omitempty"`
+
+	// ID of the master item of a dependent item.
+	MasterItemID string `json:"master_itemid,omitempty"`
+
+	// ID of the value map of the item.
+	ValueMapID string `json:"valuemapid,omitempty"`
+
+	// ID of the host inventory field populated by the item.
+	InventoryLink *types.ZBXInt `json:"inventory_link,omitempty"`
+
+	// Timeout of the check.
+	Timeout string `json:"timeout,omitempty"`
+
+	// URL of an HTTP agent item.
+	URL string `json:"url,omitempty"`
+
+	// Query parameters of an HTTP agent item.
+	QueryFields []map[string]interface{} `json:"query_fields,omitempty"`
+
+	// Headers of an HTTP agent item.
+	Headers []map[string]interface{} `json:"headers,omitempty"`
+
+	// Request body of an HTTP agent item.
+	Posts string `json:"posts,omitempty"`
+
+	// Request method of an HTTP agent item.
+	RequestMethod *types.ZBXInt `json:"request_method,omitempty"`
+
+	// Allowed HTTP status codes of an HTTP agent item.
+	StatusCodes string `json:"status_codes,omitempty"`
+
+	// SNMP OID of an SNMP agent item.
+	SNMPOID string `json:"snmp_oid,omitempty"`
+
+	// Formula, SQL query, script or commands depending on the item type.
+	Params string `json:"params,omitempty"`
+
+	// Parameters of a script or browser item.
+	Parameters []ItemParameter `json:"parameters,omitempty"`
+
+	// Username for authentication.
+	Username string `json:"username,omitempty"`
+
+	// Password for authentication.
+	Password string `json:"password,omitempty"`
+
+	// Hosts allowed to send data to a trapper item.
+	TrapperHosts string `json:"trapper_hosts,omitempty"`
+
+	// Universal unique identifier of a template item.
+	UUID string `json:"uuid,omitempty"`
+
+	// Tags of the item.
+	Tags []Tag `json:"tags,omitempty"`
+
+	// Preprocessing steps of the item.
+	Preprocessing []ItemPreprocessing `json:"preprocessing,omitempty"`
+
+	// Applications the item belongs to.
+	//
+	// Removed in Zabbix 5.4.
+	Applications []string `json:"applications,omitempty"`
+}
+
+// ItemGetParams are the parameters of item.get.
+type ItemGetParams struct {
+	zabbix.GetParameters
+
+	// Return only items with the given IDs.
+	ItemIDs []string `json:"itemids,omitempty"`
+
+	// Return only items that belong to hosts of the given groups.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// Return only items that belong to the given hosts.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// Return only items that use the given interfaces.
+	InterfaceIDs []string `json:"interfaceids,omitempty"`
+
+	// Return only items that belong to the given templates.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// Return only enabled items of monitored hosts.
+	Monitored bool `json:"monitored,omitempty"`
+
+	// Return only items of templates.
+	Templated bool `json:"templated,omitempty"`
+
+	// Include web items in the result.
+	WebItems bool `json:"webitems,omitempty"`
+
+	// Return the host of the item in the hosts property.
+	SelectHosts zabbix.SelectQuery `json:"selectHosts,omitempty"`
+
+	// Return the interface of the item in the interfaces property.
+	SelectInterfaces zabbix.SelectQuery `json:"selectInterfaces,omitempty"`
+
+	// Return the tags of the item in the tags property.
+	SelectTags zabbix.SelectQuery `json:"selectTags,omitempty"`
+
+	// Return the preprocessing steps of the item in the preprocessing property.
+	SelectPreprocessing zabbix.SelectQuery `json:"selectPreprocessing,omitempty"`
+
+	// Return the triggers using the item in the triggers property.
+	SelectTriggers zabbix.SelectQuery `json:"selectTriggers,omitempty"`
+}
+
+// GetItems calls item.get with the given parameters.
+//
+// zabbix.ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func GetItems(s *zabbix.Session, params ItemGetParams) ([]Item, error) {
+	result := make([]Item, 0)
+	if err := s.Get("item.get", params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, zabbix.ErrNotFound
+	}
+
+	return result, nil
+}
+
+// CreateItems calls item.create and returns the IDs of the created
+// objects.
+func CreateItems(s *zabbix.Session, objects ...Item) ([]string, error) {
+	return s.GetIDs("item.create", objects, "itemids")
+}
+
+// UpdateItems calls item.update and returns the IDs of the updated
+// objects.
+func UpdateItems(s *zabbix.Session, objects ...Item) ([]string, error) {
+	return s.GetIDs("item.update", objects, "itemids")
+}
+
+// DeleteItems calls item.delete and returns the IDs of the deleted
+// objects.
+func DeleteItems(s *zabbix.Session, ids ...string) ([]string, error) {
+	return s.GetIDs("item.delete", ids, "itemids")
+}
+
+// UserMedia is a media of a User.
+type UserMedia struct {
+	// ID of the media.
+	//
+	// Read-only.
+	MediaID string `json:"mediaid,omitempty"`
+
+	// ID of the media type used by the media.
+	MediaTypeID string `json:"mediatypeid,omitempty"`
+
+	// Addresses of the recipient.
+	SendTo []string `json:"sendto,omitempty"`
+
+	// Whether the media is enabled: 0 if it is, 1 otherwise.
+	Active *types.ZBXInt `json:"active,omitempty"`
+
+	// Trigger severities to send notifications about, as a bitmask.
+	Severity *types.ZBXInt `json:"severity,omitempty"`
+
+	// Time when notifications can be sent, as a time period.
+	Period string `json:"period,omitempty"`
+}
+
+// User is a Zabbix user.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/user/object
+type User struct {
+	// ID of the user.
+	//
+	// Read-only.
+	UserID string `json:"userid,omitempty"`
+
+	// User name.
+	//
+	// Available since Zabbix 5.4.
+	Username string `json:"username,omitempty"`
+
+	// User alias.
+	//
+	// Removed in Zabbix 5.4.
+	Alias string `json:"alias,omitempty"`
+
+	// Name of the user.
+	Name string `json:"name,omitempty"`
+
+	// Surname of the user.
+	Surname string `json:"surname,omitempty"`
+
+	// Password of the user. Not returned by the API.
+	Password string `json:"passwd,omitempty"`
+
+	// ID of the role of the user.
+	//
+	// Available since Zabbix 5.2.
+	RoleID string `json:"roleid,omitempty"`
+
+	// Whether to enable auto-login: 1 if it is enabled, 0 otherwise.
+	AutoLogin *types.ZBXInt `json:"autologin,omitempty"`
+
+	// User session life time, or 0 to never expire.
+	AutoLogout *types.ZBXDuration `json:"autologout,omitempty"`
+
+	// Language code of the user.
+	Lang string `json:"lang,omitempty"`
+
+	// Automatic refresh period.
+	Refresh string `json:"refresh,omitempty"`
+
+	// Number of object rows to show per page.
+	RowsPerPage *types.ZBXInt `json:"rows_per_page,omitempty"`
+
+	// Theme of the user.
+	Theme string `json:"theme,omitempty"`
+
+	// Time zone of the user.
+	Timezone string `json:"timezone,omitempty"`
+
+	// URL of the page to redirect the user to after logging in.
+	URL string `json:"url,omitempty"`
+
+	// Number of recent failed login attempts.
+	//
+	// Read-only.
+	AttemptFailed types.ZBXInt `json:"attempt_failed,omitempty"`
+
+	// IP address of the last failed login attempt.
+	//
+	// Read-only.
+	AttemptIP string `json:"attempt_ip,omitempty"`
+
+	// Time of the last failed login attempt.
+	//
+	// Read-only.
+	AttemptClock *types.ZBXUnixTimestamp `json:"attempt_clock,omitempty"`
+
+	// Time the user was last provisioned.
+	//
+	// Read-only. Available since Zabbix 6.4.
+	Provisioned *types.ZBXUnixTimestamp `json:"provisioned,omitempty"`
+
+	// ID of the user directory used by the user.
+	//
+	// Available since Zabbix 6.4.
+	UserDirectoryID string `json:"userdirectoryid,omitempty"`
+
+	// User groups to add the user to.
+	UserGroups []UserGroupID `json:"usrgrps,omitempty"`
+
+	// Medias of the user.
+	Medias []UserMedia `json:"medias,omitempty"`
+}
+
+// UserGetParams are the parameters of user.get.
+type UserGetParams struct {
+	zabbix.GetParameters
+
+	// Return only users with the given IDs.
+	UserIDs []string `json:"userids,omitempty"`
+
+	// Return only users that belong to the given user groups.
+	UserGroupIDs []string `json:"usrgrpids,omitempty"`
+
+	// Return only users that use the given medias.
+	MediaIDs []string `json:"mediaids,omitempty"`
+
+	// Return only users that use the given media types.
+	MediaTypeIDs []string `json:"mediatypeids,omitempty"`
+
+	// Add the access properties of the user to the result.
+	GetAccess bool `json:"getAccess,omitempty"`
+
+	// Return the medias of the user in the medias property.
+	SelectMedias zabbix.SelectQuery `json:"selectMedias,omitempty"`
+
+	// Return the media types of the user in the mediatypes property.
+	SelectMediaTypes zabbix.SelectQuery `json:"selectMediatypes,omitempty"`
+
+	// Return the user groups of the user in the usrgrps property.
+	SelectUserGroups zabbix.SelectQuery `json:"selectUsrgrps,omitempty"`
+
+	// Return the role of the user in the role property.
+	//
+	// Available since Zabbix 5.2.
+	SelectRole zabbix.SelectQuery `json:"selectRole,omitempty"`
+}
+
+// GetUsers calls user.get with the given parameters.
+//
+// zabbix.ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func GetUsers(s *zabbix.Session, params UserGetParams) ([]User, error) {
+	result := make([]User, 0)
+	if err := s.Get("user.get", params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, zabbix.ErrNotFound
+	}
+
+	return result, nil
+}
+
+// CreateUsers calls user.create and returns the IDs of the created
+// objects.
+func CreateUsers(s *zabbix.Session, objects ...User) ([]string, error) {
+	return s.GetIDs("user.create", objects, "userids")
+}
+
+// UpdateUsers calls user.update and returns the IDs of the updated
+// objects.
+func UpdateUsers(s *zabbix.Session, objects ...User) ([]string, error) {
+	return s.GetIDs("user.update", objects, "userids")
+}
+
+// DeleteUsers calls user.delete and returns the IDs of the deleted
+// objects.
+func DeleteUsers(s *zabbix.Session, ids ...string) ([]string, error) {
+	return s.GetIDs("user.delete", ids, "userids")
+}
+
+// MediaTypeParameter is a parameter of a webhook or script MediaType.
+type MediaTypeParameter struct {
+	// Parameter name.
+	Name string `json:"name,omitempty"`
+
+	// Parameter value.
+	Value string `json:"value,omitempty"`
+
+	// Order of the parameter of a script media type.
+	//
+	// Available since Zabbix 6.4.
+	SortOrder *types.ZBXInt `json:"sortorder,omitempty"`
+}
+
+// MediaTypeMessage is a default message template of a MediaType.
+type MediaTypeMessage struct {
+	// Event source of the message.
+	EventSource *types.ZBXInt `json:"eventsource,omitempty"`
+
+	// Operation mode of the message.
+	Recovery *types.ZBXInt `json:"recovery,omitempty"`
+
+	// Message subject.
+	Subject string `json:"subject,omitempty"`
+
+	// Message text.
+	Message string `json:"message,omitempty"`
+}
+
+// MediaType is a Zabbix media type.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/mediatype/object
+type MediaType struct {
+	// ID of the media type.
+	//
+	// Read-only.
+	MediaTypeID string `json:"mediatypeid,omitempty"`
+
+	// Name of the media type.
+	Name string `json:"name,omitempty"`
+
+	// Transport used by the media type.
+	Type *MediaTypeType `json:"type,string,omitempty"`
+
+	// Whether the media type is enabled.
+	Status *MediaTypeStatus `json:"status,string,omitempty"`
+
+	// Description of the media type.
+	Description string `json:"description,omitempty"`
+
+	// Name of the script file of a script media type.
+	ExecPath string `json:"exec_path,omitempty"`
+
+	// Serial device name of the GSM modem.
+	GSMModem string `json:"gsm_modem,omitempty"`
+
+	// Email provider.
+	//
+	// Available since Zabbix 6.2.
+	Provider *types.ZBXInt `json:"provider,omitempty"`
+
+	// SMTP server.
+	SMTPServer string `json:"smtp_server,omitempty"`
+
+	// SMTP server port.
+	SMTPPort *types.ZBXInt `json:"smtp_port,omitempty"`
+
+	// SMTP HELO.
+	SMTPHelo string `json:"smtp_helo,omitempty"`
+
+	// Email address notifications are sent from.
+	SMTPEmail string `json:"smtp_email,omitempty"`
+
+	// SMTP connection security level.
+	SMTPSecurity *types.ZBXInt `json:"smtp_security,omitempty"`
+
+	// Whether to verify the host of the SMTP server certificate.
+	SMTPVerifyHost *types.ZBXBoolean `json:"smtp_verify_host,omitempty"`
+
+	// Whether to verify the SMTP server certificate.
+	SMTPVerifyPeer *types.ZBXBoolean `json:"smtp_verify_peer,omitempty"`
+
+	// SMTP authentication method.
+	SMTPAuthentication *types.ZBXInt `json:"smtp_authentication,omitempty"`
+
+	// User name.
+	Username string `json:"username,omitempty"`
+
+	// Authentication password.
+	Password string `json:"passwd,omitempty"`
+
+	// Message format.
+	//
+	// Removed in Zabbix 7.0.
+	//
+	// Deprecated: Deprecated since Zabbix 6.4.
+	ContentType *types.ZBXInt `json:"content_type,omitempty"`
+
+	// Message format.
+	//
+	// Available since Zabbix 7.0.
+	MessageFormat *types.ZBXInt `json:"message_format,omitempty"`
+
+	// Maximum number of alerts processed in parallel.
+	MaxSessions *types.ZBXInt `json:"maxsessions,omitempty"`
+
+	// Maximum number of attempts to send an alert.
+	MaxAttempts *types.ZBXInt `json:"maxattempts,omitempty"`
+
+	// Interval between retry attempts.
+	AttemptInterval string `json:"attempt_interval,omitempty"`
+
+	// Script of a webhook media type.
+	Script string `json:"script,omitempty"`
+
+	// Timeout of a webhook media type.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Whether to process the properties of webhook responses as tags.
+	ProcessTags *types.ZBXBoolean `json:"process_tags,omitempty"`
+
+	// Whether to add an entry linking to EventMenuURL to the event menu.
+	ShowEventMenu *types.ZBXBoolean `json:"show_event_menu,omitempty"`
+
+	// URL of the event menu entry.
+	EventMenuURL string `json:"event_menu_url,omitempty"`
+
+	// Name of the event menu entry.
+	EventMenuName string `json:"event_menu_name,omitempty"`
+
+	// Parameters of a webhook or script media type.
+	Parameters []MediaTypeParameter `json:"parameters,omitempty"`
+
+	// Default message templates of the media type.
+	MessageTemplates []MediaTypeMessage `json:"message_templates,omitempty"`
+}
+
+// MediaTypeGetParams are the parameters of mediatype.get.
+type MediaTypeGetParams struct {
+	zabbix.GetParameters
+
+	// Return only media types with the given IDs.
+	MediaTypeIDs []string `json:"mediatypeids,omitempty"`
+
+	// Return only media types used by the given medias.
+	MediaIDs []string `json:"mediaids,omitempty"`
+
+	// Return only media types used by the given users.
+	UserIDs []string `json:"userids,omitempty"`
+
+	// Return the message templates of the media type in the message_templates
+	// property.
+	SelectMessageTemplates zabbix.SelectQuery `json:"selectMessageTemplates,omitempty"`
+
+	// Return the users using the media type in the users property.
+	SelectUsers zabbix.SelectQuery `json:"selectUsers,omitempty"`
+
+	// Return the actions using the media type in the actions property.
+	SelectActions zabbix.SelectQuery `json:"selectActions,omitempty"`
+}
+
+// GetMediaTypes calls mediatype.get with the given parameters.
+//
+// zabbix.ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func GetMediaTypes(s *zabbix.Session, params MediaTypeGetParams) ([]MediaType, error) {
+	result := make([]MediaType, 0)
+	if err := s.Get("mediatype.get", params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, zabbix.ErrNotFound
+	}
+
+	return result, nil
+}
+
+// CreateMediaTypes calls mediatype.create and returns the IDs of the created
+// objects.
+func CreateMediaTypes(s *zabbix.Session, objects ...MediaType) ([]string, error) {
+	return s.GetIDs("mediatype.create", objects, "mediatypeids")
+}
+
+// UpdateMediaTypes calls mediatype.update and returns the IDs of the updated
+// objects.
+func UpdateMediaTypes(s *zabbix.Session, objects ...MediaType) ([]string, error) {
+	return s.GetIDs("mediatype.update", objects, "mediatypeids")
+}
+
+// DeleteMediaTypes calls mediatype.delete and returns the IDs of the deleted
+// objects.
+func DeleteMediaTypes(s *zabbix.Session, ids ...string) ([]string, error) {
+	return s.GetIDs("mediatype.delete", ids, "mediatypeids")
+}
+
+// fields are the fields of the API objects with the versions they were added
+// and removed in.
+var fields = map[string][]field{
+	"host": {
+		{"hostid", "", ""},
+		{"host", "", ""},
+		{"name", "", ""},
+		{"description", "", ""},
+		{"status", "", ""},
+		{"inventory_mode", "", ""},
+		{"monitored_by", "7.0", ""},
+		{"proxyid", "7.0", ""},
+		{"proxy_groupid", "7.0", ""},
+		{"assigned_proxyid", "7.0", ""},
+		{"proxy_hostid", "", "7.0"},
+		{"active_available", "6.4", ""},
+		{"flags", "", ""},
+		{"maintenanceid", "", ""},
+		{"maintenance_status", "", ""},
+		{"maintenance_type", "", ""},
+		{"maintenance_from", "", ""},
+		{"ipmi_authtype", "", ""},
+		{"ipmi_privilege", "", ""},
+		{"ipmi_username", "", ""},
+		{"ipmi_password", "", ""},
+		{"tls_connect", "", ""},
+		{"tls_accept", "", ""},
+		{"tls_issuer", "", ""},
+		{"tls_subject", "", ""},
+		{"tls_psk_identity", "", ""},
+		{"tls_psk", "", ""},
+		{"groups", "", ""},
+		{"templates", "", ""},
+		{"interfaces", "", ""},
+		{"tags", "", ""},
+		{"inventory", "", ""},
+	},
+	"hostinterface": {
+		{"interfaceid", "", ""},
+		{"hostid", "", ""},
+		{"type", "", ""},
+		{"main", "", ""},
+		{"useip", "", ""},
+		{"ip", "", ""},
+		{"dns", "", ""},
+		{"port", "", ""},
+		{"details", "", ""},
+		{"available", "", ""},
+		{"error", "", ""},
+		{"errors_from", "", ""},
+		{"disable_until", "", ""},
+	},
+	"item": {
+		{"itemid", "", ""},
+		{"hostid", "", ""},
+		{"interfaceid", "", ""},
+		{"name", "", ""},
+		{"key_", "", ""},
+		{"type", "", ""},
+		{"value_type", "", ""},
+		{"delay", "", ""},
+		{"history", "", ""},
+		{"trends", "", ""},
+		{"units", "", ""},
+		{"description", "", ""},
+		{"status", "", ""},
+		{"state", "", ""},
+		{"error", "", ""},
+		{"flags", "", ""},
+		{"templateid", "", ""},
+		{"master_itemid", "", ""},
+		{"valuemapid", "", ""},
+		{"inventory_link", "", ""},
+		{"timeout", "", ""},
+		{"url", "", ""},
+		{"query_fields", "", ""},
+		{"headers", "", ""},
+		{"posts", "", ""},
+		{"request_method", "", ""},
+		{"status_codes", "", ""},
+		{"snmp_oid", "", ""},
+		{"params", "", ""},
+		{"parameters", "", ""},
+		{"username", "", ""},
+		{"password", "", ""},
+		{"trapper_hosts", "", ""},
+		{"uuid", "", ""},
+		{"tags", "", ""},
+		{"preprocessing", "", ""},
+		{"applications", "", "5.4"},
+	},
+	"user": {
+		{"userid", "", ""},
+		{"username", "5.4", ""},
+		{"alias", "", "5.4"},
+		{"name", "", ""},
+		{"surname", "", ""},
+		{"passwd", "", ""},
+		{"roleid", "5.2", ""},
+		{"autologin", "", ""},
+		{"autologout", "", ""},
+		{"lang", "", ""},
+		{"refresh", "", ""},
+		{"rows_per_page", "", ""},
+		{"theme", "", ""},
+		{"timezone", "", ""},
+		{"url", "", ""},
+		{"attempt_failed", "", ""},
+		{"attempt_ip", "", ""},
+		{"attempt_clock", "", ""},
+		{"provisioned", "6.4", ""},
+		{"userdirectoryid", "6.4", ""},
+		{"usrgrps", "", ""},
+		{"medias", "", ""},
+	},
+	"mediatype": {
+		{"mediatypeid", "", ""},
+		{"name", "", ""},
+		{"type", "", ""},
+		{"status", "", ""},
+		{"description", "", ""},
+		{"exec_path", "", ""},
+		{"gsm_modem", "", ""},
+		{"provider", "6.2", ""},
+		{"smtp_server", "", ""},
+		{"smtp_port", "", ""},
+		{"smtp_helo", "", ""},
+		{"smtp_email", "", ""},
+		{"smtp_security", "", ""},
+		{"smtp_verify_host", "", ""},
+		{"smtp_verify_peer", "", ""},
+		{"smtp_authentication", "", ""},
+		{"username", "", ""},
+		{"passwd", "", ""},
+		{"content_type", "", "7.0"},
+		{"message_format", "7.0", ""},
+		{"maxsessions", "", ""},
+		{"maxattempts", "", ""},
+		{"attempt_interval", "", ""},
+		{"script", "", ""},
+		{"timeout", "", ""},
+		{"process_tags", "", ""},
+		{"show_event_menu", "", ""},
+		{"event_menu_url", "", ""},
+		{"event_menu_name", "", ""},
+		{"parameters", "", ""},
+		{"message_templates", "", ""},
+	},
+}
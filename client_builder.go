@@ -9,6 +9,9 @@ type ClientBuilder struct {
 	url         string
 	credentials map[string]string
 	client      *http.Client
+	warn        func(w *Warning)
+	strict      StrictMode
+	decodeWarn  func(method string, issue *DecodeIssue)
 }
 
 // WithCache sets cache for Zabbix sessions
@@ -34,6 +37,23 @@ func (builder *ClientBuilder) WithHTTPClient(client *http.Client) *ClientBuilder
 	return builder
 }
 
+// WithWarnings sets the function called with the request parameters rewritten
+// or ignored for the version of the Zabbix API
+func (builder *ClientBuilder) WithWarnings(warn func(w *Warning)) *ClientBuilder {
+	builder.warn = warn
+
+	return builder
+}
+
+// WithStrictDecoding sets how the fields of API responses which do not decode
+// cleanly are reported, and the function called with them in StrictWarn mode
+func (builder *ClientBuilder) WithStrictDecoding(mode StrictMode, warn func(method string, issue *DecodeIssue)) *ClientBuilder {
+	builder.strict = mode
+	builder.decodeWarn = warn
+
+	return builder
+}
+
 // Connect creates Zabbix API client and connects to the API server
 // or provides a cached server if any cache was specified
 func (builder *ClientBuilder) Connect() (session *Session, err error) {
@@ -41,12 +61,21 @@ func (builder *ClientBuilder) Connect() (session *Session, err error) {
 	if builder.hasCache && builder.cache.HasSession() {
 		if session, err = builder.cache.GetSession(); err == nil {
 			session.client = builder.client
+			session.Warn = builder.warn
+			session.Strict = builder.strict
+			session.DecodeWarn = builder.decodeWarn
 			return session, nil
 		}
 	}
 
 	// Otherwise - login to a Zabbix server
-	session = &Session{URL: builder.url, client: builder.client}
+	session = &Session{
+		URL:        builder.url,
+		client:     builder.client,
+		Warn:       builder.warn,
+		Strict:     builder.strict,
+		DecodeWarn: builder.decodeWarn,
+	}
 	err = session.login(builder.credentials["username"], builder.credentials["password"])
 
 	if err != nil {
@@ -0,0 +1,389 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ConfigurationFormat is the serialization format of exported and imported
+// Zabbix configuration.
+type ConfigurationFormat string
+
+const (
+	// ConfigurationFormatYAML is the YAML configuration format.
+	ConfigurationFormatYAML ConfigurationFormat = "yaml"
+
+	// ConfigurationFormatXML is the XML configuration format.
+	ConfigurationFormatXML ConfigurationFormat = "xml"
+
+	// ConfigurationFormatJSON is the JSON configuration format.
+	ConfigurationFormatJSON ConfigurationFormat = "json"
+)
+
+// ConfigurationExportOptions selects the objects to export by their IDs.
+type ConfigurationExportOptions struct {
+	// HostGroupIDs are the IDs of the host groups to export.
+	HostGroupIDs []string `json:"host_groups,omitempty"`
+
+	// TemplateGroupIDs are the IDs of the template groups to export.
+	TemplateGroupIDs []string `json:"template_groups,omitempty"`
+
+	// HostIDs are the IDs of the hosts to export.
+	HostIDs []string `json:"hosts,omitempty"`
+
+	// ImageIDs are the IDs of the images to export.
+	ImageIDs []string `json:"images,omitempty"`
+
+	// MapIDs are the IDs of the maps to export.
+	MapIDs []string `json:"maps,omitempty"`
+
+	// MediaTypeIDs are the IDs of the media types to export.
+	MediaTypeIDs []string `json:"mediaTypes,omitempty"`
+
+	// TemplateIDs are the IDs of the templates to export.
+	TemplateIDs []string `json:"templates,omitempty"`
+}
+
+// ConfigurationExportParams is params for configuration.export call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/configuration/export
+type ConfigurationExportParams struct {
+	// Options selects the objects to export.
+	Options ConfigurationExportOptions `json:"options"`
+
+	// Format is the format of the exported data.
+	Format ConfigurationFormat `json:"format"`
+
+	// PrettyPrint adds indentation to JSON exports.
+	PrettyPrint bool `json:"prettyprint,omitempty"`
+}
+
+// ImportRule controls how objects of a type are imported. Not every rule is
+// accepted for every object type, see the Zabbix API docs.
+type ImportRule struct {
+	// CreateMissing creates objects which do not exist yet.
+	CreateMissing bool `json:"createMissing,omitempty"`
+
+	// UpdateExisting updates objects which already exist.
+	UpdateExisting bool `json:"updateExisting,omitempty"`
+
+	// DeleteMissing deletes objects which are not present in the import.
+	DeleteMissing bool `json:"deleteMissing,omitempty"`
+}
+
+// ImportRules controls how objects of each type are imported. Objects of
+// types without a rule are ignored.
+type ImportRules struct {
+	DiscoveryRules     *ImportRule `json:"discoveryRules,omitempty"`
+	Graphs             *ImportRule `json:"graphs,omitempty"`
+	HostGroups         *ImportRule `json:"host_groups,omitempty"`
+	TemplateGroups     *ImportRule `json:"template_groups,omitempty"`
+	Hosts              *ImportRule `json:"hosts,omitempty"`
+	HTTPTests          *ImportRule `json:"httptests,omitempty"`
+	Images             *ImportRule `json:"images,omitempty"`
+	Items              *ImportRule `json:"items,omitempty"`
+	Maps               *ImportRule `json:"maps,omitempty"`
+	MediaTypes         *ImportRule `json:"mediaTypes,omitempty"`
+	TemplateLinkage    *ImportRule `json:"templateLinkage,omitempty"`
+	Templates          *ImportRule `json:"templates,omitempty"`
+	TemplateDashboards *ImportRule `json:"templateDashboards,omitempty"`
+	Triggers           *ImportRule `json:"triggers,omitempty"`
+	ValueMaps          *ImportRule `json:"valueMaps,omitempty"`
+}
+
+// NewImportRules returns ImportRules which create missing and update
+// existing objects of every type, but never delete anything.
+func NewImportRules() ImportRules {
+	createOrUpdate := func() *ImportRule {
+		return &ImportRule{CreateMissing: true, UpdateExisting: true}
+	}
+
+	return ImportRules{
+		DiscoveryRules:     createOrUpdate(),
+		Graphs:             createOrUpdate(),
+		HostGroups:         createOrUpdate(),
+		TemplateGroups:     createOrUpdate(),
+		Hosts:              createOrUpdate(),
+		HTTPTests:          createOrUpdate(),
+		Images:             createOrUpdate(),
+		Items:              createOrUpdate(),
+		Maps:               createOrUpdate(),
+		MediaTypes:         createOrUpdate(),
+		TemplateLinkage:    &ImportRule{CreateMissing: true},
+		Templates:          createOrUpdate(),
+		TemplateDashboards: createOrUpdate(),
+		Triggers:           createOrUpdate(),
+		ValueMaps:          createOrUpdate(),
+	}
+}
+
+// ConfigurationImportParams is params for configuration.import and
+// configuration.importcompare calls
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/configuration/import
+type ConfigurationImportParams struct {
+	// Format is the format of Source.
+	Format ConfigurationFormat `json:"format"`
+
+	// Source is the serialized configuration to import.
+	Source string `json:"source"`
+
+	// Rules controls how objects of each type are imported.
+	Rules ImportRules `json:"rules"`
+}
+
+// ImportChanges lists the objects of one type an import would add, remove
+// or update.
+type ImportChanges struct {
+	Added   []map[string]interface{} `json:"added,omitempty"`
+	Removed []map[string]interface{} `json:"removed,omitempty"`
+	Updated []ImportUpdate           `json:"updated,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ImportChanges) UnmarshalJSON(data []byte) error {
+	type importChanges ImportChanges
+	var changes importChanges
+	if err := unmarshalObject(data, &changes); err != nil {
+		return err
+	}
+
+	*c = ImportChanges(changes)
+	return nil
+}
+
+// ImportUpdate is an object an import would update.
+type ImportUpdate struct {
+	// Before is the object as currently configured.
+	Before map[string]interface{}
+
+	// After is the object as it would be configured by the import.
+	After map[string]interface{}
+
+	// Changes lists the changes of the child objects, such as the items of
+	// a template, keyed by object type.
+	Changes ImportComparison
+}
+
+// UnmarshalJSON implements json.Unmarshaler. All fields other than before and
+// after are child object changes.
+func (u *ImportUpdate) UnmarshalJSON(data []byte) error {
+	fields := make(map[string]json.RawMessage)
+	if err := unmarshalObject(data, &fields); err != nil {
+		return err
+	}
+
+	*u = ImportUpdate{}
+	for name, value := range fields {
+		var err error
+		switch name {
+		case "before":
+			err = unmarshalObject(value, &u.Before)
+		case "after":
+			err = unmarshalObject(value, &u.After)
+		default:
+			var changes ImportChanges
+			if err = json.Unmarshal(value, &changes); err == nil {
+				if u.Changes == nil {
+					u.Changes = make(ImportComparison)
+				}
+				u.Changes[name] = changes
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportComparison lists the changes an import would make, keyed by object
+// type.
+type ImportComparison map[string]ImportChanges
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *ImportComparison) UnmarshalJSON(data []byte) error {
+	changes := make(map[string]ImportChanges)
+	if err := unmarshalObject(data, &changes); err != nil {
+		return err
+	}
+
+	*c = changes
+	return nil
+}
+
+// unmarshalObject unmarshals a JSON object, accepting the empty array PHP
+// encodes empty objects as.
+func unmarshalObject(data []byte, v interface{}) error {
+	if string(data) == "[]" {
+		return nil
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// ExportConfiguration exports the configuration of the selected objects and
+// returns it serialized in the requested format.
+//
+// An error is returned if a transport, parsing or API error occurs.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/configuration/export
+func (c *Session) ExportConfiguration(params ConfigurationExportParams) (string, error) {
+	options, err := c.configurationGroups(params.Options)
+	if err != nil {
+		return "", err
+	}
+
+	req := map[string]interface{}{
+		"options": options,
+		"format":  params.Format,
+	}
+	// prettyprint is only supported since Zabbix 5.4
+	if params.PrettyPrint {
+		req["prettyprint"] = true
+	}
+
+	var data string
+	if err := c.Get("configuration.export", req, &data); err != nil {
+		return "", err
+	}
+
+	return data, nil
+}
+
+// ImportConfiguration imports serialized configuration.
+//
+// An error is returned if a transport, parsing or API error occurs.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/configuration/import
+func (c *Session) ImportConfiguration(params ConfigurationImportParams) error {
+	req, err := c.configurationImportParams(params)
+	if err != nil {
+		return err
+	}
+
+	var ok bool
+	if err := c.Get("configuration.import", req, &ok); err != nil {
+		return err
+	}
+
+	if !ok {
+		return errors.New("Configuration import failed")
+	}
+
+	return nil
+}
+
+// CompareImportConfiguration returns the changes ImportConfiguration would
+// make with the given params, without changing anything.
+//
+// An UnsupportedVersionError is returned if the connected Zabbix API is older
+// than v5.4.
+// An error is returned if a transport, parsing or API error occurs.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/configuration/importcompare
+func (c *Session) CompareImportConfiguration(params ConfigurationImportParams) (ImportComparison, error) {
+	ver, err := c.GetVersion()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve Zabbix API version: %v", err)
+	}
+
+	if ver.Compare(zabbixVersion540) < 0 {
+		return nil, &UnsupportedVersionError{
+			Method:   "configuration.importcompare",
+			Required: zabbixVersion540.String(),
+			Version:  ver.String(),
+		}
+	}
+
+	req, err := c.configurationImportParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := make(ImportComparison)
+	if err := c.Get("configuration.importcompare", req, &comparison); err != nil {
+		return nil, err
+	}
+
+	return comparison, nil
+}
+
+func (c *Session) configurationImportParams(params ConfigurationImportParams) (map[string]interface{}, error) {
+	rules, err := c.configurationGroups(params.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"format": params.Format,
+		"source": params.Source,
+		"rules":  rules,
+	}, nil
+}
+
+// configurationGroups converts export options or import rules for the
+// connected Zabbix API. Before v6.2 host and template groups were a single
+// "groups" object type.
+func (c *Session) configurationGroups(v interface{}) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+
+	ver, err := c.GetVersion()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve Zabbix API version: %v", err)
+	}
+
+	if ver.Compare(zabbixVersion620) >= 0 {
+		return fields, nil
+	}
+
+	hostGroups, hasHostGroups := fields["host_groups"]
+	templateGroups, hasTemplateGroups := fields["template_groups"]
+	delete(fields, "host_groups")
+	delete(fields, "template_groups")
+
+	switch {
+	case hasHostGroups && hasTemplateGroups && hostGroups[0] == '[':
+		// merge the group IDs of export options
+		var hostGroupIDs, templateGroupIDs []string
+		if err := json.Unmarshal(hostGroups, &hostGroupIDs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(templateGroups, &templateGroupIDs); err != nil {
+			return nil, err
+		}
+		b, _ := json.Marshal(append(hostGroupIDs, templateGroupIDs...))
+		fields["groups"] = b
+	case hasHostGroups && hasTemplateGroups:
+		// merge import rules, allowing what either rule allows
+		var hostRule, templateRule ImportRule
+		if err := json.Unmarshal(hostGroups, &hostRule); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(templateGroups, &templateRule); err != nil {
+			return nil, err
+		}
+		b, _ := json.Marshal(ImportRule{
+			CreateMissing:  hostRule.CreateMissing || templateRule.CreateMissing,
+			UpdateExisting: hostRule.UpdateExisting || templateRule.UpdateExisting,
+			DeleteMissing:  hostRule.DeleteMissing || templateRule.DeleteMissing,
+		})
+		fields["groups"] = b
+	case hasHostGroups:
+		fields["groups"] = hostGroups
+	case hasTemplateGroups:
+		fields["groups"] = templateGroups
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,208 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestExportConfiguration(t *testing.T) {
+	tests := map[string]string{
+		"7.0.0": `{"host_groups":["2"],"template_groups":["1"],"templates":["10001"]}`,
+		"6.0.0": `{"groups":["2","1"],"templates":["10001"]}`,
+	}
+
+	for version, expected := range tests {
+		server := test.NewServer(t)
+		server.Handle("configuration.export", func(params json.RawMessage) (interface{}, error) {
+			return "zabbix_export:\n  version: '7.0'\n", nil
+		})
+		session := server.Session(t, version)
+
+		data, err := session.ExportConfiguration(zabbix.ConfigurationExportParams{
+			Format: zabbix.ConfigurationFormatYAML,
+			Options: zabbix.ConfigurationExportOptions{
+				HostGroupIDs:     []string{"2"},
+				TemplateGroupIDs: []string{"1"},
+				TemplateIDs:      []string{"10001"},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data != "zabbix_export:\n  version: '7.0'\n" {
+			t.Errorf("Unexpected export: %q", data)
+		}
+
+		calls := server.Calls()
+		var params map[string]json.RawMessage
+		if err := json.Unmarshal(calls[len(calls)-1].Params.(json.RawMessage), &params); err != nil {
+			t.Fatal(err)
+		}
+
+		if string(params["options"]) != expected || string(params["format"]) != `"yaml"` {
+			t.Errorf("Unexpected export params for v%s: %s %s", version, params["options"], params["format"])
+		}
+
+		// prettyprint is unknown to servers older than 5.4
+		if _, ok := params["prettyprint"]; ok {
+			t.Errorf("Expected no prettyprint for v%s but got %s", version, params["prettyprint"])
+		}
+	}
+}
+
+func TestExportConfigurationPrettyPrint(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("configuration.export", func(params json.RawMessage) (interface{}, error) {
+		return "{}", nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	_, err := session.ExportConfiguration(zabbix.ConfigurationExportParams{
+		Format:      zabbix.ConfigurationFormatJSON,
+		PrettyPrint: true,
+		Options:     zabbix.ConfigurationExportOptions{TemplateIDs: []string{"10001"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal(server.Calls()[0].Params.(json.RawMessage), &params); err != nil {
+		t.Fatal(err)
+	}
+	if params["prettyprint"] != true {
+		t.Errorf("Expected prettyprint but got %v", params)
+	}
+}
+
+func TestCompareImportConfigurationUnsupported(t *testing.T) {
+	server := test.NewServer(t)
+	session := server.Session(t, "5.0.0")
+
+	_, err := session.CompareImportConfiguration(zabbix.ConfigurationImportParams{
+		Format: zabbix.ConfigurationFormatJSON,
+		Source: `{"zabbix_export":{"version":"5.0"}}`,
+	})
+	if _, ok := err.(*zabbix.UnsupportedVersionError); !ok {
+		t.Fatalf("Expected UnsupportedVersionError but got %v", err)
+	}
+	if len(server.Calls()) != 0 {
+		t.Errorf("Expected no API calls but got %v", server.Calls())
+	}
+}
+
+func TestImportConfiguration(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("configuration.import", func(params json.RawMessage) (interface{}, error) {
+		return true, nil
+	})
+	session := server.Session(t, "6.0.0")
+
+	err := session.ImportConfiguration(zabbix.ConfigurationImportParams{
+		Format: zabbix.ConfigurationFormatJSON,
+		Source: `{"zabbix_export":{"version":"6.0"}}`,
+		Rules: zabbix.ImportRules{
+			HostGroups:     &zabbix.ImportRule{CreateMissing: true},
+			TemplateGroups: &zabbix.ImportRule{UpdateExisting: true},
+			Items:          &zabbix.ImportRule{CreateMissing: true, DeleteMissing: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	var params struct {
+		Rules json.RawMessage `json:"rules"`
+	}
+	if err := json.Unmarshal(calls[len(calls)-1].Params.(json.RawMessage), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	// host and template group rules are merged before Zabbix 6.2
+	expect := `{"groups":{"createMissing":true,"updateExisting":true},"items":{"createMissing":true,"deleteMissing":true}}`
+	if string(params.Rules) != expect {
+		t.Errorf("Expected rules %s but got %s", expect, params.Rules)
+	}
+}
+
+func TestNewImportRules(t *testing.T) {
+	rules := zabbix.NewImportRules()
+	if !rules.Templates.CreateMissing || !rules.Templates.UpdateExisting || rules.Templates.DeleteMissing {
+		t.Errorf("Unexpected template rule: %+v", rules.Templates)
+	}
+
+	if rules.TemplateLinkage.UpdateExisting {
+		t.Errorf("Template linkage does not support updateExisting")
+	}
+}
+
+func TestCompareImportConfiguration(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("configuration.importcompare", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`{
+			"templates": {
+				"updated": [{
+					"before": {"uuid": "e2d2b4e4ac28483996cc11fe42823d57", "template": "Linux by Zabbix agent"},
+					"after": {"uuid": "e2d2b4e4ac28483996cc11fe42823d57", "template": "Linux by Zabbix agent", "description": "Updated"},
+					"items": {
+						"added": [{"uuid": "aa", "name": "CPU steal time", "key": "system.cpu.util[,steal]"}],
+						"removed": [{"uuid": "bb", "name": "Free swap", "key": "system.swap.size[,free]"}]
+					},
+					"tags": []
+				}]
+			},
+			"mediaTypes": []
+		}`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	comparison, err := session.CompareImportConfiguration(zabbix.ConfigurationImportParams{
+		Format: zabbix.ConfigurationFormatYAML,
+		Source: "zabbix_export: {}",
+		Rules:  zabbix.NewImportRules(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	updated := comparison["templates"].Updated
+	if len(updated) != 1 || updated[0].After["description"] != "Updated" || updated[0].Before["description"] != nil {
+		t.Fatalf("Unexpected template changes: %+v", comparison["templates"])
+	}
+
+	items := updated[0].Changes["items"]
+	if len(items.Added) != 1 || items.Added[0]["name"] != "CPU steal time" || len(items.Removed) != 1 {
+		t.Errorf("Unexpected item changes: %+v", items)
+	}
+
+	if !reflect.DeepEqual(comparison["mediaTypes"], zabbix.ImportChanges{}) {
+		t.Errorf("Expected no media type changes but got %+v", comparison["mediaTypes"])
+	}
+}
+
+func TestCompareImportConfigurationEmpty(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("configuration.importcompare", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	comparison, err := session.CompareImportConfiguration(zabbix.ConfigurationImportParams{
+		Format: zabbix.ConfigurationFormatYAML,
+		Source: "zabbix_export: {}",
+		Rules:  zabbix.NewImportRules(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(comparison) != 0 {
+		t.Errorf("Expected no changes but got %+v", comparison)
+	}
+}
@@ -1,6 +1,8 @@
 package zabbix
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -20,3 +22,59 @@ func dprintf(format string, a ...interface{}) {
 		fmt.Fprintf(os.Stderr, format, a...)
 	}
 }
+
+// secretFields are the fields holding passwords in API requests.
+var secretFields = []string{"password", "passwd", "current_passwd"}
+
+// redact returns the given JSON message with the values of secret macros and
+// passwords replaced by MacroRedacted, so that it can be printed for
+// debugging. The message is returned unchanged if it holds no secrets.
+func redact(b []byte) []byte {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil || !redactValue(v) {
+		return b
+	}
+
+	r, err := json.Marshal(v)
+	if err != nil {
+		return b
+	}
+	return r
+}
+
+// redactValue redacts the secrets of a decoded JSON value in place and
+// returns true if any was found.
+func redactValue(v interface{}) bool {
+	redacted := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		_, isMacro := v["macro"]
+		if _, ok := v["value"]; ok && isMacro && fmt.Sprint(v["type"]) == strconv.Itoa(int(MacroTypeSecret)) {
+			v["value"] = MacroRedacted
+			redacted = true
+		}
+
+		for _, field := range secretFields {
+			if _, ok := v[field].(string); ok {
+				v[field] = MacroRedacted
+				redacted = true
+			}
+		}
+
+		for _, e := range v {
+			if redactValue(e) {
+				redacted = true
+			}
+		}
+
+	case []interface{}:
+		for _, e := range v {
+			if redactValue(e) {
+				redacted = true
+			}
+		}
+	}
+	return redacted
+}
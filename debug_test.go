@@ -0,0 +1,25 @@
+package zabbix
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	tests := map[string]string{
+		`{"method":"usermacro.create","params":[{"macro":"{$A}","value":"s3cr3t","type":"1"},{"macro":"{$B}","value":"plain","type":"0"}]}`: `{"method":"usermacro.create","params":[{"macro":"{$A}","type":"1","value":"******"},{"macro":"{$B}","type":"0","value":"plain"}]}`,
+		`{"params":{"username":"Admin","password":"zabbix"}}`:                                                                               `{"params":{"password":"******","username":"Admin"}}`,
+		`{"result":[{"macro":"{$C}","value":"secret/zabbix:pass","type":2}]}`:                                                               `{"result":[{"macro":"{$C}","value":"secret/zabbix:pass","type":2}]}`,
+		`not json`: `not json`,
+	}
+
+	for in, expected := range tests {
+		if out := string(redact([]byte(in))); out != expected {
+			t.Errorf("Unexpected redaction of %s: %s", in, out)
+		}
+	}
+
+	if out := string(redact([]byte(`[{"macro":"{$A}","value":"x","type":1}]`))); strings.Contains(out, `"x"`) {
+		t.Errorf("Secret value with numeric type was not redacted: %s", out)
+	}
+}
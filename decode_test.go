@@ -0,0 +1,146 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+)
+
+// payloads holding the values returned by different Zabbix versions and
+// methods for the same fields
+var lenientPayloads = map[string]string{
+	"strings": `{
+		"hosts": [{"hostid":"10084","status":"1","available":"1","inventory_mode":"1","tls_connect":"2","flags":"4","inventory":{"os":"Linux"}}],
+		"items": [{"itemid":"23296","lastclock":"1351090996","value_type":"3","delay":"1m;10s/1-5,09:00-18:00","history":"90d","trends":"365d"}],
+		"events": [{"eventid":"1","clock":"1351090996","ns":"10","source":"0","object":"0","objectid":"13","value":"1","severity":"4","acknowledged":"1","suppressed":"0"}],
+		"triggers": [{"triggerid":"13","value":"1","status":"1","lastchange":"1351090996","priority":"4","state":"1"}],
+		"proxies": [{"proxyid":"3","status":"6","tls_connect":"1","interface":{"interfaceid":"1","useip":"1","port":"10051"}}]
+	}`,
+	"numbers": `{
+		"hosts": [{"hostid":"10084","status":1,"available":1,"inventory_mode":1,"tls_connect":2,"flags":4,"inventory":{"os":"Linux"}}],
+		"items": [{"itemid":"23296","lastclock":1351090996,"value_type":3,"delay":"60","history":7776000,"trends":"{$TRENDS}"}],
+		"events": [{"eventid":"1","clock":1351090996,"ns":10,"source":0,"object":0,"objectid":13,"value":1,"severity":4,"acknowledged":1,"suppressed":0}],
+		"triggers": [{"triggerid":"13","value":1,"status":1,"lastchange":1351090996,"priority":4,"state":1}],
+		"proxies": [{"proxyid":"3","status":6,"tls_connect":1,"interface":{"interfaceid":"1","useip":1,"port":10051}}]
+	}`,
+}
+
+type lenientObjects struct {
+	Hosts    []zabbix.Host    `json:"hosts"`
+	Items    []zabbix.Item    `json:"items"`
+	Events   []zabbix.Event   `json:"events"`
+	Triggers []zabbix.Trigger `json:"triggers"`
+	Proxies  []zabbix.Proxy   `json:"proxies"`
+}
+
+func TestDecodeLenient(t *testing.T) {
+	for name, payload := range lenientPayloads {
+		var v lenientObjects
+		if err := json.Unmarshal([]byte(payload), &v); err != nil {
+			t.Errorf("Error decoding %s: %v", name, err)
+			continue
+		}
+
+		host := v.Hosts[0]
+		if host.Status != zabbix.HostStatusUnmonitored || host.InventoryMode != 1 || host.TLSConnect != 2 || host.Source != zabbix.HostSourceDiscovery || host.Inventory["os"] != "Linux" {
+			t.Errorf("Unexpected host for %s: %+v", name, host)
+		}
+
+		item := v.Items[0]
+		if item.LastClock != 1351090996 || item.LastValueType != 3 || item.Delay.Interval.Duration != time.Minute || item.History.Duration != 90*24*time.Hour {
+			t.Errorf("Unexpected item for %s: %+v", name, item)
+		}
+
+		event := v.Events[0]
+		if !event.Timestamp().Equal(time.Unix(1351090996, 10)) || event.ObjectID != 13 || event.Severity != zabbix.TriggerSeverityHigh || !bool(event.Acknowledged) {
+			t.Errorf("Unexpected event for %s: %+v", name, event)
+		}
+
+		trigger := v.Triggers[0]
+		if trigger.LastChange != 1351090996 || trigger.Severity != zabbix.TriggerSeverityHigh {
+			t.Errorf("Unexpected trigger for %s: %+v", name, trigger)
+		}
+
+		proxy := v.Proxies[0]
+		if proxy.Interface.Port != 10051 || !bool(proxy.Interface.UseIP) || proxy.TLSConnect != 1 {
+			t.Errorf("Unexpected proxy for %s: %+v", name, proxy)
+		}
+	}
+}
+
+func TestDecodeEmptyArrays(t *testing.T) {
+	var v lenientObjects
+	payload := `{"hosts":[{"hostid":"10084","inventory":[]}],"proxies":[{"proxyid":"3","interface":[]}]}`
+	if err := json.Unmarshal([]byte(payload), &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Hosts[0].Inventory == nil || len(v.Hosts[0].Inventory) != 0 {
+		t.Errorf("Expected empty inventory but got %#v", v.Hosts[0].Inventory)
+	}
+	if v.Proxies[0].Interface != (zabbix.ProxyInterface{}) {
+		t.Errorf("Expected empty interface but got %+v", v.Proxies[0].Interface)
+	}
+}
+
+func FuzzDecodeObjects(f *testing.F) {
+	for _, payload := range lenientPayloads {
+		f.Add([]byte(payload))
+	}
+	f.Add([]byte(`{"hosts":[{"inventory":[]}],"proxies":[{"interface":[]}]}`))
+	f.Add([]byte(`{"events":[{"clock":"","ns":null,"severity":"9"}]}`))
+	f.Add([]byte(`{"events":[{"acknowledges":[{"clock":""},{"clock":0}]}]}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v lenientObjects
+		if err := json.Unmarshal(data, &v); err != nil {
+			return
+		}
+
+		// decoded objects must encode to values which decode the same way
+		b, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		var decoded lenientObjects
+		if err := json.Unmarshal(b, &decoded); err != nil {
+			t.Fatalf("Error decoding %s encoded from %s: %v", b, data, err)
+		}
+		normalize(reflect.ValueOf(&v).Elem())
+		normalize(reflect.ValueOf(&decoded).Elem())
+		if !reflect.DeepEqual(decoded, v) {
+			t.Fatalf("Decoding %s encoded from %s gave %+v instead of %+v", b, data, decoded, v)
+		}
+	})
+}
+
+// normalize replaces the empty slices and maps held by v with nil, as both
+// may be omitted when encoded.
+func normalize(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			normalize(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				normalize(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			normalize(v.Index(i))
+		}
+		if v.Len() == 0 {
+			v.Set(reflect.Zero(v.Type()))
+		}
+	case reflect.Map:
+		if v.Len() == 0 {
+			v.Set(reflect.Zero(v.Type()))
+		}
+	}
+}
@@ -0,0 +1,452 @@
+package zabbix
+
+import (
+	"github.com/NexonSU/go-zabbix/schedule"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+const (
+	// LLDFilterEvalTypeAndOr evaluates filter conditions with the same macro
+	// using OR and conditions with different macros using AND.
+	LLDFilterEvalTypeAndOr = 0
+
+	// LLDFilterEvalTypeAnd evaluates filter conditions using AND.
+	LLDFilterEvalTypeAnd = 1
+
+	// LLDFilterEvalTypeOr evaluates filter conditions using OR.
+	LLDFilterEvalTypeOr = 2
+
+	// LLDFilterEvalTypeCustom evaluates filter conditions using the custom
+	// LLDFilter.Formula.
+	LLDFilterEvalTypeCustom = 3
+)
+
+const (
+	// LLDFilterOperatorMatches matches values against a regular expression.
+	LLDFilterOperatorMatches = 8
+
+	// LLDFilterOperatorNotMatches matches values not matching a regular
+	// expression.
+	LLDFilterOperatorNotMatches = 9
+
+	// LLDFilterOperatorExists matches if the macro exists.
+	LLDFilterOperatorExists = 12
+
+	// LLDFilterOperatorNotExists matches if the macro does not exist.
+	LLDFilterOperatorNotExists = 13
+)
+
+const (
+	// LLDOverrideObjectItemPrototype applies an override operation to item
+	// prototypes.
+	LLDOverrideObjectItemPrototype = 0
+
+	// LLDOverrideObjectTriggerPrototype applies an override operation to
+	// trigger prototypes.
+	LLDOverrideObjectTriggerPrototype = 1
+
+	// LLDOverrideObjectGraphPrototype applies an override operation to graph
+	// prototypes.
+	LLDOverrideObjectGraphPrototype = 2
+
+	// LLDOverrideObjectHostPrototype applies an override operation to host
+	// prototypes.
+	LLDOverrideObjectHostPrototype = 3
+)
+
+// DiscoveryRule represents a Zabbix low-level Discovery Rule returned from the
+// Zabbix API.
+//
+// Zero values of optional fields are omitted when creating or updating a
+// Discovery Rule, so the server defaults apply, or the current values are
+// kept. Enumerated fields such as Status are pointers, as their zero value is
+// valid: nil fields are omitted.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/discoveryrule/object
+type DiscoveryRule struct {
+	// ItemID is the unique ID of the Discovery Rule.
+	ItemID string `json:"itemid,omitempty"`
+
+	// HostID is the ID of the Host or Template the Discovery Rule belongs to.
+	HostID string `json:"hostid,omitempty"`
+
+	// InterfaceID is the ID of the host interface used by the Discovery Rule.
+	InterfaceID string `json:"interfaceid,omitempty"`
+
+	// TemplateID is the ID of the parent template Discovery Rule.
+	TemplateID string `json:"templateid,omitempty"`
+
+	// Key is the item key of the Discovery Rule.
+	Key string `json:"key_,omitempty"`
+
+	// Name is the name of the Discovery Rule.
+	Name string `json:"name,omitempty"`
+
+	// Type is the item type of the Discovery Rule.
+	Type *types.ZBXInt `json:"type,omitempty"`
+
+	// Delay is the update interval of the Discovery Rule.
+	Delay *schedule.Delay `json:"delay,omitempty"`
+
+	// Description is the description of the Discovery Rule.
+	Description string `json:"description,omitempty"`
+
+	// Status is 0 if the Discovery Rule is enabled and 1 if disabled.
+	Status *types.ZBXInt `json:"status,omitempty"`
+
+	// State is 1 if the Discovery Rule is not supported.
+	State int `json:"state,string,omitempty"`
+
+	// Error is the error text if there are problems updating the Discovery
+	// Rule.
+	Error string `json:"error,omitempty"`
+
+	// Lifetime is the period after which resources that are no longer
+	// discovered are deleted.
+	Lifetime string `json:"lifetime,omitempty"`
+
+	// LifetimeType is 0 to delete lost resources after Lifetime, 1 to never
+	// delete them and 2 to delete them immediately.
+	LifetimeType int `json:"lifetime_type,string,omitempty"`
+
+	// EnabledLifetime is the period after which resources that are no longer
+	// discovered are disabled.
+	EnabledLifetime string `json:"enabled_lifetime,omitempty"`
+
+	// EnabledLifetimeType is 0 to disable lost resources after
+	// EnabledLifetime, 1 to never disable them and 2 to disable them
+	// immediately.
+	EnabledLifetimeType int `json:"enabled_lifetime_type,string,omitempty"`
+
+	// MasterItemID is the ID of the master Item of a dependent Discovery
+	// Rule.
+	MasterItemID string `json:"master_itemid,omitempty"`
+
+	// Params are the additional parameters of the item type, such as the
+	// script of a script Discovery Rule.
+	Params string `json:"params,omitempty"`
+
+	// Timeout is the timeout of the item check.
+	Timeout string `json:"timeout,omitempty"`
+
+	// TrapperHosts are the allowed hosts of a trapper Discovery Rule.
+	TrapperHosts string `json:"trapper_hosts,omitempty"`
+
+	// SNMPOID is the SNMP OID of an SNMP Discovery Rule.
+	SNMPOID string `json:"snmp_oid,omitempty"`
+
+	// URL is the URL of an HTTP agent Discovery Rule.
+	URL string `json:"url,omitempty"`
+
+	// Username is the user name used by the item check.
+	Username string `json:"username,omitempty"`
+
+	// Password is the password used by the item check.
+	Password string `json:"password,omitempty"`
+
+	// UUID is the universal unique identifier of a template Discovery Rule.
+	UUID string `json:"uuid,omitempty"`
+
+	// Filter is the filter applied to discovered entities.
+	Filter *LLDFilter `json:"filter,omitempty"`
+
+	// LLDMacroPaths map LLD macros to JSONPath expressions in the discovered
+	// data.
+	LLDMacroPaths []LLDMacroPath `json:"lld_macro_paths,omitempty"`
+
+	// Preprocessing are the preprocessing steps of the Discovery Rule.
+	Preprocessing []ItemPreprocessing `json:"preprocessing,omitempty"`
+
+	// Overrides change the prototypes for entities matching their filters.
+	Overrides []LLDOverride `json:"overrides,omitempty"`
+
+	// Hosts is only populated if DiscoveryRuleGetParams.SelectHosts is given
+	// in the query parameters that returned this Discovery Rule.
+	Hosts []Host `json:"hosts,omitempty"`
+
+	// ItemPrototypes is only populated if DiscoveryRuleGetParams.SelectItems
+	// is given in the query parameters that returned this Discovery Rule.
+	ItemPrototypes []ItemPrototype `json:"items,omitempty"`
+
+	// TriggerPrototypes is only populated if
+	// DiscoveryRuleGetParams.SelectTriggers is given in the query parameters
+	// that returned this Discovery Rule.
+	TriggerPrototypes []TriggerPrototype `json:"triggers,omitempty"`
+
+	// GraphPrototypes is only populated if DiscoveryRuleGetParams.SelectGraphs
+	// is given in the query parameters that returned this Discovery Rule.
+	GraphPrototypes []GraphPrototype `json:"graphs,omitempty"`
+
+	// HostPrototypes is only populated if
+	// DiscoveryRuleGetParams.SelectHostPrototypes is given in the query
+	// parameters that returned this Discovery Rule.
+	HostPrototypes []HostPrototype `json:"hostPrototypes,omitempty"`
+}
+
+// LLDFilter is the filter of a Discovery Rule or an LLDOverride.
+type LLDFilter struct {
+	// EvalType is the evaluation method of the filter conditions.
+	//
+	// EvalType must be one of the LLDFilterEvalType constants.
+	EvalType int `json:"evaltype,string"`
+
+	// Formula is the custom expression used with LLDFilterEvalTypeCustom,
+	// referring to conditions by their FormulaID.
+	Formula string `json:"formula,omitempty"`
+
+	// EvalFormula is the generated expression used to evaluate the filter.
+	EvalFormula string `json:"eval_formula,omitempty"`
+
+	// Conditions are the filter conditions.
+	Conditions []LLDFilterCondition `json:"conditions"`
+}
+
+// LLDFilterCondition is a condition of an LLDFilter.
+type LLDFilterCondition struct {
+	// Macro is the LLD macro to check.
+	Macro string `json:"macro"`
+
+	// Value is the regular expression to match the macro value against.
+	Value string `json:"value"`
+
+	// Operator must be one of the LLDFilterOperator constants.
+	Operator int `json:"operator,string"`
+
+	// FormulaID is the ID of the condition in a custom filter Formula.
+	FormulaID string `json:"formulaid,omitempty"`
+}
+
+// LLDMacroPath maps an LLD macro to a JSONPath expression.
+type LLDMacroPath struct {
+	// LLDMacro is the LLD macro such as {#FSNAME}.
+	LLDMacro string `json:"lld_macro"`
+
+	// Path is the JSONPath selecting the macro value in a discovered entity.
+	Path string `json:"path"`
+}
+
+// ItemPreprocessing is a preprocessing step of an Item, Item Prototype or
+// Discovery Rule.
+type ItemPreprocessing struct {
+	// Type is the preprocessing step type.
+	Type int `json:"type,string"`
+
+	// Params are the step parameters, separated by new lines.
+	Params string `json:"params"`
+
+	// ErrorHandler is the action taken if the step fails.
+	ErrorHandler int `json:"error_handler,string"`
+
+	// ErrorHandlerParams are the parameters of ErrorHandler.
+	ErrorHandlerParams string `json:"error_handler_params"`
+}
+
+// LLDOverride changes the prototypes of discovered entities matching its
+// filter.
+type LLDOverride struct {
+	// Name is the unique name of the override.
+	Name string `json:"name"`
+
+	// Step is the order in which overrides are processed.
+	Step int `json:"step,string"`
+
+	// Stop is 1 to stop processing later overrides if the filter matches.
+	Stop int `json:"stop,string,omitempty"`
+
+	// Filter selects the discovered entities the override applies to.
+	Filter *LLDFilter `json:"filter,omitempty"`
+
+	// Operations are the changes applied to matching prototypes.
+	Operations []LLDOverrideOperation `json:"operations,omitempty"`
+}
+
+// LLDOverrideOperation is an operation of an LLDOverride.
+type LLDOverrideOperation struct {
+	// OperationObject is the prototype type the operation applies to and
+	// must be one of the LLDOverrideObject constants.
+	OperationObject int `json:"operationobject,string"`
+
+	// Operator is the operator used to match prototype names against Value.
+	Operator int `json:"operator,string"`
+
+	// Value is the pattern matched against prototype names.
+	Value string `json:"value,omitempty"`
+
+	// OpStatus overrides the status of the discovered objects.
+	OpStatus *LLDOverrideStatus `json:"opstatus,omitempty"`
+
+	// OpDiscover overrides whether the discovered objects are created.
+	OpDiscover *LLDOverrideDiscover `json:"opdiscover,omitempty"`
+
+	// OpPeriod overrides the update interval of discovered items.
+	OpPeriod *LLDOverridePeriod `json:"opperiod,omitempty"`
+
+	// OpHistory overrides the history storage period of discovered items.
+	OpHistory *LLDOverrideHistory `json:"ophistory,omitempty"`
+
+	// OpTrends overrides the trends storage period of discovered items.
+	OpTrends *LLDOverrideTrends `json:"optrends,omitempty"`
+
+	// OpSeverity overrides the severity of discovered triggers.
+	OpSeverity *LLDOverrideSeverity `json:"opseverity,omitempty"`
+
+	// OpTag adds tags to the discovered objects.
+	OpTag []TriggerTag `json:"optag,omitempty"`
+
+	// OpTemplate links templates to discovered hosts.
+	OpTemplate []LLDOverrideTemplate `json:"optemplate,omitempty"`
+
+	// OpInventory overrides the inventory mode of discovered hosts.
+	OpInventory *LLDOverrideInventory `json:"opinventory,omitempty"`
+}
+
+// LLDOverrideStatus is the status set by an LLDOverrideOperation.
+type LLDOverrideStatus struct {
+	Status int `json:"status,string"`
+}
+
+// LLDOverrideDiscover is the discover flag set by an LLDOverrideOperation.
+type LLDOverrideDiscover struct {
+	Discover int `json:"discover,string"`
+}
+
+// LLDOverridePeriod is the update interval set by an LLDOverrideOperation.
+type LLDOverridePeriod struct {
+	Delay schedule.Delay `json:"delay"`
+}
+
+// LLDOverrideHistory is the history storage period set by an
+// LLDOverrideOperation.
+type LLDOverrideHistory struct {
+	History types.ZBXDuration `json:"history"`
+}
+
+// LLDOverrideTrends is the trends storage period set by an
+// LLDOverrideOperation.
+type LLDOverrideTrends struct {
+	Trends types.ZBXDuration `json:"trends"`
+}
+
+// LLDOverrideSeverity is the trigger severity set by an LLDOverrideOperation.
+type LLDOverrideSeverity struct {
+	Severity Severity `json:"severity,string"`
+}
+
+// LLDOverrideTemplate is a template linked by an LLDOverrideOperation.
+type LLDOverrideTemplate struct {
+	TemplateID string `json:"templateid"`
+}
+
+// LLDOverrideInventory is the inventory mode set by an LLDOverrideOperation.
+type LLDOverrideInventory struct {
+	InventoryMode int `json:"inventory_mode,string"`
+}
+
+// DiscoveryRuleGetParams is query params for discoveryrule.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/discoveryrule/get
+type DiscoveryRuleGetParams struct {
+	GetParameters
+
+	// ItemIDs filters search results to Discovery Rules with the given IDs.
+	ItemIDs []string `json:"itemids,omitempty"`
+
+	// GroupIDs filters search results to Discovery Rules of hosts in the
+	// given groups.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// HostIDs filters search results to Discovery Rules of the given hosts.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// TemplateIDs filters search results to Discovery Rules of the given
+	// templates.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// InterfaceIDs filters search results to Discovery Rules using the given
+	// host interfaces.
+	InterfaceIDs []string `json:"interfaceids,omitempty"`
+
+	// Inherited filters search results to Discovery Rules inherited from a
+	// template.
+	Inherited bool `json:"inherited,omitempty"`
+
+	// Templated filters search results to Discovery Rules of templates.
+	Templated bool `json:"templated,omitempty"`
+
+	// Monitored filters search results to enabled Discovery Rules of
+	// monitored hosts.
+	Monitored bool `json:"monitored,omitempty"`
+
+	// SelectFilter returns the filter of each Discovery Rule.
+	SelectFilter SelectQuery `json:"selectFilter,omitempty"`
+
+	// SelectLLDMacroPaths returns the LLD macro paths of each Discovery Rule.
+	SelectLLDMacroPaths SelectQuery `json:"selectLLDMacroPaths,omitempty"`
+
+	// SelectPreprocessing returns the preprocessing steps of each Discovery
+	// Rule.
+	SelectPreprocessing SelectQuery `json:"selectPreprocessing,omitempty"`
+
+	// SelectOverrides returns the overrides of each Discovery Rule.
+	SelectOverrides SelectQuery `json:"selectOverrides,omitempty"`
+
+	// SelectHosts returns the host the Discovery Rule belongs to.
+	SelectHosts SelectQuery `json:"selectHosts,omitempty"`
+
+	// SelectItems returns the item prototypes of each Discovery Rule.
+	SelectItems SelectQuery `json:"selectItems,omitempty"`
+
+	// SelectTriggers returns the trigger prototypes of each Discovery Rule.
+	SelectTriggers SelectQuery `json:"selectTriggers,omitempty"`
+
+	// SelectGraphs returns the graph prototypes of each Discovery Rule.
+	SelectGraphs SelectQuery `json:"selectGraphs,omitempty"`
+
+	// SelectHostPrototypes returns the host prototypes of each Discovery
+	// Rule.
+	SelectHostPrototypes SelectQuery `json:"selectHostPrototypes,omitempty"`
+}
+
+// GetDiscoveryRules queries the Zabbix API for Discovery Rules matching the
+// given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetDiscoveryRules(params DiscoveryRuleGetParams) ([]DiscoveryRule, error) {
+	rules := make([]DiscoveryRule, 0)
+	err := c.Get("discoveryrule.get", params, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return rules, nil
+}
+
+// CreateDiscoveryRules creates Discovery Rules.
+// Returns a list of IDs of the created Discovery Rules.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/discoveryrule/create
+func (c *Session) CreateDiscoveryRules(rules ...DiscoveryRule) ([]string, error) {
+	return c.GetIDs("discoveryrule.create", rules, "itemids")
+}
+
+// UpdateDiscoveryRules updates Discovery Rules.
+// Returns a list of IDs of the updated Discovery Rules.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/discoveryrule/update
+func (c *Session) UpdateDiscoveryRules(rules ...DiscoveryRule) ([]string, error) {
+	return c.GetIDs("discoveryrule.update", rules, "itemids")
+}
+
+// DeleteDiscoveryRules deletes Discovery Rules and all the prototypes and
+// discovered objects they own.
+// Returns a list of IDs of the deleted Discovery Rules.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/discoveryrule/delete
+func (c *Session) DeleteDiscoveryRules(itemIDs ...string) ([]string, error) {
+	return c.GetIDs("discoveryrule.delete", itemIDs, "ruleids")
+}
@@ -0,0 +1,192 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/schedule"
+	"github.com/NexonSU/go-zabbix/test"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+func TestGetDiscoveryRules(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("discoveryrule.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{
+			"itemid":"42269","hostid":"10197","key_":"vfs.fs.discovery","name":"Mounted filesystem discovery",
+			"type":"0","status":"0","delay":"1h","lifetime":"7d",
+			"filter":{"evaltype":"1","formula":"","eval_formula":"A and B","conditions":[
+				{"macro":"{#FSTYPE}","value":"ext4","operator":"8","formulaid":"A"},
+				{"macro":"{#FSNAME}","value":"^/boot","operator":"9","formulaid":"B"}
+			]},
+			"lld_macro_paths":[{"lld_macro":"{#FSNAME}","path":"$.fsname"}],
+			"overrides":[{"name":"Skip tmp","step":"1","stop":"1",
+				"filter":{"evaltype":"0","formula":"","conditions":[{"macro":"{#FSNAME}","value":"^/tmp","operator":"8","formulaid":"A"}]},
+				"operations":[{"operationobject":"0","operator":"0","value":"","opdiscover":{"discover":"1"}}]
+			}]
+		}]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	rules, err := session.GetDiscoveryRules(zabbix.DiscoveryRuleGetParams{
+		SelectFilter:    zabbix.SelectExtendedOutput,
+		SelectOverrides: zabbix.SelectExtendedOutput,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := rules[0]
+	if rule.Delay == nil || rule.Delay.Interval.Duration != time.Hour {
+		t.Errorf("Unexpected delay: %v", rule.Delay)
+	}
+
+	if rule.Filter == nil || rule.Filter.EvalType != zabbix.LLDFilterEvalTypeAnd || len(rule.Filter.Conditions) != 2 {
+		t.Fatalf("Unexpected filter: %+v", rule.Filter)
+	}
+
+	if c := rule.Filter.Conditions[1]; c.Operator != zabbix.LLDFilterOperatorNotMatches || c.Macro != "{#FSNAME}" {
+		t.Errorf("Unexpected filter condition: %+v", c)
+	}
+
+	if !reflect.DeepEqual(rule.LLDMacroPaths, []zabbix.LLDMacroPath{{LLDMacro: "{#FSNAME}", Path: "$.fsname"}}) {
+		t.Errorf("Unexpected LLD macro paths: %+v", rule.LLDMacroPaths)
+	}
+
+	if len(rule.Overrides) != 1 || rule.Overrides[0].Stop != 1 {
+		t.Fatalf("Unexpected overrides: %+v", rule.Overrides)
+	}
+
+	op := rule.Overrides[0].Operations[0]
+	if op.OperationObject != zabbix.LLDOverrideObjectItemPrototype || op.OpDiscover == nil || op.OpDiscover.Discover != 1 {
+		t.Errorf("Unexpected override operation: %+v", op)
+	}
+}
+
+func TestDiscoveryRuleMethods(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("discoveryrule.create", func(params json.RawMessage) (interface{}, error) {
+		return map[string][]string{"itemids": {"42270"}}, nil
+	})
+	server.Handle("discoveryrule.update", func(params json.RawMessage) (interface{}, error) {
+		return map[string][]string{"itemids": {"42270"}}, nil
+	})
+	server.Handle("discoveryrule.delete", func(params json.RawMessage) (interface{}, error) {
+		return map[string][]string{"ruleids": {"42270"}}, nil
+	})
+	server.Handle("itemprototype.delete", func(params json.RawMessage) (interface{}, error) {
+		return map[string][]string{"prototypeids": {"42271"}}, nil
+	})
+	server.Handle("hostprototype.create", func(params json.RawMessage) (interface{}, error) {
+		// some versions return IDs as numbers
+		return json.RawMessage(`{"hostids":[10204]}`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	itemType := types.ZBXInt(7)
+	ids, err := session.CreateDiscoveryRules(zabbix.DiscoveryRule{
+		HostID: "10197",
+		Name:   "Network interfaces",
+		Key:    "net.if.discovery",
+		Delay:  &schedule.Delay{Interval: types.ZBXDuration{Duration: time.Hour}},
+		Type:   &itemType,
+	})
+	if err != nil || !reflect.DeepEqual(ids, []string{"42270"}) {
+		t.Fatalf("Unexpected create result %v: %v", ids, err)
+	}
+
+	var create []map[string]interface{}
+	if err := json.Unmarshal(server.Calls()[0].Params.(json.RawMessage), &create); err != nil {
+		t.Fatal(err)
+	}
+	if create[0]["delay"] != "1h" {
+		t.Errorf("Expected delay 1h but got %v", create[0]["delay"])
+	}
+
+	// unset fields are left out to keep their current values
+	if _, err := session.UpdateDiscoveryRules(zabbix.DiscoveryRule{ItemID: "42270", Name: "Interfaces"}); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	var update []map[string]interface{}
+	if err := json.Unmarshal(calls[len(calls)-1].Params.(json.RawMessage), &update); err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]interface{}{"itemid": "42270", "name": "Interfaces"}
+	if !reflect.DeepEqual(update[0], expect) {
+		t.Errorf("Expected update params %v but got %v", expect, update[0])
+	}
+
+	if ids, err := session.DeleteDiscoveryRules("42270"); err != nil || ids[0] != "42270" {
+		t.Errorf("Unexpected delete result %v: %v", ids, err)
+	}
+
+	if ids, err := session.DeleteItemPrototypes("42271"); err != nil || ids[0] != "42271" {
+		t.Errorf("Unexpected item prototype delete result %v: %v", ids, err)
+	}
+
+	ids, err = session.CreateHostPrototypes(zabbix.HostPrototype{
+		RuleID:     "42270",
+		Hostname:   "{#VM.NAME}",
+		GroupLinks: []zabbix.HostPrototypeGroupLink{{GroupID: "2"}},
+	})
+	if err != nil || !reflect.DeepEqual(ids, []string{"10204"}) {
+		t.Errorf("Unexpected host prototype create result %v: %v", ids, err)
+	}
+}
+
+func TestPrototypePartialUpdates(t *testing.T) {
+	server := test.NewServer(t)
+	for method, key := range map[string]string{
+		"itemprototype.update":    "itemids",
+		"triggerprototype.update": "triggerids",
+		"hostprototype.update":    "hostids",
+		"graphprototype.update":   "graphids",
+	} {
+		key := key
+		server.Handle(method, func(params json.RawMessage) (interface{}, error) {
+			return map[string][]string{key: {"1"}}, nil
+		})
+	}
+	session := server.Session(t, "7.0.0")
+
+	// value types, priorities and statuses must not be reset to zero
+	if _, err := session.UpdateItemPrototypes(zabbix.ItemPrototype{ItemID: "1", Name: "Free space on {#FSNAME}"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.UpdateTriggerPrototypes(zabbix.TriggerPrototype{TriggerID: "1", Description: "Disk full"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.UpdateHostPrototypes(zabbix.HostPrototype{HostID: "1", DisplayName: "VM {#VM.NAME}"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.UpdateGraphPrototypes(zabbix.GraphPrototype{GraphID: "1", Name: "Disk usage"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`[{"itemid":"1","name":"Free space on {#FSNAME}"}]`,
+		`[{"triggerid":"1","description":"Disk full"}]`,
+		`[{"hostid":"1","name":"VM {#VM.NAME}"}]`,
+		`[{"graphid":"1","name":"Disk usage"}]`,
+	}
+	for i, call := range server.Calls() {
+		if params := string(call.Params.(json.RawMessage)); params != expected[i] {
+			t.Errorf("Expected %s params %s but got %s", call.Method, expected[i], params)
+		}
+	}
+
+	disabled := zabbix.HostStatusUnmonitored
+	if _, err := session.UpdateHostPrototypes(zabbix.HostPrototype{HostID: "1", Status: &disabled}); err != nil {
+		t.Fatal(err)
+	}
+	calls := server.Calls()
+	if params := string(calls[len(calls)-1].Params.(json.RawMessage)); params != `[{"hostid":"1","status":"1"}]` {
+		t.Errorf("Unexpected host prototype status update %s", params)
+	}
+}
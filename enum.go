@@ -0,0 +1,74 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// enum is the names of the values of an enumerated type, used by its String,
+// MarshalText and UnmarshalText methods.
+//
+// Enumerated types are encoded as numeric strings in JSON, as the API
+// expects, and as names in text, such as in configuration files.
+type enum struct {
+	typ   string
+	names map[int]string
+}
+
+func (e enum) format(v int) string {
+	if name, ok := e.names[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("%s(%d)", e.typ, v)
+}
+
+func (e enum) marshalText(v int) ([]byte, error) {
+	name, ok := e.names[v]
+	if !ok {
+		return nil, fmt.Errorf("Invalid %s %d", e.typ, v)
+	}
+	return []byte(name), nil
+}
+
+// unmarshalText parses a name, case-insensitively, or a number.
+func (e enum) unmarshalText(text []byte, v *int) error {
+	s := strings.ToLower(strings.TrimSpace(string(text)))
+	for value, name := range e.names {
+		if name == s {
+			*v = value
+			return nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("Invalid %s %q", e.typ, text)
+	}
+	if _, ok := e.names[n]; !ok {
+		return fmt.Errorf("Invalid %s %d", e.typ, n)
+	}
+	*v = n
+	return nil
+}
+
+func (e enum) marshalJSON(v int) ([]byte, error) {
+	return json.Marshal(strconv.Itoa(v))
+}
+
+// unmarshalJSON decodes a number or a numeric string. Unknown values are
+// accepted, as newer Zabbix versions may add values.
+func (e enum) unmarshalJSON(data []byte, v *int) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	n, err := strconv.Atoi(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return fmt.Errorf("Invalid %s %s", e.typ, data)
+	}
+	*v = n
+	return nil
+}
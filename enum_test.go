@@ -0,0 +1,299 @@
+package zabbix_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+)
+
+type enumValue interface {
+	fmt.Stringer
+	encoding.TextMarshaler
+	json.Marshaler
+}
+
+func TestEnums(t *testing.T) {
+	tests := []struct {
+		value enumValue
+		name  string
+		n     int
+	}{
+		{zabbix.TriggerAlarmStateOK, "ok", 0},
+		{zabbix.TriggerAlarmStateProblem, "problem", 1},
+		{zabbix.TriggerStateNormal, "normal", 0},
+		{zabbix.TriggerStateUnknown, "unknown", 1},
+		{zabbix.TriggerSeverityNotClassified, "not_classified", 0},
+		{zabbix.TriggerSeverityInformation, "information", 1},
+		{zabbix.TriggerSeverityWarning, "warning", 2},
+		{zabbix.TriggerSeverityAverage, "average", 3},
+		{zabbix.TriggerSeverityHigh, "high", 4},
+		{zabbix.TriggerSeverityDisaster, "disaster", 5},
+		{zabbix.EventSourceTrigger, "trigger", 0},
+		{zabbix.EventSourceDiscoveryRule, "discovery", 1},
+		{zabbix.EventSourceAutoRegistration, "autoregistration", 2},
+		{zabbix.EventSourceInternal, "internal", 3},
+		{zabbix.EventSourceService, "service", 4},
+		{zabbix.EventObjectTypeTrigger, "trigger", 0},
+		{zabbix.EventObjectTypeDiscoveredHost, "discovered_host", 1},
+		{zabbix.EventObjectTypeDiscoveredService, "discovered_service", 2},
+		{zabbix.EventObjectTypeAutoRegisteredHost, "autoregistered_host", 3},
+		{zabbix.EventObjectTypeItem, "item", 4},
+		{zabbix.EventObjectTypeLLDRule, "lld_rule", 5},
+		{zabbix.EventObjectTypeService, "service", 6},
+		{zabbix.HostStatusMonitored, "monitored", 0},
+		{zabbix.HostStatusUnmonitored, "unmonitored", 1},
+		{zabbix.HostAvailabilityUnknown, "unknown", 0},
+		{zabbix.HostAvailabilityAvailable, "available", 1},
+		{zabbix.HostAvailabilityUnavailable, "unavailable", 2},
+		{zabbix.HostInterfaceTypeAgent, "agent", 1},
+		{zabbix.HostInterfaceTypeSNMP, "snmp", 2},
+		{zabbix.HostInterfaceTypeIPMI, "ipmi", 3},
+		{zabbix.HostInterfaceTypeJMX, "jmx", 4},
+		{zabbix.ScriptTypeScript, "script", 0},
+		{zabbix.ScriptTypeIPMI, "ipmi", 1},
+		{zabbix.ScriptTypeSSH, "ssh", 2},
+		{zabbix.ScriptTypeTelnet, "telnet", 3},
+		{zabbix.ScriptTypeWebhook, "webhook", 5},
+		{zabbix.ScriptTypeURL, "url", 6},
+		{zabbix.ScriptScopeActionOperation, "action_operation", 1},
+		{zabbix.ScriptScopeManualHostOperation, "manual_host_operation", 2},
+		{zabbix.ScriptScopeManualEventAction, "manual_event_action", 4},
+		{zabbix.ScriptExecuteOnAgent, "agent", 0},
+		{zabbix.ScriptExecuteOnServer, "server", 1},
+		{zabbix.ScriptExecuteOnProxy, "proxy", 2},
+		{zabbix.ProxyStatusActive, "active", 5},
+		{zabbix.ProxyStatusPassive, "passive", 6},
+		{zabbix.MacroTypeText, "text", 0},
+		{zabbix.MacroTypeSecret, "secret", 1},
+		{zabbix.MacroTypeVault, "vault", 2},
+		{zabbix.HistoryValueTypeFloat, "float", 0},
+		{zabbix.HistoryValueTypeCharacter, "character", 1},
+		{zabbix.HistoryValueTypeLog, "log", 2},
+		{zabbix.HistoryValueTypeUnsigned, "unsigned", 3},
+		{zabbix.HistoryValueTypeText, "text", 4},
+		{zabbix.HistoryValueTypeBinary, "binary", 5},
+		{zabbix.MaintenanceWithDataCollection, "with_data_collection", 0},
+		{zabbix.MaintenanceWithoutDataCollection, "without_data_collection", 1},
+		{zabbix.TagsEvaltypeAndOr, "and_or", 0},
+		{zabbix.TagsEvaltypeOr, "or", 2},
+		{zabbix.MaintenanceTimeperiodOnce, "once", 0},
+		{zabbix.MaintenanceTimeperiodDaily, "daily", 2},
+		{zabbix.MaintenanceTimeperiodWeekly, "weekly", 3},
+		{zabbix.MaintenanceTimeperiodMonthly, "monthly", 4},
+		{zabbix.ActionStatusEnabled, "enabled", 0},
+		{zabbix.ActionStatusDisabled, "disabled", 1},
+		{zabbix.ActionEvalTypeAndOr, "and_or", 0},
+		{zabbix.ActionEvalTypeAnd, "and", 1},
+		{zabbix.ActionEvalTypeOr, "or", 2},
+		{zabbix.ActionEvalTypeCustom, "custom", 3},
+		{zabbix.ActionConditionTypeHostGroup, "host_group", 0},
+		{zabbix.ActionConditionTypeHost, "host", 1},
+		{zabbix.ActionConditionTypeTrigger, "trigger", 2},
+		{zabbix.ActionConditionTypeEventName, "event_name", 3},
+		{zabbix.ActionConditionTypeTriggerSeverity, "trigger_severity", 4},
+		{zabbix.ActionConditionTypeTimePeriod, "time_period", 6},
+		{zabbix.ActionConditionTypeHostIP, "host_ip", 7},
+		{zabbix.ActionConditionTypeDiscoveredServiceType, "discovered_service_type", 8},
+		{zabbix.ActionConditionTypeDiscoveredServicePort, "discovered_service_port", 9},
+		{zabbix.ActionConditionTypeDiscoveryStatus, "discovery_status", 10},
+		{zabbix.ActionConditionTypeUptimeDowntime, "uptime_downtime", 11},
+		{zabbix.ActionConditionTypeReceivedValue, "received_value", 12},
+		{zabbix.ActionConditionTypeHostTemplate, "host_template", 13},
+		{zabbix.ActionConditionTypeEventAcknowledged, "event_acknowledged", 14},
+		{zabbix.ActionConditionTypeProblemSuppressed, "problem_suppressed", 16},
+		{zabbix.ActionConditionTypeDiscoveryRule, "discovery_rule", 18},
+		{zabbix.ActionConditionTypeDiscoveryCheck, "discovery_check", 19},
+		{zabbix.ActionConditionTypeProxy, "proxy", 20},
+		{zabbix.ActionConditionTypeDiscoveryObject, "discovery_object", 21},
+		{zabbix.ActionConditionTypeHostName, "host_name", 22},
+		{zabbix.ActionConditionTypeEventType, "event_type", 23},
+		{zabbix.ActionConditionTypeHostMetadata, "host_metadata", 24},
+		{zabbix.ActionConditionTypeEventTag, "event_tag", 25},
+		{zabbix.ActionConditionTypeEventTagValue, "event_tag_value", 26},
+		{zabbix.ActionConditionTypeService, "service", 27},
+		{zabbix.ActionConditionTypeServiceName, "service_name", 28},
+		{zabbix.ActionConditionOperatorEqual, "equal", 0},
+		{zabbix.ActionConditionOperatorNotEqual, "not_equal", 1},
+		{zabbix.ActionConditionOperatorContains, "contains", 2},
+		{zabbix.ActionConditionOperatorNotContains, "not_contains", 3},
+		{zabbix.ActionConditionOperatorIn, "in", 4},
+		{zabbix.ActionConditionOperatorGreaterOrEqual, "greater_or_equal", 5},
+		{zabbix.ActionConditionOperatorLessOrEqual, "less_or_equal", 6},
+		{zabbix.ActionConditionOperatorNotIn, "not_in", 7},
+		{zabbix.ActionConditionOperatorMatches, "matches", 8},
+		{zabbix.ActionConditionOperatorNotMatches, "not_matches", 9},
+		{zabbix.ActionConditionOperatorYes, "yes", 10},
+		{zabbix.ActionConditionOperatorNo, "no", 11},
+		{zabbix.ActionOperationTypeSendMessage, "send_message", 0},
+		{zabbix.ActionOperationTypeGlobalScript, "global_script", 1},
+		{zabbix.ActionOperationTypeAddHost, "add_host", 2},
+		{zabbix.ActionOperationTypeRemoveHost, "remove_host", 3},
+		{zabbix.ActionOperationTypeAddToHostGroup, "add_to_host_group", 4},
+		{zabbix.ActionOperationTypeRemoveFromHostGroup, "remove_from_host_group", 5},
+		{zabbix.ActionOperationTypeLinkTemplate, "link_template", 6},
+		{zabbix.ActionOperationTypeUnlinkTemplate, "unlink_template", 7},
+		{zabbix.ActionOperationTypeEnableHost, "enable_host", 8},
+		{zabbix.ActionOperationTypeDisableHost, "disable_host", 9},
+		{zabbix.ActionOperationTypeSetInventoryMode, "set_inventory_mode", 10},
+		{zabbix.ActionOperationTypeNotifyAllInvolved, "notify_all_involved", 11},
+		{zabbix.ActionOperationTypeNotifyAllInvolvedUpdate, "notify_all_involved_update", 12},
+		{zabbix.ActionOperationTypeAddHostTags, "add_host_tags", 13},
+		{zabbix.ActionOperationTypeRemoveHostTags, "remove_host_tags", 14},
+	}
+
+	for _, test := range tests {
+		typ := reflect.TypeOf(test.value)
+
+		if s := test.value.String(); s != test.name {
+			t.Errorf("Expected %s %d to be named %q but got %q", typ, test.n, test.name, s)
+		}
+
+		if text, err := test.value.MarshalText(); err != nil {
+			t.Errorf("Error marshalling %s %d: %v", typ, test.n, err)
+		} else if string(text) != test.name {
+			t.Errorf("Expected %s %d to marshal to %q but got %q", typ, test.n, test.name, text)
+		}
+
+		if b, err := test.value.MarshalJSON(); err != nil {
+			t.Errorf("Error marshalling %s %d to JSON: %v", typ, test.n, err)
+		} else if expected := strconv.Quote(strconv.Itoa(test.n)); string(b) != expected {
+			t.Errorf("Expected %s %d to marshal to JSON %s but got %s", typ, test.n, expected, b)
+		}
+
+		for _, text := range []string{test.name, strconv.Itoa(test.n)} {
+			v := reflect.New(typ)
+			if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+				t.Errorf("Error unmarshalling %s %q: %v", typ, text, err)
+			} else if v.Elem().Interface() != test.value {
+				t.Errorf("Expected %s %q to unmarshal to %v but got %v", typ, text, test.value, v.Elem())
+			}
+		}
+
+		for _, data := range []string{strconv.Itoa(test.n), strconv.Quote(strconv.Itoa(test.n))} {
+			v := reflect.New(typ)
+			if err := json.Unmarshal([]byte(data), v.Interface()); err != nil {
+				t.Errorf("Error unmarshalling %s from JSON %s: %v", typ, data, err)
+			} else if v.Elem().Interface() != test.value {
+				t.Errorf("Expected %s JSON %s to unmarshal to %v but got %v", typ, data, test.value, v.Elem())
+			}
+		}
+	}
+}
+
+func TestEnumUnknownValues(t *testing.T) {
+	severity := zabbix.Severity(9)
+	if s := severity.String(); s != "Severity(9)" {
+		t.Errorf("Expected unknown severity to be formatted as Severity(9) but got %q", s)
+	}
+	if _, err := severity.MarshalText(); err == nil {
+		t.Error("Expected an error marshalling an unknown severity to text")
+	}
+	for _, text := range []string{"9", "critical", ""} {
+		if err := severity.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("Expected an error unmarshalling severity %q", text)
+		}
+	}
+
+	// newer servers may return values unknown to this package
+	var status zabbix.HostStatus
+	if err := json.Unmarshal([]byte(`"3"`), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status != 3 {
+		t.Errorf("Expected unknown host status 3 but got %d", status)
+	}
+
+	if err := json.Unmarshal([]byte(`"monitored"`), &status); err == nil {
+		t.Error("Expected an error unmarshalling a host status name from JSON")
+	}
+}
+
+func TestEnumTextCaseInsensitive(t *testing.T) {
+	var source zabbix.EventSource
+	if err := source.UnmarshalText([]byte(" AutoRegistration ")); err != nil {
+		t.Fatal(err)
+	}
+	if source != zabbix.EventSourceAutoRegistration {
+		t.Errorf("Expected %v but got %v", zabbix.EventSourceAutoRegistration, source)
+	}
+}
+
+func TestEnumFields(t *testing.T) {
+	var trigger zabbix.Trigger
+	if err := json.Unmarshal([]byte(`{"triggerid":"1","value":"1","priority":"4","state":"0"}`), &trigger); err != nil {
+		t.Fatal(err)
+	}
+	if trigger.AlarmState != zabbix.TriggerAlarmStateProblem {
+		t.Errorf("Expected alarm state %v but got %v", zabbix.TriggerAlarmStateProblem, trigger.AlarmState)
+	}
+	if trigger.Severity != zabbix.TriggerSeverityHigh {
+		t.Errorf("Expected severity %v but got %v", zabbix.TriggerSeverityHigh, trigger.Severity)
+	}
+
+	b, err := json.Marshal(zabbix.Host{Status: zabbix.HostStatusUnmonitored})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["status"] != "1" {
+		t.Errorf("Expected host status to be encoded as \"1\" but got %v", fields["status"])
+	}
+}
+
+func TestSeverityMask(t *testing.T) {
+	mask := zabbix.NewSeverityMask(zabbix.TriggerSeverityAverage, zabbix.TriggerSeverityHigh, zabbix.TriggerSeverityDisaster)
+	if mask != 56 {
+		t.Errorf("Expected severity mask 56 but got %d", mask)
+	}
+	if !mask.Has(zabbix.TriggerSeverityHigh) || mask.Has(zabbix.TriggerSeverityWarning) {
+		t.Errorf("Unexpected severities in mask %v", mask)
+	}
+	if s := mask.String(); s != "average,high,disaster" {
+		t.Errorf("Expected average,high,disaster but got %q", s)
+	}
+	if zabbix.SeverityMaskAll != 63 {
+		t.Errorf("Expected all severities mask 63 but got %d", zabbix.SeverityMaskAll)
+	}
+	if m := zabbix.NewSeverityMask(-1, 6, 64, zabbix.TriggerSeverityHigh); m != 16 || m.Has(-1) || m.Has(64) {
+		t.Errorf("Expected invalid severities to be ignored but got mask %d", m)
+	}
+
+	tests := map[string]zabbix.SeverityMask{
+		"average,high,disaster":  mask,
+		"disaster, HIGH,average": mask,
+		"56":                     mask,
+		"":                       0,
+		"63":                     zabbix.SeverityMaskAll,
+	}
+	for text, expected := range tests {
+		var m zabbix.SeverityMask
+		if err := m.UnmarshalText([]byte(text)); err != nil {
+			t.Errorf("Error unmarshalling severity mask %q: %v", text, err)
+		} else if m != expected {
+			t.Errorf("Expected severity mask %q to be %d but got %d", text, expected, m)
+		}
+	}
+
+	for _, text := range []string{"64", "-1", "average,critical"} {
+		var m zabbix.SeverityMask
+		if err := m.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("Expected an error unmarshalling severity mask %q", text)
+		}
+	}
+
+	var media zabbix.Media
+	if err := json.Unmarshal([]byte(`{"severity":"48"}`), &media); err != nil {
+		t.Fatal(err)
+	}
+	if media.Severity != zabbix.NewSeverityMask(zabbix.TriggerSeverityHigh, zabbix.TriggerSeverityDisaster) {
+		t.Errorf("Expected high,disaster but got %v", media.Severity)
+	}
+}
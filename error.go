@@ -28,3 +28,20 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("%s", e.Message)
 }
+
+// UnsupportedVersionError describes an API method which is not available in
+// the version of the connected Zabbix API.
+type UnsupportedVersionError struct {
+	// Method is the name of the unsupported API method.
+	Method string
+
+	// Required is the minimum Zabbix API version supporting Method.
+	Required string
+
+	// Version is the version of the connected Zabbix API.
+	Version string
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("%s requires Zabbix API v%s or later, connected to v%s", e.Method, e.Required, e.Version)
+}
@@ -6,9 +6,12 @@ import (
 	"github.com/NexonSU/go-zabbix/types"
 )
 
+// EventSource is the type of the source of an Event.
+type EventSource int
+
 const (
 	// EventSourceTrigger indicates that an Event was created by a Trigger.
-	EventSourceTrigger = iota
+	EventSourceTrigger EventSource = iota
 
 	// EventSourceDiscoveryRule indicates than an Event was created by a
 	// Discovery Rule.
@@ -21,12 +24,19 @@ const (
 	// EventSourceInternal indicates that an Event was created by an Internal
 	// Event.
 	EventSourceInternal
+
+	// EventSourceService indicates that an Event was created by a Service
+	// status update. Available since Zabbix 6.0.
+	EventSourceService
 )
 
+// EventObjectType is the type of the object related to an Event.
+type EventObjectType int
+
 const (
 	// EventObjectTypeTrigger indicates that an Event with Source type
 	// EventSourceTrigger or EventSourceInternal is related to a Trigger.
-	EventObjectTypeTrigger = iota
+	EventObjectTypeTrigger EventObjectType = iota
 
 	// EventObjectTypeDiscoveredHost indicates that an Event with Source type
 	// EventSourceDiscoveryRule is related to a discovered Host.
@@ -47,8 +57,88 @@ const (
 	// EventObjectTypeLLDRule indicates that an Event with Source type
 	// EventSourceInternal is related to a low-level Discovery Rule.
 	EventObjectTypeLLDRule
+
+	// EventObjectTypeService indicates that an Event with Source type
+	// EventSourceService is related to a Service. Available since Zabbix 6.0.
+	EventObjectTypeService
 )
 
+var (
+	eventSources = enum{"EventSource", map[int]string{
+		int(EventSourceTrigger):          "trigger",
+		int(EventSourceDiscoveryRule):    "discovery",
+		int(EventSourceAutoRegistration): "autoregistration",
+		int(EventSourceInternal):         "internal",
+		int(EventSourceService):          "service",
+	}}
+
+	eventObjectTypes = enum{"EventObjectType", map[int]string{
+		int(EventObjectTypeTrigger):            "trigger",
+		int(EventObjectTypeDiscoveredHost):     "discovered_host",
+		int(EventObjectTypeDiscoveredService):  "discovered_service",
+		int(EventObjectTypeAutoRegisteredHost): "autoregistered_host",
+		int(EventObjectTypeItem):               "item",
+		int(EventObjectTypeLLDRule):            "lld_rule",
+		int(EventObjectTypeService):            "service",
+	}}
+)
+
+// String returns the name of the EventSource.
+func (s EventSource) String() string {
+	return eventSources.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s EventSource) MarshalText() ([]byte, error) {
+	return eventSources.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *EventSource) UnmarshalText(text []byte) error {
+	return eventSources.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s EventSource) MarshalJSON() ([]byte, error) {
+	return eventSources.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *EventSource) UnmarshalJSON(data []byte) error {
+	return eventSources.unmarshalJSON(data, (*int)(s))
+}
+
+// String returns the name of the EventObjectType.
+func (t EventObjectType) String() string {
+	return eventObjectTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t EventObjectType) MarshalText() ([]byte, error) {
+	return eventObjectTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *EventObjectType) UnmarshalText(text []byte) error {
+	return eventObjectTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t EventObjectType) MarshalJSON() ([]byte, error) {
+	return eventObjectTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *EventObjectType) UnmarshalJSON(data []byte) error {
+	return eventObjectTypes.unmarshalJSON(data, (*int)(t))
+}
+
 const (
 	// TriggerEventValueOK indicates that the Object related to an Event with
 	// Source type EventSourceTrigger is in an "OK" state.
@@ -98,28 +188,32 @@ type Event struct {
 	EventID string `json:"eventid"`
 
 	// Acknowledged indicates if the Event has been acknowledged by an operator.
-	Acknowledged types.ZBXBoolean `json:"acknowledged,string"`
+	Acknowledged types.ZBXBoolean `json:"acknowledged"`
 
-	clock       int64 `json:"clock,string"`
-	nanoseconds int64 `json:"ns,string"`
+	// Clock is the Unix time when the Event was created.
+	Clock types.ZBXInt `json:"clock"`
+
+	// Nanoseconds is the nanoseconds part of the time when the Event was
+	// created.
+	Nanoseconds types.ZBXInt `json:"ns"`
 
 	// Source is the type of the Event source.
 	//
 	// Source must be one of the EventSource constants.
-	Source int `json:"source,string"`
+	Source EventSource `json:"source"`
 
 	// ObjectType is the type of the Object that is related to the Event.
 	// ObjectType must be one of the EventObjectType constants.
-	ObjectType int `json:"object,string"`
+	ObjectType EventObjectType `json:"object"`
 
 	// ObjectID is the unique identifier of the Object that caused this Event.
-	ObjectID int `json:"objectid,string"`
+	ObjectID types.ZBXInt `json:"objectid"`
 
 	// Value is the state of the related Object.
 	//
 	// Value must be one of the EventValue constants, according to the Event's
 	// Source type.
-	Value int `json:"value,string"`
+	Value types.ZBXInt `json:"value"`
 
 	// ValueChanges indicates if the state of the related Object has changed
 	// since the previous Event.
@@ -132,12 +226,84 @@ type Event struct {
 	// query parameters that returned this Event and the Event Source is one of
 	// EventSourceTrigger or EventSourceDiscoveryRule.
 	Hosts []Host `json:"hosts"`
+
+	// RecoveryEventID is the ID of the recovery Event.
+	RecoveryEventID string `json:"r_eventid,omitempty"`
+
+	// Severity is the current severity of the Event.
+	//
+	// Severity must be one of the TriggerSeverity constants.
+	Severity Severity `json:"severity"`
+
+	// Suppressed indicates if the Event is suppressed by a maintenance or
+	// manually by an operator.
+	Suppressed types.ZBXBoolean `json:"suppressed"`
+
+	// Acknowledges is an array of updates made to the Event by operators.
+	//
+	// Acknowledges is only populated if EventGetParams.SelectAcknowledgements
+	// is given in the query parameters that returned this Event.
+	Acknowledges []EventAcknowledge `json:"acknowledges,omitempty"`
+
+	// Tags is an array of Event tags.
+	//
+	// Tags is only populated if EventGetParams.SelectTags is given in the
+	// query parameters that returned this Event.
+	Tags []EventTag `json:"tags,omitempty"`
+}
+
+// EventTag is event tag
+type EventTag struct {
+	Name  string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// EventAcknowledge represents an update made to an Event by an operator.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/event/object#acknowledges
+type EventAcknowledge struct {
+	// AcknowledgeID is the unique ID of the update.
+	AcknowledgeID string `json:"acknowledgeid"`
+
+	// UserID is the ID of the User that updated the Event.
+	UserID string `json:"userid"`
+
+	// EventID is the ID of the updated Event.
+	EventID string `json:"eventid"`
+
+	// Timestamp is the time when the Event was updated.
+	Timestamp types.ZBXUnixTimestamp `json:"clock"`
+
+	// Message is the text of the message left by the operator.
+	Message string `json:"message"`
+
+	// Action is the set of update actions that were performed.
+	Action EventAcknowledgeAction `json:"action,string"`
+
+	// OldSeverity is the Event severity before the update.
+	OldSeverity Severity `json:"old_severity"`
+
+	// NewSeverity is the Event severity after the update.
+	NewSeverity Severity `json:"new_severity"`
+
+	// SuppressUntil is the time until which the Event is suppressed. Zero
+	// means the Event is suppressed indefinitely.
+	SuppressUntil types.ZBXInt `json:"suppress_until,omitempty"`
+
+	// Username is the name of the User that updated the Event.
+	Username string `json:"username,omitempty"`
+
+	// Name is the first name of the User that updated the Event.
+	Name string `json:"name,omitempty"`
+
+	// Surname is the last name of the User that updated the Event.
+	Surname string `json:"surname,omitempty"`
 }
 
 // Timestamp returns time.Time depending on the seconds and nanoseconds returned
 // by Zabbix
 func (e *Event) Timestamp() time.Time {
-	return time.Unix(e.clock, e.nanoseconds)
+	return time.Unix(int64(e.Clock), int64(e.Nanoseconds))
 }
 
 // EventGetParams is query params for event.get call
@@ -164,7 +330,7 @@ type EventGetParams struct {
 	// Type. Must be one of the EventObjectType constants.
 	//
 	// Default: EventObjectTypeTrigger
-	ObjectType int `json:"object"`
+	ObjectType EventObjectType `json:"object"`
 
 	// AcknowledgedOnly filters search results to event which have been
 	// acknowledged.
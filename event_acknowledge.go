@@ -0,0 +1,186 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// EventAcknowledgeAction is a bitmask of update actions performed on an Event
+// by an `event.acknowledge` API call.
+type EventAcknowledgeAction int
+
+const (
+	// EventAcknowledgeActionClose closes the problem.
+	EventAcknowledgeActionClose EventAcknowledgeAction = 1 << iota
+
+	// EventAcknowledgeActionAcknowledge acknowledges the Event.
+	EventAcknowledgeActionAcknowledge
+
+	// EventAcknowledgeActionMessage adds a message to the Event.
+	EventAcknowledgeActionMessage
+
+	// EventAcknowledgeActionSeverity changes the severity of the Event.
+	EventAcknowledgeActionSeverity
+
+	// EventAcknowledgeActionUnacknowledge removes the acknowledgement of the
+	// Event.
+	EventAcknowledgeActionUnacknowledge
+
+	// EventAcknowledgeActionSuppress suppresses the Event.
+	EventAcknowledgeActionSuppress
+
+	// EventAcknowledgeActionUnsuppress removes the suppression of the Event.
+	EventAcknowledgeActionUnsuppress
+
+	// EventAcknowledgeActionChangeToCause changes the rank of a symptom Event
+	// to cause.
+	EventAcknowledgeActionChangeToCause
+
+	// EventAcknowledgeActionChangeToSymptom changes the rank of an Event to
+	// symptom of the Event given in EventAcknowledgeParams.CauseEventID.
+	EventAcknowledgeActionChangeToSymptom
+)
+
+// ErrEventAcknowledgeNoAction is returned by AcknowledgeEvents if no update
+// action was requested.
+var ErrEventAcknowledgeNoAction = errors.New("No event update action was given")
+
+// Has returns true if all the given actions are set in the bitmask.
+func (a EventAcknowledgeAction) Has(action EventAcknowledgeAction) bool {
+	return a&action == action
+}
+
+// EventAcknowledgeParams is params for event.acknowledge call.
+//
+// The update actions are best built with NewEventAcknowledge and its chained
+// methods, which set both the action bit and the related parameter.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/event/acknowledge
+type EventAcknowledgeParams struct {
+	// EventIDs are the IDs of the Events to update.
+	EventIDs []string `json:"eventids"`
+
+	// Action is the bitmask of update actions to perform.
+	Action EventAcknowledgeAction `json:"action"`
+
+	// Message is the text of the message, required if Action contains
+	// EventAcknowledgeActionMessage.
+	Message string `json:"message,omitempty"`
+
+	// Severity is the new severity of the Events, required if Action contains
+	// EventAcknowledgeActionSeverity.
+	//
+	// Severity must be one of the TriggerSeverity constants.
+	Severity *Severity `json:"severity,omitempty"`
+
+	// SuppressUntil is the time until which the Events are suppressed if
+	// Action contains EventAcknowledgeActionSuppress. Zero suppresses the
+	// Events indefinitely.
+	SuppressUntil int64 `json:"suppress_until,omitempty"`
+
+	// CauseEventID is the ID of the cause Event, required if Action contains
+	// EventAcknowledgeActionChangeToSymptom.
+	CauseEventID string `json:"cause_eventid,omitempty"`
+}
+
+// NewEventAcknowledge returns new event.acknowledge params for the given
+// Event IDs without any update action set.
+func NewEventAcknowledge(eventIDs ...string) *EventAcknowledgeParams {
+	return &EventAcknowledgeParams{
+		EventIDs: eventIDs,
+	}
+}
+
+// Close closes the problems.
+func (p *EventAcknowledgeParams) Close() *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionClose
+	return p
+}
+
+// Acknowledge acknowledges the Events.
+func (p *EventAcknowledgeParams) Acknowledge() *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionAcknowledge
+	return p
+}
+
+// Unacknowledge removes the acknowledgement of the Events.
+func (p *EventAcknowledgeParams) Unacknowledge() *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionUnacknowledge
+	return p
+}
+
+// WithMessage adds the given message to the Events.
+func (p *EventAcknowledgeParams) WithMessage(message string) *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionMessage
+	p.Message = message
+	return p
+}
+
+// WithSeverity changes the severity of the Events. Severity must be one of the
+// TriggerSeverity constants.
+func (p *EventAcknowledgeParams) WithSeverity(severity Severity) *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionSeverity
+	p.Severity = &severity
+	return p
+}
+
+// Suppress suppresses the Events until the given time. A zero time suppresses
+// the Events indefinitely.
+func (p *EventAcknowledgeParams) Suppress(until time.Time) *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionSuppress
+	p.SuppressUntil = 0
+	if !until.IsZero() {
+		p.SuppressUntil = until.Unix()
+	}
+	return p
+}
+
+// Unsuppress removes the suppression of the Events.
+func (p *EventAcknowledgeParams) Unsuppress() *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionUnsuppress
+	return p
+}
+
+// ChangeToCause changes the rank of the Events to cause.
+func (p *EventAcknowledgeParams) ChangeToCause() *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionChangeToCause
+	return p
+}
+
+// ChangeToSymptom changes the rank of the Events to symptoms of the given
+// cause Event.
+func (p *EventAcknowledgeParams) ChangeToSymptom(causeEventID string) *EventAcknowledgeParams {
+	p.Action |= EventAcknowledgeActionChangeToSymptom
+	p.CauseEventID = causeEventID
+	return p
+}
+
+// AcknowledgeEvents updates Events with the actions given in the params.
+// Returns a list of updated Event IDs.
+//
+// ErrEventAcknowledgeNoAction is returned if no update action was given.
+// An error is returned if a transport, parsing or API error occurs.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/event/acknowledge
+func (c *Session) AcknowledgeEvents(params *EventAcknowledgeParams) ([]string, error) {
+	if params.Action == 0 {
+		return nil, ErrEventAcknowledgeNoAction
+	}
+
+	var body struct {
+		EventIDs []json.Number `json:"eventids"`
+	}
+
+	if err := c.Get("event.acknowledge", params, &body); err != nil {
+		return nil, err
+	}
+
+	// event IDs are returned as numbers by some Zabbix versions
+	eventIDs := make([]string, 0, len(body.EventIDs))
+	for _, id := range body.EventIDs {
+		eventIDs = append(eventIDs, id.String())
+	}
+
+	return eventIDs, nil
+}
@@ -0,0 +1,63 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+)
+
+func TestEventAcknowledgeParams(t *testing.T) {
+	tests := map[string]*zabbix.EventAcknowledgeParams{
+		`{"eventids":["1"],"action":6,"message":"on it"}`: zabbix.NewEventAcknowledge("1").
+			Acknowledge().
+			WithMessage("on it"),
+		`{"eventids":["1","2"],"action":9,"severity":"0"}`: zabbix.NewEventAcknowledge("1", "2").
+			Close().
+			WithSeverity(zabbix.TriggerSeverityNotClassified),
+		`{"eventids":["1"],"action":32,"suppress_until":1683642493}`: zabbix.NewEventAcknowledge("1").
+			Suppress(time.Unix(1683642493, 0)),
+		`{"eventids":["1"],"action":32}`: zabbix.NewEventAcknowledge("1").
+			Suppress(time.Time{}),
+		`{"eventids":["1"],"action":80}`: zabbix.NewEventAcknowledge("1").
+			Unacknowledge().
+			Unsuppress(),
+		`{"eventids":["3"],"action":256,"cause_eventid":"2"}`: zabbix.NewEventAcknowledge("3").
+			ChangeToSymptom("2"),
+		`{"eventids":["2"],"action":128}`: zabbix.NewEventAcknowledge("2").
+			ChangeToCause(),
+	}
+
+	for expected, params := range tests {
+		b, err := json.Marshal(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(b) != expected {
+			t.Errorf("Expected params %s but got %s", expected, b)
+		}
+	}
+}
+
+func TestEventAcknowledgeActionHas(t *testing.T) {
+	action := zabbix.NewEventAcknowledge("1").Acknowledge().WithMessage("").Action
+
+	if !action.Has(zabbix.EventAcknowledgeActionAcknowledge | zabbix.EventAcknowledgeActionMessage) {
+		t.Errorf("Expected action %d to contain acknowledge and message", action)
+	}
+
+	if action.Has(zabbix.EventAcknowledgeActionClose) {
+		t.Errorf("Expected action %d not to contain close", action)
+	}
+}
+
+func TestAcknowledgeEventsWithoutAction(t *testing.T) {
+	session := &zabbix.Session{}
+
+	_, err := session.AcknowledgeEvents(zabbix.NewEventAcknowledge("1"))
+	if err != zabbix.ErrEventAcknowledgeNoAction {
+		t.Errorf("Expected ErrEventAcknowledgeNoAction but got %v", err)
+	}
+}
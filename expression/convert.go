@@ -0,0 +1,242 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ConvertError is returned when a function in the old syntax has no
+// equivalent in the new syntax.
+type ConvertError struct {
+	Function string
+	Reason   string
+}
+
+func (e *ConvertError) Error() string {
+	return fmt.Sprintf("Cannot convert function %s: %s", e.Function, e.Reason)
+}
+
+// Convert returns the expression in the new syntax, converting functions the
+// way the Zabbix 5.4 upgrade does. For example:
+//
+//	{host:key.last(#3)}         => last(/host/key,#3)
+//	{host:key.avg(1h,1d)}       => avg(/host/key,1h:now-1d)
+//	{host:key.diff()}           => (last(/host/key,#1)<>last(/host/key,#2))
+//	{host:key.str("error",5m)}  => find(/host/key,5m,"like","error")
+//
+// Expressions in the new syntax are returned unchanged.
+func (e *Expression) Convert() (*Expression, error) {
+	if e.Syntax == SyntaxNew {
+		return e, nil
+	}
+
+	root, err := convert(e.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Expression{Root: root, Syntax: SyntaxNew}, nil
+}
+
+func convert(n Node) (Node, error) {
+	var err error
+	switch n := n.(type) {
+	case *OldFunction:
+		return convertFunction(n)
+
+	case *Unary:
+		u := &Unary{Op: n.Op}
+		u.X, err = convert(n.X)
+		return u, err
+
+	case *Binary:
+		b := &Binary{Op: n.Op}
+		if b.X, err = convert(n.X); err != nil {
+			return nil, err
+		}
+		b.Y, err = convert(n.Y)
+		return b, err
+
+	case *Paren:
+		p := &Paren{}
+		p.X, err = convert(n.X)
+		return p, err
+	}
+
+	return n, nil
+}
+
+// countOperators are the operators of count and their new names.
+var countOperators = map[string]string{
+	"eq":      "eq",
+	"ne":      "ne",
+	"gt":      "gt",
+	"ge":      "ge",
+	"lt":      "lt",
+	"le":      "le",
+	"like":    "like",
+	"band":    "bitand",
+	"regexp":  "regexp",
+	"iregexp": "iregexp",
+}
+
+func convertFunction(f *OldFunction) (Node, error) {
+	q := &Query{Host: f.Host, Key: f.Key}
+	param := func(i int) string {
+		if i < len(f.Params) {
+			return unquote(f.Params[i])
+		}
+		return ""
+	}
+	call := func(name string, args ...Node) *Function {
+		// trailing omitted arguments are dropped
+		for len(args) > 0 && args[len(args)-1] == nil {
+			args = args[:len(args)-1]
+		}
+		return &Function{Name: name, Args: args}
+	}
+
+	switch f.Name {
+	case "last":
+		return call("last", q, lastPeriod(param(0), param(1))), nil
+
+	case "prev":
+		return call("last", q, &Period{Value: "#2"}), nil
+
+	case "avg", "min", "max", "sum":
+		return call(f.Name, q, period(param(0), param(1))), nil
+
+	case "count":
+		var op, pattern Node
+		if len(f.Params) > 1 {
+			pattern = literal(param(1))
+		}
+		if o := param(2); o != "" {
+			name, ok := countOperators[o]
+			if !ok {
+				return nil, &ConvertError{Function: f.String(), Reason: fmt.Sprintf("unknown operator %q", o)}
+			}
+			op = &String{Value: name}
+		}
+		return call("count", q, period(param(0), param(3)), op, pattern), nil
+
+	case "nodata", "fuzzytime":
+		return call(f.Name, q, literal(param(0))), nil
+
+	case "change":
+		return call("change", q), nil
+
+	case "abschange":
+		return call("abs", call("change", q)), nil
+
+	case "diff":
+		return &Paren{X: &Binary{
+			Op: "<>",
+			X:  call("last", q, &Period{Value: "#1"}),
+			Y:  call("last", q, &Period{Value: "#2"}),
+		}}, nil
+
+	case "delta":
+		p := period(param(0), param(1))
+		return &Paren{X: &Binary{Op: "-", X: call("max", q, p), Y: call("min", q, p)}}, nil
+
+	case "percentile":
+		return call("percentile", q, period(param(0), param(1)), literal(param(2))), nil
+
+	case "strlen":
+		return call("length", call("last", q, lastPeriod(param(0), param(1)))), nil
+
+	case "str", "regexp", "iregexp":
+		op := f.Name
+		if op == "str" {
+			op = "like"
+		}
+		return call("find", q, period(param(1), ""), &String{Value: op}, &String{Value: param(0)}), nil
+
+	case "band":
+		return call("bitand", call("last", q, lastPeriod(param(0), param(2))), literal(param(1))), nil
+
+	case "forecast":
+		return call("forecast", q, period(param(0), param(1)), literal(param(2)), str(param(3)), str(param(4))), nil
+
+	case "timeleft":
+		return call("timeleft", q, period(param(0), param(1)), literal(param(2)), str(param(3))), nil
+
+	case "logeventid", "logsource":
+		return call(f.Name, q, nil, str(param(0))), nil
+
+	case "logseverity":
+		return call("logseverity", q), nil
+
+	case "date", "time", "now", "dayofweek", "dayofmonth":
+		return call(f.Name), nil
+	}
+
+	return nil, &ConvertError{Function: f.String(), Reason: "unsupported function"}
+}
+
+// period returns the period parameter of a new function from the period and
+// time shift parameters of an old one. 0 is the last value.
+func period(p, shift string) Node {
+	if p == "0" {
+		p = ""
+	}
+
+	if shift != "" && shift != "0" {
+		if p == "" {
+			p = "#1"
+		}
+		return &Period{Value: p + ":now-" + shift}
+	}
+
+	switch {
+	case p == "":
+		return nil
+	case strings.HasPrefix(p, "#"):
+		return &Period{Value: p}
+	}
+
+	return literal(p)
+}
+
+// lastPeriod returns the period of last for the old functions returning the
+// last value. Only #n periods select a value: time periods are ignored by
+// the old functions and are invalid for last.
+func lastPeriod(p, shift string) Node {
+	if !strings.HasPrefix(p, "#") {
+		p = ""
+	}
+	return period(p, shift)
+}
+
+var numberRegexp = regexp.MustCompile(`^[0-9]*\.?[0-9]+([eE][+-]?[0-9]+)?[smhdwKMGT]?$`)
+
+// literal returns a number, macro or string node for an unquoted parameter.
+func literal(s string) Node {
+	switch {
+	case s == "":
+		return nil
+	case numberRegexp.MatchString(s):
+		return &Number{Value: s}
+	case strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") && strings.Count(s, "{") == 1:
+		return &Macro{Name: s}
+	}
+	return &String{Value: s}
+}
+
+// str returns a string node for a parameter, or nil if it is empty.
+func str(s string) Node {
+	if s == "" {
+		return nil
+	}
+	return &String{Value: s}
+}
+
+// unquote returns the value of a parameter of a function in the old syntax.
+func unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], `\"`, `"`)
+}
@@ -0,0 +1,852 @@
+package expression
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Value is an item value received at a point in time.
+type Value struct {
+	Clock time.Time
+	Value string
+}
+
+// Source provides the values of the items referenced by an expression.
+type Source interface {
+	// Values returns the values of an item received after from, if not
+	// zero, and until to, newest first. At most limit values are returned if
+	// limit is not zero.
+	Values(item Item, from, to time.Time, limit int) ([]Value, error)
+}
+
+// History is a Source holding the values of items in memory, in any order.
+type History map[Item][]Value
+
+// Values returns the values of an item. An error is returned if the History
+// has no values for the item.
+func (h History) Values(item Item, from, to time.Time, limit int) ([]Value, error) {
+	all, ok := h[item]
+	if !ok {
+		return nil, fmt.Errorf("No history for item /%s/%s", item.Host, item.Key)
+	}
+
+	sorted := append([]Value{}, all...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Clock.After(sorted[j].Clock)
+	})
+
+	var values []Value
+	for _, v := range sorted {
+		if v.Clock.After(to) || !from.IsZero() && !v.Clock.After(from) {
+			continue
+		}
+		values = append(values, v)
+		if limit > 0 && len(values) == limit {
+			break
+		}
+	}
+
+	return values, nil
+}
+
+// Step is the value of a function or operation of an evaluated expression.
+type Step struct {
+	Expression string
+	Value      string
+}
+
+// Result is the result of the evaluation of an expression.
+type Result struct {
+	// Problem is true if the expression evaluated to a non-zero value.
+	Problem bool
+
+	Value float64
+
+	// Fired are the comparisons and functions which made the expression
+	// evaluate to a problem.
+	Fired []string
+
+	// Steps are the values of the functions, comparisons and logical
+	// operations of the expression, in evaluation order.
+	Steps []Step
+}
+
+// String explains the Result.
+func (r *Result) String() string {
+	var b strings.Builder
+	if r.Problem {
+		b.WriteString("PROBLEM\n")
+		for _, s := range r.Fired {
+			fmt.Fprintf(&b, "  fired: %s\n", s)
+		}
+	} else {
+		b.WriteString("OK\n")
+	}
+	for _, s := range r.Steps {
+		fmt.Fprintf(&b, "  %s = %s\n", s.Expression, s.Value)
+	}
+	return b.String()
+}
+
+// UnknownError is returned when an expression cannot be evaluated, such as
+// when there is not enough data for a function. The Zabbix server sets the
+// trigger to the unknown state in that case.
+type UnknownError struct {
+	Expression string
+	Reason     string
+}
+
+func (e *UnknownError) Error() string {
+	return fmt.Sprintf("Cannot evaluate %s: %s", e.Expression, e.Reason)
+}
+
+// Evaluator evaluates trigger expressions offline, against the item values
+// of a Source.
+//
+// The supported functions are abs, last, avg, min, max, sum, count, nodata,
+// change and percentile. Expressions in the old syntax are converted first,
+// so that diff, prev, delta and abschange are supported as well.
+type Evaluator struct {
+	Source Source
+
+	// Time is the time of the evaluation. The current time is used if zero.
+	Time time.Time
+
+	// Macros expands the macros of text, such as with macro.Expand. It is
+	// called for macros, item queries and string parameters. Expressions
+	// holding macros cannot be evaluated if nil.
+	Macros func(text string) (string, error)
+}
+
+// Evaluate evaluates an expression. An UnknownError is returned if the
+// expression cannot be evaluated because of missing data.
+func (ev *Evaluator) Evaluate(e *Expression) (*Result, error) {
+	e, err := e.Convert()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &evaluation{Evaluator: ev, now: ev.Time}
+	if s.now.IsZero() {
+		s.now = time.Now()
+	}
+
+	v, err := s.eval(e.Root)
+	if err != nil {
+		return nil, err
+	}
+	if v.unknown != "" {
+		return nil, &UnknownError{Expression: v.unknownExpr, Reason: v.unknown}
+	}
+
+	res := &Result{Steps: s.steps}
+	if res.Value, err = v.float(e.Root); err != nil {
+		return nil, err
+	}
+
+	if res.Problem = res.Value != 0; res.Problem {
+		fired := v.fired
+		if len(fired) == 0 {
+			fired = []Node{e.Root}
+		}
+		for _, n := range fired {
+			res.Fired = append(res.Fired, n.String())
+		}
+	}
+
+	return res, nil
+}
+
+// evaluation is the state of the evaluation of an expression.
+type evaluation struct {
+	*Evaluator
+
+	now   time.Time
+	steps []Step
+}
+
+// value is the value of a node.
+type value struct {
+	num  float64
+	str  string
+	text bool
+
+	// unknown is the reason why the value is unknown, if not empty.
+	unknown     string
+	unknownExpr string
+
+	// fired are the nodes explaining a true boolean value.
+	fired []Node
+}
+
+func (v value) String() string {
+	switch {
+	case v.unknown != "":
+		return "unknown"
+	case v.text:
+		return strconv.Quote(v.str)
+	}
+	return strconv.FormatFloat(v.num, 'f', -1, 64)
+}
+
+func (v value) float(n Node) (float64, error) {
+	if v.text {
+		return 0, fmt.Errorf("Cannot use string %q of %s as a number", v.str, n)
+	}
+	return v.num, nil
+}
+
+func (v value) known() bool {
+	return v.unknown == ""
+}
+
+func boolean(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func unknown(n Node, format string, a ...interface{}) value {
+	return value{unknown: fmt.Sprintf(format, a...), unknownExpr: n.String()}
+}
+
+// textValue returns a numeric value if s is a number, or else a string.
+func textValue(s string) value {
+	if f, err := parseNumber(s); err == nil {
+		return value{num: f}
+	}
+	return value{str: s, text: true}
+}
+
+// itemValue returns a numeric value if the item value s is a number, or else
+// a string. Unlike constants, item values have no suffixes.
+func itemValue(s string) value {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return value{num: f}
+	}
+	return value{str: s, text: true}
+}
+
+// equalEpsilon is the precision of the Zabbix server when comparing floats.
+const equalEpsilon = 0.000001
+
+func (s *evaluation) step(n Node, v value) value {
+	s.steps = append(s.steps, Step{Expression: n.String(), Value: v.String()})
+	return v
+}
+
+func (s *evaluation) expand(text string) (string, error) {
+	if !strings.Contains(text, "{") {
+		return text, nil
+	}
+	if s.Macros == nil {
+		if macroRegexp.MatchString(text) {
+			return "", fmt.Errorf("Cannot expand macros of %q", text)
+		}
+		return text, nil
+	}
+	return s.Macros(text)
+}
+
+func (s *evaluation) eval(n Node) (value, error) {
+	switch n := n.(type) {
+	case *Number:
+		f, err := n.Float()
+		return value{num: f}, err
+
+	case *String:
+		text, err := s.expand(n.Value)
+		return value{str: text, text: true}, err
+
+	case *Macro:
+		text, err := s.expand(n.Name)
+		if err != nil {
+			return value{}, err
+		}
+		return textValue(text), nil
+
+	case *Paren:
+		return s.eval(n.X)
+
+	case *Unary:
+		return s.unary(n)
+
+	case *Binary:
+		return s.binary(n)
+
+	case *Function:
+		v, err := s.function(n)
+		if err != nil {
+			return value{}, err
+		}
+		return s.step(n, v), nil
+
+	case *FunctionID:
+		return value{}, fmt.Errorf("Cannot evaluate function %s, expand the expression first", n)
+	}
+
+	return value{}, fmt.Errorf("Cannot evaluate %s outside of a function", n)
+}
+
+func (s *evaluation) unary(n *Unary) (value, error) {
+	x, err := s.eval(n.X)
+	if err != nil || !x.known() {
+		return x, err
+	}
+
+	f, err := x.float(n.X)
+	if err != nil {
+		return value{}, err
+	}
+
+	if n.Op == "-" {
+		return value{num: -f}, nil
+	}
+
+	v := value{num: boolean(f == 0)}
+	if v.num != 0 {
+		v.fired = []Node{n}
+	}
+	return s.step(n, v), nil
+}
+
+func (s *evaluation) binary(n *Binary) (value, error) {
+	x, err := s.eval(n.X)
+	if err != nil {
+		return value{}, err
+	}
+	y, err := s.eval(n.Y)
+	if err != nil {
+		return value{}, err
+	}
+
+	switch n.Op {
+	case "and", "or":
+		v, err := s.logical(n, x, y)
+		if err != nil {
+			return value{}, err
+		}
+		return s.step(n, v), nil
+	}
+
+	if !x.known() {
+		return x, nil
+	}
+	if !y.known() {
+		return y, nil
+	}
+
+	switch n.Op {
+	case "=", "<>":
+		var equal bool
+		if x.text || y.text {
+			equal = x.String() == y.String()
+			if x.text && y.text {
+				equal = x.str == y.str
+			}
+		} else {
+			equal = math.Abs(x.num-y.num) <= equalEpsilon
+		}
+
+		v := value{num: boolean(equal == (n.Op == "="))}
+		if v.num != 0 {
+			v.fired = []Node{n}
+		}
+		return s.step(n, v), nil
+	}
+
+	a, err := x.float(n.X)
+	if err != nil {
+		return value{}, err
+	}
+	b, err := y.float(n.Y)
+	if err != nil {
+		return value{}, err
+	}
+
+	var v value
+	switch n.Op {
+	case "<":
+		v.num = boolean(a < b)
+	case "<=":
+		v.num = boolean(a <= b)
+	case ">":
+		v.num = boolean(a > b)
+	case ">=":
+		v.num = boolean(a >= b)
+	case "+":
+		return value{num: a + b}, nil
+	case "-":
+		return value{num: a - b}, nil
+	case "*":
+		return value{num: a * b}, nil
+	case "/":
+		if b == 0 {
+			return unknown(n, "division by zero"), nil
+		}
+		return value{num: a / b}, nil
+	}
+
+	if v.num != 0 {
+		v.fired = []Node{n}
+	}
+	return s.step(n, v), nil
+}
+
+// logical evaluates and and or, where unknown operands are ignored if the
+// other operand decides the result, as the Zabbix server does.
+func (s *evaluation) logical(n *Binary, x, y value) (value, error) {
+	truth := func(v value, operand Node) (known, truth bool, err error) {
+		if !v.known() {
+			return false, false, nil
+		}
+		f, err := v.float(operand)
+		return true, f != 0, err
+	}
+
+	xKnown, xTrue, err := truth(x, n.X)
+	if err != nil {
+		return value{}, err
+	}
+	yKnown, yTrue, err := truth(y, n.Y)
+	if err != nil {
+		return value{}, err
+	}
+
+	fired := func(v value, operand Node) []Node {
+		if len(v.fired) == 0 {
+			return []Node{operand}
+		}
+		return v.fired
+	}
+
+	if n.Op == "or" {
+		if xTrue || yTrue {
+			v := value{num: 1}
+			if xTrue {
+				v.fired = append(v.fired, fired(x, n.X)...)
+			}
+			if yTrue {
+				v.fired = append(v.fired, fired(y, n.Y)...)
+			}
+			return v, nil
+		}
+	} else {
+		if xKnown && !xTrue || yKnown && !yTrue {
+			return value{}, nil
+		}
+		if xKnown && yKnown {
+			return value{num: 1, fired: append(fired(x, n.X), fired(y, n.Y)...)}, nil
+		}
+	}
+
+	if !xKnown {
+		return x, nil
+	}
+	if !yKnown {
+		return y, nil
+	}
+	return value{}, nil
+}
+
+func (s *evaluation) function(f *Function) (value, error) {
+	if f.Name == "abs" {
+		if len(f.Args) != 1 || f.Args[0] == nil {
+			return value{}, fmt.Errorf("Invalid parameters of function %s", f)
+		}
+		x, err := s.eval(f.Args[0])
+		if err != nil || !x.known() {
+			return x, err
+		}
+		a, err := x.float(f.Args[0])
+		return value{num: math.Abs(a)}, err
+	}
+
+	if len(f.Args) == 0 {
+		return value{}, fmt.Errorf("Unsupported function %s", f)
+	}
+	q, ok := f.Args[0].(*Query)
+	if !ok {
+		return value{}, fmt.Errorf("Unsupported function %s", f)
+	}
+
+	if s.Source == nil {
+		return value{}, errors.New("No item value source to evaluate functions")
+	}
+
+	host, err := s.expand(q.Host)
+	if err != nil {
+		return value{}, err
+	}
+	key, err := s.expand(q.Key)
+	if err != nil {
+		return value{}, err
+	}
+	item := Item{Host: host, Key: key}
+
+	args := make([]string, len(f.Args)-1)
+	for i, arg := range f.Args[1:] {
+		if args[i], err = s.arg(arg); err != nil {
+			return value{}, err
+		}
+	}
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return ""
+	}
+
+	switch f.Name {
+	case "last":
+		p := arg(0)
+		if p == "" {
+			p = "#1"
+		}
+		w, err := s.window(p)
+		if err != nil {
+			return value{}, err
+		}
+		if w.count == 0 {
+			return value{}, fmt.Errorf("Invalid parameters of function %s", f)
+		}
+		values, err := s.Source.Values(item, w.from, w.to, w.count)
+		if err != nil {
+			return value{}, err
+		}
+		if len(values) < w.count {
+			return unknown(f, "not enough data"), nil
+		}
+		return itemValue(values[w.count-1].Value), nil
+
+	case "avg", "min", "max", "sum", "percentile":
+		values, err := s.values(f, item, arg(0))
+		if err != nil {
+			return value{}, err
+		}
+		if len(values) == 0 {
+			return unknown(f, "not enough data"), nil
+		}
+
+		nums, err := numbers(f, values)
+		if err != nil {
+			return value{}, err
+		}
+		return aggregate(f, nums, arg(1))
+
+	case "count":
+		values, err := s.values(f, item, arg(0))
+		if err != nil {
+			return value{}, err
+		}
+		n, err := count(values, arg(1), arg(2))
+		return value{num: float64(n)}, err
+
+	case "nodata":
+		d, err := duration(arg(0))
+		if err != nil {
+			return value{}, fmt.Errorf("Invalid parameters of function %s: %v", f, err)
+		}
+		values, err := s.Source.Values(item, s.now.Add(-d), s.now, 1)
+		if err != nil {
+			return value{}, err
+		}
+		return value{num: boolean(len(values) == 0)}, nil
+
+	case "change":
+		values, err := s.Source.Values(item, time.Time{}, s.now, 2)
+		if err != nil {
+			return value{}, err
+		}
+		if len(values) < 2 {
+			return unknown(f, "not enough data"), nil
+		}
+		last, prev := itemValue(values[0].Value), itemValue(values[1].Value)
+		if last.text || prev.text {
+			return value{num: boolean(values[0].Value != values[1].Value)}, nil
+		}
+		return value{num: last.num - prev.num}, nil
+	}
+
+	return value{}, fmt.Errorf("Unsupported function %s", f)
+}
+
+// arg returns the text of a function argument after the item query.
+func (s *evaluation) arg(n Node) (string, error) {
+	switch n := n.(type) {
+	case nil:
+		return "", nil
+	case *Period:
+		return s.expand(n.Value)
+	case *Number:
+		return n.Value, nil
+	case *String:
+		return s.expand(n.Value)
+	case *Macro:
+		return s.expand(n.Name)
+	}
+	return "", fmt.Errorf("Unsupported function parameter %s", n)
+}
+
+// values returns the values of an item within a period.
+func (s *evaluation) values(f *Function, item Item, period string) ([]Value, error) {
+	w, err := s.window(period)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid parameters of function %s: %v", f, err)
+	}
+	return s.Source.Values(item, w.from, w.to, w.count)
+}
+
+// window is the range of values of a period parameter.
+type window struct {
+	from  time.Time
+	to    time.Time
+	count int
+}
+
+// window parses a period parameter such as #5, 5m or 1h:now-1d.
+func (s *evaluation) window(period string) (window, error) {
+	w := window{to: s.now}
+	if period == "" {
+		return w, errors.New("missing period")
+	}
+
+	if i := strings.IndexByte(period, ':'); i >= 0 {
+		to, err := timeShift(s.now, period[i+1:])
+		if err != nil {
+			return w, err
+		}
+		w.to, period = to, period[:i]
+	}
+
+	if strings.HasPrefix(period, "#") {
+		n, err := strconv.Atoi(period[1:])
+		if err != nil || n <= 0 {
+			return w, fmt.Errorf("invalid number of values %q", period)
+		}
+		w.count = n
+		return w, nil
+	}
+
+	d, err := duration(period)
+	if err != nil {
+		return w, err
+	}
+	w.from = w.to.Add(-d)
+	return w, nil
+}
+
+// duration parses a time period with an optional suffix, such as 300 or 5m.
+func duration(s string) (time.Duration, error) {
+	f, err := parseNumber(s)
+	if err != nil || f <= 0 || strings.ContainsAny(s, "KMGT") {
+		return 0, fmt.Errorf("invalid time period %q", s)
+	}
+	return time.Duration(f * float64(time.Second)), nil
+}
+
+var shiftRegexp = regexp.MustCompile(`^(/[hdwMy]|[+-][0-9]+[smhdwMy]?)`)
+
+// timeShift applies a time shift such as now-1d or now/d to now.
+func timeShift(now time.Time, shift string) (time.Time, error) {
+	if !strings.HasPrefix(shift, "now") {
+		return now, fmt.Errorf("invalid time shift %q", shift)
+	}
+
+	t := now
+	for rest := shift[len("now"):]; rest != ""; {
+		op := shiftRegexp.FindString(rest)
+		if op == "" {
+			return now, fmt.Errorf("invalid time shift %q", shift)
+		}
+		rest = rest[len(op):]
+
+		unit := op[len(op)-1]
+		if op[0] == '/' {
+			t = truncate(t, unit)
+			continue
+		}
+
+		n, err := strconv.Atoi(strings.TrimRight(op[1:], "smhdwMy"))
+		if err != nil {
+			return now, fmt.Errorf("invalid time shift %q", shift)
+		}
+		if op[0] == '-' {
+			n = -n
+		}
+
+		switch unit {
+		case 'M':
+			t = t.AddDate(0, n, 0)
+		case 'y':
+			t = t.AddDate(n, 0, 0)
+		default:
+			if unit >= '0' && unit <= '9' {
+				unit = 's'
+			}
+			t = t.Add(time.Duration(n) * time.Duration(suffixes[unit]) * time.Second)
+		}
+	}
+
+	return t, nil
+}
+
+// truncate rounds t down to the start of its hour, day, week, month or year.
+func truncate(t time.Time, unit byte) time.Time {
+	y, m, d := t.Date()
+	switch unit {
+	case 'h':
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location())
+	case 'd':
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	case 'w':
+		// weeks start on Monday
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case 'M':
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+}
+
+func numbers(f *Function, values []Value) ([]float64, error) {
+	nums := make([]float64, len(values))
+	for i, v := range values {
+		n, err := strconv.ParseFloat(v.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Cannot evaluate %s of non-numeric value %q", f, v.Value)
+		}
+		nums[i] = n
+	}
+	return nums, nil
+}
+
+func aggregate(f *Function, nums []float64, percentage string) (value, error) {
+	var v float64
+	switch f.Name {
+	case "avg", "sum":
+		for _, n := range nums {
+			v += n
+		}
+		if f.Name == "avg" {
+			v /= float64(len(nums))
+		}
+
+	case "min", "max":
+		v = nums[0]
+		for _, n := range nums[1:] {
+			if f.Name == "min" && n < v || f.Name == "max" && n > v {
+				v = n
+			}
+		}
+
+	case "percentile":
+		p, err := strconv.ParseFloat(percentage, 64)
+		if err != nil || p < 0 || p > 100 {
+			return value{}, fmt.Errorf("Invalid percentage %q of function %s", percentage, f)
+		}
+
+		sort.Float64s(nums)
+		i := int(math.Ceil(p/100*float64(len(nums)))) - 1
+		if i < 0 {
+			i = 0
+		}
+		v = nums[i]
+	}
+
+	return value{num: v}, nil
+}
+
+// count counts the values matching a pattern with an operator.
+func count(values []Value, op, pattern string) (int, error) {
+	if pattern == "" && op == "" {
+		return len(values), nil
+	}
+	if op == "" {
+		op = "eq"
+	}
+
+	var match func(string) bool
+	switch op {
+	case "like":
+		match = func(v string) bool { return strings.Contains(v, pattern) }
+
+	case "regexp", "iregexp":
+		expr := pattern
+		if op == "iregexp" {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid count pattern %q: %v", pattern, err)
+		}
+		match = re.MatchString
+
+	case "bitand":
+		want, mask := pattern, pattern
+		if i := strings.IndexByte(pattern, '/'); i >= 0 {
+			want, mask = pattern[:i], pattern[i+1:]
+		}
+		w, err1 := strconv.ParseUint(want, 10, 64)
+		m, err2 := strconv.ParseUint(mask, 10, 64)
+		if err1 != nil || err2 != nil {
+			return 0, fmt.Errorf("Invalid count pattern %q", pattern)
+		}
+		match = func(v string) bool {
+			n, err := strconv.ParseUint(v, 10, 64)
+			return err == nil && n&m == w
+		}
+
+	case "eq", "ne", "gt", "ge", "lt", "le":
+		p, err := strconv.ParseFloat(pattern, 64)
+		if err != nil {
+			// strings only support equality
+			switch op {
+			case "eq":
+				match = func(v string) bool { return v == pattern }
+			case "ne":
+				match = func(v string) bool { return v != pattern }
+			default:
+				return 0, fmt.Errorf("Invalid count pattern %q for operator %s", pattern, op)
+			}
+			break
+		}
+
+		match = func(v string) bool {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return false
+			}
+			switch op {
+			case "eq":
+				return math.Abs(n-p) <= equalEpsilon
+			case "ne":
+				return math.Abs(n-p) > equalEpsilon
+			case "gt":
+				return n > p
+			case "ge":
+				return n >= p
+			case "lt":
+				return n < p
+			}
+			return n <= p
+		}
+
+	default:
+		return 0, fmt.Errorf("Unknown count operator %q", op)
+	}
+
+	n := 0
+	for _, v := range values {
+		if match(v.Value) {
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,230 @@
+package expression_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/expression"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+var evalTime = time.Date(2024, 3, 13, 12, 0, 0, 0, time.UTC)
+
+// series returns values received every minute until evalTime, oldest first.
+func series(values ...string) []expression.Value {
+	series := make([]expression.Value, len(values))
+	for i, v := range values {
+		series[i] = expression.Value{Clock: evalTime.Add(-time.Duration(len(values)-1-i) * time.Minute), Value: v}
+	}
+	return series
+}
+
+func testHistory() expression.History {
+	return expression.History{
+		{Host: "web", Key: "system.cpu.load"}:     series("1", "2", "3", "4", "10"),
+		{Host: "web", Key: "agent.ping"}:          series("1", "1"),
+		{Host: "web", Key: "net.if.status"}:       series("up", "up", "down"),
+		{Host: "db", Key: "agent.ping"}:           {{Clock: evalTime.Add(-time.Hour), Value: "1"}},
+		{Host: "db", Key: "log"}:                  series("ok", "error: disk", "ok", "fatal error"),
+		{Host: "db", Key: "vfs.fs.size[/,pfree]"}: series("50", "30", "8"),
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	macros := map[string]string{
+		`{$LOW_SPACE:"/"}`: "10",
+		"{$PERIOD}":        "5m",
+		"{HOST.HOST}":      "db",
+	}
+	ev := &expression.Evaluator{
+		Source: testHistory(),
+		Time:   evalTime,
+		Macros: func(text string) (string, error) {
+			for m, v := range macros {
+				text = strings.ReplaceAll(text, m, v)
+			}
+			return text, nil
+		},
+	}
+
+	tests := map[string]bool{
+		"last(/web/system.cpu.load)>5":                                       true,
+		"last(/web/system.cpu.load,#2)>5":                                    false,
+		"last(/web/system.cpu.load,#1:now-1m)=4":                             true,
+		"avg(/web/system.cpu.load,5m)=4":                                     true,
+		"avg(/web/system.cpu.load,{$PERIOD})>5":                              false,
+		"avg(/web/system.cpu.load,2m)>5":                                     true,
+		"min(/web/system.cpu.load,#3)=3 and max(/web/system.cpu.load,#3)=10": true,
+		"sum(/web/system.cpu.load,1h)=20":                                    true,
+		"percentile(/web/system.cpu.load,5m,80)=4":                           true,
+		"percentile(/web/system.cpu.load,5m,100)=10":                         true,
+		"change(/web/system.cpu.load)=6":                                     true,
+		"change(/web/net.if.status)=1":                                       true,
+		`last(/web/net.if.status)="down"`:                                    true,
+		`last(/web/net.if.status,#2)<>"up"`:                                  false,
+		"nodata(/web/agent.ping,5m)=1":                                       false,
+		"nodata(/db/agent.ping,5m)=1":                                        true,
+		`count(/db/log,10m,"like","error")=2`:                                true,
+		`count(/db/log,10m,"regexp","^fatal")=1`:                             true,
+		`count(/db/log,10m,,"ok")=2`:                                         true,
+		`count(/web/system.cpu.load,5m,"gt",2)=3`:                            true,
+		"count(/web/system.cpu.load,#2)=2":                                   true,
+		`last(/{HOST.HOST}/vfs.fs.size[/,pfree])<{$LOW_SPACE:"/"}`:           true,
+		"abs(last(/web/system.cpu.load)-20)=10":                              true,
+		"not last(/web/agent.ping)=1":                                        false,
+		"last(/web/system.cpu.load)/2>=5 or 1/0>1":                           true,
+		"last(/web/system.cpu.load)>100 and last(/web/agent.ping,#9)>0":      false,
+		// old syntax
+		"{web:system.cpu.load.diff()}=1":    true,
+		"{web:system.cpu.load.prev()}=4":    true,
+		"{web:system.cpu.load.delta(5m)}>5": true,
+		"{web:system.cpu.load.last(0)}>5":   true,
+		"{db:agent.ping.nodata(5m)}=1":      true,
+	}
+
+	for s, problem := range tests {
+		e, err := expression.Parse(s)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", s, err)
+			continue
+		}
+
+		res, err := ev.Evaluate(e)
+		if err != nil {
+			t.Errorf("Error evaluating %s: %v", s, err)
+			continue
+		}
+
+		if res.Problem != problem {
+			t.Errorf("Expected problem=%v for %s, got:\n%s", problem, s, res)
+		}
+	}
+}
+
+func TestEvaluateFired(t *testing.T) {
+	ev := &expression.Evaluator{Source: testHistory(), Time: evalTime}
+
+	e, err := expression.Parse(`last(/web/system.cpu.load)>5 or nodata(/web/agent.ping,5m)=1 or (count(/db/log,10m,"like","error")>0 and nodata(/db/agent.ping,5m))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := ev.Evaluate(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"last(/web/system.cpu.load)>5",
+		`count(/db/log,10m,"like","error")>0`,
+		"nodata(/db/agent.ping,5m)",
+	}
+	if !res.Problem || res.Value != 1 || !reflect.DeepEqual(res.Fired, expected) {
+		t.Errorf("Unexpected result:\n%s", res)
+	}
+
+	if res.Steps[0] != (expression.Step{Expression: "last(/web/system.cpu.load)", Value: "10"}) {
+		t.Errorf("Unexpected first step: %+v", res.Steps[0])
+	}
+
+	if s := res.String(); !strings.HasPrefix(s, "PROBLEM\n  fired: last(/web/system.cpu.load)>5\n") {
+		t.Errorf("Unexpected explanation:\n%s", s)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	ev := &expression.Evaluator{Source: testHistory(), Time: evalTime}
+
+	unknown := []string{
+		"last(/web/agent.ping,#3)=1",
+		"avg(/db/agent.ping,5m)>0",
+		"last(/web/system.cpu.load)/0>1",
+		"last(/web/agent.ping,#3)=1 or last(/web/agent.ping)=0",
+	}
+	for _, s := range unknown {
+		e, err := expression.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ev.Evaluate(e); err == nil {
+			t.Errorf("Expected error evaluating %s", s)
+		} else if _, ok := err.(*expression.UnknownError); !ok {
+			t.Errorf("Expected UnknownError evaluating %s, got %v", s, err)
+		}
+	}
+
+	invalid := []string{
+		"last(/missing/key)>0",
+		"avg(/web/net.if.status,5m)>0",
+		"forecast(/web/system.cpu.load,1h,1h)>0",
+		"{12345}>0",
+		"last(/web/system.cpu.load)>{$UNDEFINED}",
+		`last(/web/net.if.status)>"up"`,
+		"avg(/web/system.cpu.load,5x:now)>0",
+	}
+	for _, s := range invalid {
+		e, err := expression.Parse(s)
+		if err != nil {
+			continue
+		}
+		if _, err := ev.Evaluate(e); err == nil {
+			t.Errorf("Expected error evaluating %s", s)
+		} else if _, ok := err.(*expression.UnknownError); ok {
+			t.Errorf("Unexpected UnknownError evaluating %s: %v", s, err)
+		}
+	}
+}
+
+func TestSessionSource(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("item.get", func(params json.RawMessage) (interface{}, error) {
+		return []map[string]string{{"itemid": "28275", "key_": "system.cpu.load", "value_type": "0"}}, nil
+	})
+	server.Handle("history.get", func(params json.RawMessage) (interface{}, error) {
+		return []map[string]string{
+			{"itemid": "28275", "clock": "1710331200", "value": "7.5", "ns": "0"},
+			{"itemid": "28275", "clock": "1710331140", "value": "2.5", "ns": "0"},
+		}, nil
+	})
+
+	ev := &expression.Evaluator{
+		Source: expression.NewSessionSource(server.Session(t, "7.0.0")),
+		Time:   time.Unix(1710331200, 0),
+	}
+
+	e, err := expression.Parse("avg(/web/system.cpu.load,5m)=5 and last(/web/system.cpu.load)>5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := ev.Evaluate(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Problem {
+		t.Errorf("Expected problem:\n%s", res)
+	}
+
+	var itemGets int
+	for _, call := range server.Calls() {
+		if call.Method == "item.get" {
+			itemGets++
+		}
+	}
+	if itemGets != 1 {
+		t.Errorf("Expected item to be looked up once, got %d", itemGets)
+	}
+
+	calls := server.Calls()
+	var params zabbix.HistoryGetParams
+	if err := json.Unmarshal(calls[1].Params.(json.RawMessage), &params); err != nil {
+		t.Fatal(err)
+	}
+	if params.TimeFrom != 1710330901 || params.TimeTill != 1710331200 || params.ItemIDs[0] != "28275" {
+		t.Errorf("Unexpected history params: %s", calls[1].Params)
+	}
+}
@@ -0,0 +1,331 @@
+// Package expression parses Zabbix trigger expressions into an abstract syntax
+// tree, in the syntax introduced by Zabbix 5.4 as well as in the older one:
+//
+//	last(/Linux server/system.cpu.load[all,avg1])>5 and nodata(/Linux server/agent.ping,5m)=0
+//	{Linux server:system.cpu.load[all,avg1].last()}>5 and {Linux server:agent.ping.nodata(5m)}=0
+//
+// Expressions in the old syntax can be converted to the new one with Convert.
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/config/triggers/expression
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Syntax is the syntax of a trigger expression.
+type Syntax int
+
+const (
+	// SyntaxNew is the syntax of Zabbix 5.4 and later, such as
+	// last(/host/key)>0.
+	SyntaxNew Syntax = iota
+
+	// SyntaxOld is the syntax of Zabbix before 5.4, such as
+	// {host:key.last()}>0.
+	SyntaxOld
+)
+
+// String returns the name of the Syntax.
+func (s Syntax) String() string {
+	switch s {
+	case SyntaxNew:
+		return "new"
+	case SyntaxOld:
+		return "old"
+	}
+	return fmt.Sprintf("Syntax(%d)", int(s))
+}
+
+// Expression is a parsed trigger expression.
+type Expression struct {
+	Root Node
+
+	// Syntax is the syntax of the functions of the expression. Expressions
+	// without functions have the new syntax.
+	Syntax Syntax
+}
+
+// String formats the expression.
+func (e *Expression) String() string {
+	return e.Root.String()
+}
+
+// Node is a node of the syntax tree of an expression. String formats the node
+// as an expression.
+type Node interface {
+	String() string
+	node()
+}
+
+// Number is a numeric constant, with an optional time or size suffix, such as
+// 5, 0.5, 5m or 1K.
+type Number struct {
+	Value string
+}
+
+// Float returns the value of the number, converting time suffixes to seconds
+// and size suffixes to bytes.
+func (n *Number) Float() (float64, error) {
+	return parseNumber(n.Value)
+}
+
+// String is a string constant.
+type String struct {
+	// Value is the unquoted value of the string.
+	Value string
+}
+
+// Macro is a user macro such as {$LIMIT:"/var"}, a low-level discovery macro
+// such as {#FSNAME} or a built-in macro such as {HOST.HOST}.
+type Macro struct {
+	Name string
+}
+
+// FunctionID is a reference to a trigger function by ID, such as {13085}, as
+// returned by the API when expressions are not expanded.
+type FunctionID struct {
+	ID string
+}
+
+// Query is the item query of a function in the new syntax, such as
+// /host/key or /*/key?[group="Servers"].
+type Query struct {
+	Host string
+	Key  string
+
+	// Filter is the filter of an aggregate query, without its brackets.
+	Filter string
+}
+
+// Period is a function parameter holding a number of values such as #5, or
+// a time period with a time shift such as 1h:now-1d.
+type Period struct {
+	Value string
+}
+
+// Function is a function call in the new syntax, such as last(/host/key,#2)
+// or abs(-1). The first argument of item functions is a Query. Omitted
+// arguments are nil.
+type Function struct {
+	Name string
+	Args []Node
+}
+
+// OldFunction is a function call in the old syntax, such as
+// {host:key.last(#2)}.
+type OldFunction struct {
+	Host string
+	Key  string
+	Name string
+
+	// Params are the parameters of the function as written, quotes
+	// included.
+	Params []string
+}
+
+// Unary is a unary operation: - or not.
+type Unary struct {
+	Op string
+	X  Node
+}
+
+// Binary is a binary operation: or, and, =, <>, <, <=, >, >=, +, -, * or /.
+type Binary struct {
+	Op string
+	X  Node
+	Y  Node
+}
+
+// Paren is a parenthesized expression.
+type Paren struct {
+	X Node
+}
+
+func (*Number) node()      {}
+func (*String) node()      {}
+func (*Macro) node()       {}
+func (*FunctionID) node()  {}
+func (*Query) node()       {}
+func (*Period) node()      {}
+func (*Function) node()    {}
+func (*OldFunction) node() {}
+func (*Unary) node()       {}
+func (*Binary) node()      {}
+func (*Paren) node()       {}
+
+func (n *Number) String() string { return n.Value }
+
+func (n *String) String() string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(n.Value) + `"`
+}
+
+func (n *Macro) String() string { return n.Name }
+
+func (n *FunctionID) String() string { return "{" + n.ID + "}" }
+
+func (n *Query) String() string {
+	s := "/" + n.Host + "/" + n.Key
+	if n.Filter != "" {
+		s += "?[" + n.Filter + "]"
+	}
+	return s
+}
+
+func (n *Period) String() string { return n.Value }
+
+func (n *Function) String() string {
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		if arg != nil {
+			args[i] = arg.String()
+		}
+	}
+	return n.Name + "(" + strings.Join(args, ",") + ")"
+}
+
+func (n *OldFunction) String() string {
+	return "{" + n.Host + ":" + n.Key + "." + n.Name + "(" + strings.Join(n.Params, ",") + ")}"
+}
+
+func (n *Unary) String() string {
+	if n.Op == "not" {
+		return "not " + n.X.String()
+	}
+	return n.Op + n.X.String()
+}
+
+func (n *Binary) String() string {
+	if n.Op == "and" || n.Op == "or" {
+		return n.X.String() + " " + n.Op + " " + n.Y.String()
+	}
+	return n.X.String() + n.Op + n.Y.String()
+}
+
+func (n *Paren) String() string { return "(" + n.X.String() + ")" }
+
+// Walk calls fn for n and, if fn returns true, for each of its children in
+// order.
+func Walk(n Node, fn func(Node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+
+	switch n := n.(type) {
+	case *Function:
+		for _, arg := range n.Args {
+			Walk(arg, fn)
+		}
+	case *Unary:
+		Walk(n.X, fn)
+	case *Binary:
+		Walk(n.X, fn)
+		Walk(n.Y, fn)
+	case *Paren:
+		Walk(n.X, fn)
+	}
+}
+
+// Item is an item referenced by an expression.
+type Item struct {
+	Host string
+	Key  string
+}
+
+// References are the objects referenced by an expression, in order of first
+// appearance.
+type References struct {
+	Hosts       []string
+	Items       []Item
+	Macros      []string
+	FunctionIDs []string
+}
+
+// macroRegexp matches user, low-level discovery and built-in macros in hosts,
+// keys and strings.
+var macroRegexp = regexp.MustCompile(`\{(\$[A-Z0-9_.]+(:[^}]*)?|#[A-Z0-9_.]+|[A-Z][A-Z0-9_]*(\.[A-Z0-9_]+)+)\}`)
+
+// References returns the hosts, items, macros and function IDs referenced by
+// the expression, including the macros used in hosts, item keys and strings.
+func (e *Expression) References() References {
+	var refs References
+	seen := make(map[string]bool)
+	add := func(list *[]string, kind, s string) {
+		if !seen[kind+s] {
+			seen[kind+s] = true
+			*list = append(*list, s)
+		}
+	}
+	item := func(host, key string) {
+		add(&refs.Hosts, "host", host)
+		if !seen["item"+host+"/"+key] {
+			seen["item"+host+"/"+key] = true
+			refs.Items = append(refs.Items, Item{Host: host, Key: key})
+		}
+		for _, s := range []string{host, key} {
+			for _, m := range macroRegexp.FindAllString(s, -1) {
+				add(&refs.Macros, "macro", m)
+			}
+		}
+	}
+
+	Walk(e.Root, func(n Node) bool {
+		switch n := n.(type) {
+		case *Macro:
+			add(&refs.Macros, "macro", n.Name)
+		case *FunctionID:
+			add(&refs.FunctionIDs, "function", n.ID)
+		case *Query:
+			item(n.Host, n.Key)
+		case *OldFunction:
+			item(n.Host, n.Key)
+			for _, m := range macroRegexp.FindAllString(strings.Join(n.Params, ","), -1) {
+				add(&refs.Macros, "macro", m)
+			}
+		case *String:
+			for _, m := range macroRegexp.FindAllString(n.Value, -1) {
+				add(&refs.Macros, "macro", m)
+			}
+		case *Period:
+			for _, m := range macroRegexp.FindAllString(n.Value, -1) {
+				add(&refs.Macros, "macro", m)
+			}
+		}
+		return true
+	})
+
+	return refs
+}
+
+// suffixes are the multipliers of number suffixes.
+var suffixes = map[byte]float64{
+	's': 1,
+	'm': 60,
+	'h': 3600,
+	'd': 86400,
+	'w': 7 * 86400,
+	'K': 1 << 10,
+	'M': 1 << 20,
+	'G': 1 << 30,
+	'T': 1 << 40,
+}
+
+func parseNumber(s string) (float64, error) {
+	multiplier := 1.0
+	if s != "" {
+		if m, ok := suffixes[s[len(s)-1]]; ok {
+			multiplier = m
+			s = s[:len(s)-1]
+		}
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid number %q", s)
+	}
+
+	return f * multiplier, nil
+}
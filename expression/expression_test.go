@@ -0,0 +1,238 @@
+package expression_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix/expression"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]string{
+		// new syntax
+		"last(/Linux server/system.cpu.load[all,avg1])>5":                         "last(/Linux server/system.cpu.load[all,avg1])>5",
+		"last(/host/key) > 5 and  nodata(/host/agent.ping, 5m) = 0":               "last(/host/key)>5 and nodata(/host/agent.ping,5m)=0",
+		`last(/host/vfs.fs.size["/var",pfree])<{$LOW_SPACE:"/var"}`:               `last(/host/vfs.fs.size["/var",pfree])<{$LOW_SPACE:"/var"}`,
+		"avg(/host/key,1h:now-1d)>avg(/host/key,#10)*1.5":                         "avg(/host/key,1h:now-1d)>avg(/host/key,#10)*1.5",
+		`count(/host/log,10m,,"error")>0 or not (find(/host/log,,"like","ok")=1)`: `count(/host/log,10m,,"error")>0 or not (find(/host/log,,"like","ok")=1)`,
+		"abs(last(/host/key)-last(/host/key,#2))>1K":                              "abs(last(/host/key)-last(/host/key,#2))>1K",
+		`sum(last_foreach(/*/vfs.fs.size[/,free]?[group="Linux servers"]))<10G`:   `sum(last_foreach(/*/vfs.fs.size[/,free]?[group="Linux servers"]))<10G`,
+		"last(/{HOST.HOST}/net.if.in[{#IFNAME}])>{$MAX}":                          "last(/{HOST.HOST}/net.if.in[{#IFNAME}])>{$MAX}",
+		`last(/host/key)="say \"hi\""`:                                            `last(/host/key)="say \"hi\""`,
+		"{13085}>0 or {13086}<>1":                                                 "{13085}>0 or {13086}<>1",
+		"-1.5e3<-last(/host/key)":                                                 "-1.5e3<-last(/host/key)",
+		// old syntax
+		"{Linux server:system.cpu.load[all,avg1].last()}>5":                         "{Linux server:system.cpu.load[all,avg1].last()}>5",
+		"{host:agent.ping.nodata(5m)}=1 or {host:key.avg(1h, 1d)}>10":               "{host:agent.ping.nodata(5m)}=1 or {host:key.avg(1h,1d)}>10",
+		`{host:log[/var/log/syslog,"a,b"].str("error, fatal")}=1`:                   `{host:log[/var/log/syslog,"a,b"].str("error, fatal")}=1`,
+		"{{HOST.HOST}:vfs.fs.size[{#FSNAME},pfree].last(0)}<{$LOW_SPACE:{#FSNAME}}": "{{HOST.HOST}:vfs.fs.size[{#FSNAME},pfree].last(0)}<{$LOW_SPACE:{#FSNAME}}",
+		"{host:key.last()}#0": "{host:key.last()}<>0",
+	}
+
+	for s, expected := range tests {
+		e, err := expression.Parse(s)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", s, err)
+			continue
+		}
+		if e.String() != expected {
+			t.Errorf("Unexpected formatting of %s: %s", s, e)
+		}
+
+		// formatted expressions parse to the same tree
+		again, err := expression.Parse(e.String())
+		if err != nil || !reflect.DeepEqual(again, e) {
+			t.Errorf("Formatted expression %s does not round-trip: %v", e, err)
+		}
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	e, err := expression.Parse("not last(/h/a)=1 or last(/h/b)+1*2>3 and last(/h/c)<>0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	or, ok := e.Root.(*expression.Binary)
+	if !ok || or.Op != "or" {
+		t.Fatalf("Expected or at the root, got %#v", e.Root)
+	}
+
+	eq, ok := or.X.(*expression.Binary)
+	if !ok || eq.Op != "=" {
+		t.Fatalf("Expected = on the left of or, got %#v", or.X)
+	}
+	if u, ok := eq.X.(*expression.Unary); !ok || u.Op != "not" {
+		t.Errorf("Expected not to bind tighter than =, got %#v", eq.X)
+	}
+
+	and, ok := or.Y.(*expression.Binary)
+	if !ok || and.Op != "and" {
+		t.Fatalf("Expected and on the right of or, got %#v", or.Y)
+	}
+
+	gt := and.X.(*expression.Binary)
+	if gt.Op != ">" || gt.X.(*expression.Binary).Op != "+" || gt.X.(*expression.Binary).Y.(*expression.Binary).Op != "*" {
+		t.Errorf("Unexpected arithmetic precedence: %#v", gt)
+	}
+}
+
+func TestParseSyntax(t *testing.T) {
+	tests := map[string]expression.Syntax{
+		"last(/h/k)>0":     expression.SyntaxNew,
+		"{h:k.last()}>0":   expression.SyntaxOld,
+		"{12345}>0":        expression.SyntaxNew,
+		"abs(-1)>{$LIMIT}": expression.SyntaxNew,
+	}
+
+	for s, syntax := range tests {
+		e, err := expression.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Syntax != syntax {
+			t.Errorf("Expected %s syntax for %s, got %s", syntax, s, e.Syntax)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"last(/h/k)>",
+		"last(/h/k",
+		"(last(/h/k)>0",
+		`last(/h/k)="x`,
+		"last(/h/k[a)>0",
+		"last(/h)>0",
+		"{h:k.last()}>0 and last(/h/k)>0",
+		"{h:k}>0",
+		"{h:k.last(}>0",
+		"5x>0",
+		"last(/h/k)>0 )",
+		"Last(/h/k)>0",
+	} {
+		if _, err := expression.Parse(s); err == nil {
+			t.Errorf("Expected error parsing %q", s)
+		} else if _, ok := err.(*expression.SyntaxError); !ok {
+			t.Errorf("Expected SyntaxError parsing %q, got %v", s, err)
+		}
+	}
+}
+
+func TestReferences(t *testing.T) {
+	e, err := expression.Parse(`last(/{HOST.HOST}/vfs.fs.size[{#FSNAME},pfree])<{$LOW_SPACE:"{#FSNAME}"} and nodata(/web/agent.ping,{$NODATA})=1 and last(/web/agent.ping)=0 or {13085}>0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := expression.References{
+		Hosts: []string{"{HOST.HOST}", "web"},
+		Items: []expression.Item{
+			{Host: "{HOST.HOST}", Key: "vfs.fs.size[{#FSNAME},pfree]"},
+			{Host: "web", Key: "agent.ping"},
+		},
+		Macros:      []string{"{HOST.HOST}", "{#FSNAME}", `{$LOW_SPACE:"{#FSNAME}"}`, "{$NODATA}"},
+		FunctionIDs: []string{"13085"},
+	}
+	if refs := e.References(); !reflect.DeepEqual(refs, expected) {
+		t.Errorf("Unexpected references: %+v", refs)
+	}
+
+	e, err = expression.Parse(`{server:log[/var/log/app.log].str({$PATTERN})}=1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := e.References()
+	if !reflect.DeepEqual(refs.Items, []expression.Item{{Host: "server", Key: "log[/var/log/app.log]"}}) || !reflect.DeepEqual(refs.Macros, []string{"{$PATTERN}"}) {
+		t.Errorf("Unexpected old syntax references: %+v", refs)
+	}
+}
+
+func TestNumberFloat(t *testing.T) {
+	tests := map[string]float64{
+		"5":     5,
+		"0.5":   0.5,
+		"1.5e3": 1500,
+		"5m":    300,
+		"1d":    86400,
+		"2w":    1209600,
+		"1K":    1024,
+		"1G":    1 << 30,
+	}
+
+	for s, expected := range tests {
+		f, err := (&expression.Number{Value: s}).Float()
+		if err != nil || f != expected {
+			t.Errorf("Expected %v for %s, got %v (%v)", expected, s, f, err)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := map[string]string{
+		"{host:key.last()}>5":                                   "last(/host/key)>5",
+		"{host:key.last(0)}>5":                                  "last(/host/key)>5",
+		"{host:key.last(#3)}>5":                                 "last(/host/key,#3)>5",
+		"{host:key.last(#1,1d)}>5":                              "last(/host/key,#1:now-1d)>5",
+		"{host:key.last(5m)}>5":                                 "last(/host/key)>5",
+		"{host:key.last(5m,1d)}>5":                              "last(/host/key,#1:now-1d)>5",
+		"{host:key.strlen(300)}=0":                              "length(last(/host/key))=0",
+		"{host:key.band(1h,12)}=8":                              "bitand(last(/host/key),12)=8",
+		"{host:key.prev()}=0":                                   "last(/host/key,#2)=0",
+		"{host:key.avg(1h,1d)}>{host:key.avg(1h)}":              "avg(/host/key,1h:now-1d)>avg(/host/key,1h)",
+		"{host:key.min(300)}>0":                                 "min(/host/key,300)>0",
+		`{host:log.count(10m,"error")}>0`:                       `count(/host/log,10m,,"error")>0`,
+		"{host:key.count(#10,12,gt)}>5":                         `count(/host/key,#10,"gt",12)>5`,
+		"{host:key.count(10m,6,band,1d)}>0":                     `count(/host/key,10m:now-1d,"bitand",6)>0`,
+		"{host:agent.ping.nodata(5m)}=1":                        "nodata(/host/agent.ping,5m)=1",
+		"{host:key.diff()}=1":                                   "(last(/host/key,#1)<>last(/host/key,#2))=1",
+		"{host:key.abschange()}>10":                             "abs(change(/host/key))>10",
+		"{host:key.delta(1h)}>10":                               "(max(/host/key,1h)-min(/host/key,1h))>10",
+		"{host:key.percentile(1h,,95)}>10":                      "percentile(/host/key,1h,95)>10",
+		"{host:key.strlen()}=0":                                 "length(last(/host/key))=0",
+		`{host:log.str("fatal error")}=1`:                       `find(/host/log,,"like","fatal error")=1`,
+		`{host:log.regexp("^ERR",#5)}=1`:                        `find(/host/log,#5,"regexp","^ERR")=1`,
+		"{host:key.band(,12)}=8":                                "bitand(last(/host/key),12)=8",
+		"{host:key.now()}>0 and {host:key.dayofweek()}<6":       "now()>0 and dayofweek()<6",
+		"{host:eventlog[System].logeventid(^1000$)}=1":          `logeventid(/host/eventlog[System],,"^1000$")=1`,
+		"{host:key.timeleft(1h,,100)}<1h":                       "timeleft(/host/key,1h,100)<1h",
+		"not ({host:key.last()}>{$MAX} or {host:key.last()}#0)": "not (last(/host/key)>{$MAX} or last(/host/key)<>0)",
+		"last(/host/key)>5":                                     "last(/host/key)>5",
+	}
+
+	for s, expected := range tests {
+		e, err := expression.Parse(s)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", s, err)
+			continue
+		}
+
+		converted, err := e.Convert()
+		if err != nil {
+			t.Errorf("Error converting %s: %v", s, err)
+			continue
+		}
+
+		if converted.Syntax != expression.SyntaxNew || converted.String() != expected {
+			t.Errorf("Unexpected conversion of %s: %s", s, converted)
+		}
+
+		// converted expressions are valid in the new syntax
+		if _, err := expression.Parse(converted.String()); err != nil {
+			t.Errorf("Converted expression %s is invalid: %v", converted, err)
+		}
+	}
+
+	for _, s := range []string{"{host:key.unknown()}>0", "{host:key.count(5m,1,foo)}>0"} {
+		e, err := expression.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := e.Convert(); err == nil {
+			t.Errorf("Expected error converting %s", s)
+		} else if _, ok := err.(*expression.ConvertError); !ok {
+			t.Errorf("Expected ConvertError converting %s, got %v", s, err)
+		}
+	}
+}
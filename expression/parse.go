@@ -0,0 +1,598 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SyntaxError is returned when parsing an invalid expression.
+type SyntaxError struct {
+	Expression string
+
+	// Pos is the byte offset of the error in the expression.
+	Pos int
+
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("Invalid trigger expression at position %d: %s", e.Pos, e.Reason)
+}
+
+// levels are the binary operators by increasing precedence.
+var levels = [][]string{
+	{"or"},
+	{"and"},
+	{"=", "<>"},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/"},
+}
+
+// operators are all the binary operators, longest first.
+var operators = []string{"and", "<>", "<=", ">=", "or", "=", "<", ">", "+", "-", "*", "/"}
+
+// Parse parses a trigger expression in the new or old syntax. Both syntaxes
+// cannot be mixed in an expression.
+func Parse(s string) (*Expression, error) {
+	p := &parser{s: s}
+
+	root, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+
+	e := &Expression{Root: root}
+	if p.old {
+		if p.new {
+			return nil, &SyntaxError{Expression: s, Reason: "old and new syntax are mixed"}
+		}
+		e.Syntax = SyntaxOld
+	}
+
+	return e, nil
+}
+
+type parser struct {
+	s   string
+	pos int
+
+	// old and new are set when functions in the old or new syntax are found.
+	old bool
+	new bool
+}
+
+func (p *parser) errorf(format string, a ...interface{}) error {
+	return &SyntaxError{Expression: p.s, Pos: p.pos, Reason: fmt.Sprintf(format, a...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+// word returns true if the keyword is at the current position.
+func (p *parser) word(w string) bool {
+	if !strings.HasPrefix(p.s[p.pos:], w) {
+		return false
+	}
+	end := p.pos + len(w)
+	return end == len(p.s) || !isIdentChar(p.s[end])
+}
+
+// operator returns the binary operator at the current position.
+func (p *parser) operator() string {
+	p.skipSpace()
+	for _, op := range operators {
+		if op == "and" || op == "or" {
+			if p.word(op) {
+				return op
+			}
+		} else if strings.HasPrefix(p.s[p.pos:], op) {
+			return op
+		}
+	}
+
+	// the old syntax also uses # for "not equal"
+	if p.peek() == '#' {
+		return "#"
+	}
+
+	return ""
+}
+
+func (p *parser) parseBinary(level int) (Node, error) {
+	if level == len(levels) {
+		return p.parseUnary()
+	}
+
+	x, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op := p.operator()
+		if op == "" {
+			return x, nil
+		}
+
+		n := len(op)
+		if op == "#" {
+			op = "<>"
+			p.old = true
+		}
+		if !contains(levels[level], op) {
+			return x, nil
+		}
+		p.pos += n
+
+		y, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		x = &Binary{Op: op, X: x, Y: y}
+	}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	p.skipSpace()
+
+	var op string
+	switch {
+	case p.peek() == '-':
+		op = "-"
+	case p.word("not"):
+		op = "not"
+	default:
+		return p.parsePrimary()
+	}
+
+	p.pos += len(op)
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Unary{Op: op, X: x}, nil
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	p.skipSpace()
+
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, p.errorf("unexpected end of expression")
+
+	case c == '(':
+		p.pos++
+		x, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return &Paren{X: x}, nil
+
+	case c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return &String{Value: s}, nil
+
+	case c == '{':
+		return p.parseBrace()
+
+	case c >= '0' && c <= '9' || c == '.':
+		return p.parseNumber()
+
+	case c >= 'a' && c <= 'z':
+		return p.parseFunction()
+	}
+
+	return nil, p.errorf("unexpected %q", c)
+}
+
+func (p *parser) parseNumber() (Node, error) {
+	start := p.pos
+	for p.pos < len(p.s) && (isDigit(p.s[p.pos]) || p.s[p.pos] == '.') {
+		p.pos++
+	}
+
+	// exponent
+	if c := p.peek(); (c == 'e' || c == 'E') && p.pos+1 < len(p.s) {
+		i := p.pos + 1
+		if p.s[i] == '+' || p.s[i] == '-' {
+			i++
+		}
+		if i < len(p.s) && isDigit(p.s[i]) {
+			for p.pos = i; p.pos < len(p.s) && isDigit(p.s[p.pos]); p.pos++ {
+			}
+		}
+	}
+
+	if _, ok := suffixes[p.peek()]; ok {
+		p.pos++
+	}
+
+	if p.pos < len(p.s) && isIdentChar(p.s[p.pos]) {
+		return nil, p.errorf("invalid number suffix %q", p.s[p.pos])
+	}
+
+	n := &Number{Value: p.s[start:p.pos]}
+	if _, err := n.Float(); err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number %q", n.Value)
+	}
+
+	return n, nil
+}
+
+// parseString parses a double quoted string with \" and \\ escapes.
+func (p *parser) parseString() (string, error) {
+	start := p.pos
+	var b strings.Builder
+	for p.pos++; p.pos < len(p.s); p.pos++ {
+		switch c := p.s[p.pos]; c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if p.pos+1 < len(p.s) && (p.s[p.pos+1] == '"' || p.s[p.pos+1] == '\\') {
+				p.pos++
+			}
+			b.WriteByte(p.s[p.pos])
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	p.pos = start
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) parseFunction() (Node, error) {
+	start := p.pos
+	for p.pos < len(p.s) && isIdentChar(p.s[p.pos]) {
+		p.pos++
+	}
+
+	f := &Function{Name: p.s[start:p.pos]}
+	if p.peek() != '(' {
+		p.pos = start
+		return nil, p.errorf("unexpected %q", f.Name)
+	}
+	p.pos++
+
+	p.skipSpace()
+	if p.peek() == ')' {
+		p.pos++
+		return f, nil
+	}
+
+	for {
+		arg, err := p.parseArg(len(f.Args) == 0)
+		if err != nil {
+			return nil, err
+		}
+		f.Args = append(f.Args, arg)
+
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return f, nil
+		default:
+			return nil, p.errorf("missing closing parenthesis of function %s", f.Name)
+		}
+	}
+}
+
+// parseArg parses a function argument: an item query, a period, or an
+// expression. Omitted arguments are nil.
+func (p *parser) parseArg(first bool) (Node, error) {
+	p.skipSpace()
+
+	switch p.peek() {
+	case ',', ')':
+		return nil, nil
+	case '/':
+		if first {
+			return p.parseQuery()
+		}
+	}
+
+	// periods such as #5 or 1h:now/h are not valid expressions
+	end := p.pos
+	for end < len(p.s) && p.s[end] != ',' && p.s[end] != ')' && p.s[end] != '(' && p.s[end] != '"' {
+		end++
+	}
+	if arg := strings.TrimSpace(p.s[p.pos:end]); strings.HasPrefix(arg, "#") || strings.Contains(arg, ":now") {
+		p.pos = end
+		return &Period{Value: arg}, nil
+	}
+
+	return p.parseBinary(0)
+}
+
+func (p *parser) parseQuery() (Node, error) {
+	p.pos++
+	i := strings.IndexByte(p.s[p.pos:], '/')
+	if i < 0 {
+		return nil, p.errorf("missing item key in query")
+	}
+
+	q := &Query{Host: p.s[p.pos : p.pos+i]}
+	p.pos += i + 1
+
+	key, err := p.scanKey()
+	if err != nil {
+		return nil, err
+	}
+	q.Key = key
+
+	if strings.HasPrefix(p.s[p.pos:], "?[") {
+		p.pos++
+		start := p.pos
+		if err := p.skipBrackets(); err != nil {
+			return nil, err
+		}
+		q.Filter = p.s[start+1 : p.pos-1]
+	}
+
+	p.new = true
+	return q, nil
+}
+
+// scanKey scans an item key, such as net.if.in["eth0",bytes].
+func (p *parser) scanKey() (string, error) {
+	start := p.pos
+	for p.pos < len(p.s) && isKeyChar(p.s[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", p.errorf("missing item key")
+	}
+
+	if p.peek() == '[' {
+		if err := p.skipBrackets(); err != nil {
+			return "", err
+		}
+	}
+
+	return p.s[start:p.pos], nil
+}
+
+// skipBrackets skips brackets, with nested brackets and quoted strings.
+func (p *parser) skipBrackets() error {
+	start := p.pos
+	depth := 0
+	for ; p.pos < len(p.s); p.pos++ {
+		switch p.s[p.pos] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		case '"':
+			for p.pos++; p.pos < len(p.s) && p.s[p.pos] != '"'; p.pos++ {
+				if p.s[p.pos] == '\\' {
+					p.pos++
+				}
+			}
+		}
+	}
+
+	p.pos = start
+	return p.errorf("missing closing bracket")
+}
+
+// parseBrace parses a macro, a function ID or a function in the old syntax.
+func (p *parser) parseBrace() (Node, error) {
+	start := p.pos
+	s := p.s[p.pos:]
+
+	switch {
+	case strings.HasPrefix(s, "{$"):
+		end := userMacroEnd(s)
+		if end < 0 {
+			return nil, p.errorf("unterminated user macro")
+		}
+		p.pos += end
+		return &Macro{Name: s[:end]}, nil
+
+	case strings.HasPrefix(s, "{#"):
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return nil, p.errorf("unterminated macro")
+		}
+		p.pos += end + 1
+		return &Macro{Name: s[:end+1]}, nil
+	}
+
+	// function ID or built-in macro
+	i := 1
+	for i < len(s) && (isDigit(s[i]) || s[i] >= 'A' && s[i] <= 'Z' || s[i] == '_' || s[i] == '.') {
+		i++
+	}
+	if i > 1 && i < len(s) && s[i] == '}' {
+		p.pos += i + 1
+		if strings.Trim(s[1:i], "0123456789") == "" {
+			return &FunctionID{ID: s[1:i]}, nil
+		}
+		return &Macro{Name: s[:i+1]}, nil
+	}
+
+	p.pos = start
+	return p.parseOldFunction()
+}
+
+// userMacroEnd returns the length of the user macro at the start of s, or -1.
+func userMacroEnd(s string) int {
+	i := 2
+	for i < len(s) && s[i] != ':' && s[i] != '}' {
+		i++
+	}
+	if i < len(s) && s[i] == ':' {
+		i++
+		if strings.HasPrefix(s[i:], "regex:") {
+			i += len("regex:")
+		}
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i < len(s) && s[i] == '"' {
+			for i++; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+				}
+			}
+			i++
+		}
+	}
+
+	// unquoted contexts may hold macros such as {#FSNAME}
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return -1
+			}
+			i += end
+		case '}':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func (p *parser) parseOldFunction() (Node, error) {
+	p.pos++
+	f := &OldFunction{}
+
+	// host, possibly a macro
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != ':' {
+		switch p.s[p.pos] {
+		case '{':
+			end := strings.IndexByte(p.s[p.pos:], '}')
+			if end < 0 {
+				return nil, p.errorf("unterminated macro")
+			}
+			p.pos += end
+		case '}', '(', ')':
+			return nil, p.errorf("invalid function reference")
+		}
+		p.pos++
+	}
+	if p.pos == len(p.s) || p.pos == start {
+		return nil, p.errorf("invalid function reference")
+	}
+	f.Host = p.s[start:p.pos]
+	p.pos++
+
+	// key and function name
+	start = p.pos
+	key, err := p.scanKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(key, "]") {
+		if p.peek() != '.' {
+			return nil, p.errorf("missing function of item %s", key)
+		}
+		p.pos++
+		nameStart := p.pos
+		for p.pos < len(p.s) && isIdentChar(p.s[p.pos]) {
+			p.pos++
+		}
+		f.Key, f.Name = key, p.s[nameStart:p.pos]
+	} else if i := strings.LastIndexByte(key, '.'); i > 0 {
+		f.Key, f.Name = key[:i], key[i+1:]
+	}
+
+	if f.Name == "" || p.peek() != '(' {
+		p.pos = start
+		return nil, p.errorf("missing function of item %s", key)
+	}
+
+	// parameters, kept as written
+	p.pos++
+	start = p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != ')' {
+		switch p.s[p.pos] {
+		case ',':
+			f.Params = append(f.Params, strings.TrimSpace(p.s[start:p.pos]))
+			start = p.pos + 1
+		case '"':
+			for p.pos++; p.pos < len(p.s) && p.s[p.pos] != '"'; p.pos++ {
+				if p.s[p.pos] == '\\' {
+					p.pos++
+				}
+			}
+		}
+		p.pos++
+	}
+	if p.pos == len(p.s) {
+		return nil, p.errorf("missing closing parenthesis of function %s", f.Name)
+	}
+	if param := strings.TrimSpace(p.s[start:p.pos]); param != "" || len(f.Params) > 0 {
+		f.Params = append(f.Params, param)
+	}
+	p.pos++
+
+	if p.peek() != '}' {
+		return nil, p.errorf("missing closing brace of function %s", f.Name)
+	}
+	p.pos++
+
+	p.old = true
+	return f, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || isDigit(c) || c == '_'
+}
+
+func isKeyChar(c byte) bool {
+	return isIdentChar(c) || c == '.' || c == '-'
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package expression
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+)
+
+// SessionSource is a Source reading item values from the history of a Zabbix
+// server. Items are looked up by host and key once and cached.
+type SessionSource struct {
+	Session *zabbix.Session
+
+	items map[Item]zabbix.Item
+}
+
+// NewSessionSource returns a SessionSource reading values with the given
+// Session.
+func NewSessionSource(session *zabbix.Session) *SessionSource {
+	return &SessionSource{Session: session}
+}
+
+// Values returns the values of an item from the history of the Zabbix server.
+func (s *SessionSource) Values(item Item, from, to time.Time, limit int) ([]Value, error) {
+	it, err := s.item(item)
+	if err != nil {
+		return nil, err
+	}
+
+	params := zabbix.HistoryGetParams{
+		History:  int(it.LastValueType),
+		ItemIDs:  []string{it.ItemID},
+		TimeTill: float64(to.Unix()),
+		GetParameters: zabbix.GetParameters{
+			SortField:   []string{"clock"},
+			SortOrder:   "DESC",
+			ResultLimit: limit,
+		},
+	}
+	if !from.IsZero() {
+		params.TimeFrom = float64(from.Unix() + 1)
+	}
+
+	histories, err := s.Session.GetHistories(params)
+	if err != nil && err != zabbix.ErrNotFound {
+		return nil, err
+	}
+
+	values := make([]Value, 0, len(histories))
+	for _, h := range histories {
+		values = append(values, Value{Clock: h.Timestamp(), Value: h.Value})
+	}
+
+	return values, nil
+}
+
+func (s *SessionSource) item(item Item) (zabbix.Item, error) {
+	if it, ok := s.items[item]; ok {
+		return it, nil
+	}
+
+	items, err := s.Session.GetItems(zabbix.ItemGetParams{
+		Host:     item.Host,
+		WebItems: true,
+		GetParameters: zabbix.GetParameters{
+			Filter:       map[string]interface{}{"key_": item.Key},
+			OutputFields: zabbix.SelectFields{"itemid", "key_", "value_type"},
+		},
+	})
+	if err == zabbix.ErrNotFound {
+		return zabbix.Item{}, fmt.Errorf("Item /%s/%s not found", item.Host, item.Key)
+	}
+	if err != nil {
+		return zabbix.Item{}, err
+	}
+
+	if s.items == nil {
+		s.items = make(map[Item]zabbix.Item)
+	}
+	s.items[item] = items[0]
+
+	return items[0], nil
+}
@@ -3,3 +3,5 @@ module github.com/NexonSU/go-zabbix
 go 1.19
 
 require github.com/hashicorp/go-version v1.6.0
+
+require gopkg.in/yaml.v3 v3.0.1
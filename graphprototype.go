@@ -0,0 +1,163 @@
+package zabbix
+
+import "github.com/NexonSU/go-zabbix/types"
+
+// GraphPrototype represents a Zabbix Graph Prototype returned from the Zabbix
+// API.
+//
+// Zero values of optional fields are omitted when creating or updating a
+// Graph Prototype, so the server defaults apply, or the current values are
+// kept. Enumerated fields such as Status are pointers, as their zero value is
+// valid: nil fields are omitted.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/graphprototype/object
+type GraphPrototype struct {
+	// GraphID is the unique ID of the Graph Prototype.
+	GraphID string `json:"graphid,omitempty"`
+
+	// Name is the name of the Graph Prototype.
+	Name string `json:"name,omitempty"`
+
+	// Width is the width of the graph in pixels.
+	Width int `json:"width,string,omitempty"`
+
+	// Height is the height of the graph in pixels.
+	Height int `json:"height,string,omitempty"`
+
+	// GraphType is the graph layout type.
+	GraphType *types.ZBXInt `json:"graphtype,omitempty"`
+
+	// Discover is 0 if graphs are discovered from the prototype and 1 if not.
+	Discover *types.ZBXInt `json:"discover,omitempty"`
+
+	// TemplateID is the ID of the parent template Graph Prototype.
+	TemplateID string `json:"templateid,omitempty"`
+
+	// UUID is the universal unique identifier of a template Graph Prototype.
+	UUID string `json:"uuid,omitempty"`
+
+	// GraphItems are the items and item prototypes shown in the graph. They
+	// are required when creating a Graph Prototype.
+	GraphItems []GraphItem `json:"gitems,omitempty"`
+
+	// DiscoveryRule is only populated if GraphPrototypeGetParams.
+	// SelectDiscoveryRule is given in the query parameters that returned
+	// this Graph Prototype.
+	DiscoveryRule *DiscoveryRule `json:"discoveryRule,omitempty"`
+}
+
+// GraphItem is an item shown in a graph.
+type GraphItem struct {
+	// GraphItemID is the unique ID of the graph item.
+	GraphItemID string `json:"gitemid,omitempty"`
+
+	// ItemID is the ID of the item or item prototype.
+	ItemID string `json:"itemid"`
+
+	// Color is the hexadecimal color of the line.
+	Color string `json:"color"`
+
+	// CalcFunction is the value of the item that is displayed.
+	CalcFunction int `json:"calc_fnc,string,omitempty"`
+
+	// DrawType is the line style.
+	DrawType int `json:"drawtype,string,omitempty"`
+
+	// SortOrder is the position of the item in the graph legend.
+	SortOrder int `json:"sortorder,string,omitempty"`
+
+	// YAxisSide is 0 for the left and 1 for the right axis.
+	YAxisSide int `json:"yaxisside,string,omitempty"`
+}
+
+// GraphPrototypeGetParams is query params for graphprototype.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/graphprototype/get
+type GraphPrototypeGetParams struct {
+	GetParameters
+
+	// GraphIDs filters search results to Graph Prototypes with the given IDs.
+	GraphIDs []string `json:"graphids,omitempty"`
+
+	// DiscoveryIDs filters search results to Graph Prototypes of the given
+	// Discovery Rules.
+	DiscoveryIDs []string `json:"discoveryids,omitempty"`
+
+	// GroupIDs filters search results to Graph Prototypes of hosts in the
+	// given groups.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// HostIDs filters search results to Graph Prototypes of the given hosts.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// ItemIDs filters search results to Graph Prototypes using the given
+	// item prototypes.
+	ItemIDs []string `json:"itemids,omitempty"`
+
+	// TemplateIDs filters search results to Graph Prototypes of the given
+	// templates.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// Inherited filters search results to Graph Prototypes inherited from a
+	// template.
+	Inherited bool `json:"inherited,omitempty"`
+
+	// Templated filters search results to Graph Prototypes of templates.
+	Templated bool `json:"templated,omitempty"`
+
+	// SelectDiscoveryRule returns the Discovery Rule of each Graph Prototype.
+	SelectDiscoveryRule SelectQuery `json:"selectDiscoveryRule,omitempty"`
+
+	// SelectGraphItems returns the graph items of each Graph Prototype.
+	SelectGraphItems SelectQuery `json:"selectGraphItems,omitempty"`
+
+	// SelectHosts returns the hosts each Graph Prototype belongs to.
+	SelectHosts SelectQuery `json:"selectHosts,omitempty"`
+
+	// SelectItems returns the items and item prototypes used by each Graph
+	// Prototype.
+	SelectItems SelectQuery `json:"selectItems,omitempty"`
+}
+
+// GetGraphPrototypes queries the Zabbix API for Graph Prototypes matching the
+// given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetGraphPrototypes(params GraphPrototypeGetParams) ([]GraphPrototype, error) {
+	prototypes := make([]GraphPrototype, 0)
+	err := c.Get("graphprototype.get", params, &prototypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return prototypes, nil
+}
+
+// CreateGraphPrototypes creates Graph Prototypes.
+// Returns a list of IDs of the created Graph Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/graphprototype/create
+func (c *Session) CreateGraphPrototypes(prototypes ...GraphPrototype) ([]string, error) {
+	return c.GetIDs("graphprototype.create", prototypes, "graphids")
+}
+
+// UpdateGraphPrototypes updates Graph Prototypes.
+// Returns a list of IDs of the updated Graph Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/graphprototype/update
+func (c *Session) UpdateGraphPrototypes(prototypes ...GraphPrototype) ([]string, error) {
+	return c.GetIDs("graphprototype.update", prototypes, "graphids")
+}
+
+// DeleteGraphPrototypes deletes Graph Prototypes.
+// Returns a list of IDs of the deleted Graph Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/graphprototype/delete
+func (c *Session) DeleteGraphPrototypes(graphIDs ...string) ([]string, error) {
+	return c.GetIDs("graphprototype.delete", graphIDs, "graphids")
+}
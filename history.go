@@ -1,37 +1,220 @@
 package zabbix
 
 import (
+	"fmt"
+	"strconv"
 	"time"
+
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// HistoryValueType is the type of the values stored in a History, which
+// matches the value type of the related Item.
+type HistoryValueType int
+
+const (
+	// HistoryValueTypeFloat indicates numeric float values.
+	HistoryValueTypeFloat HistoryValueType = iota
+
+	// HistoryValueTypeCharacter indicates character values.
+	HistoryValueTypeCharacter
+
+	// HistoryValueTypeLog indicates log entry values.
+	HistoryValueTypeLog
+
+	// HistoryValueTypeUnsigned indicates numeric unsigned values.
+	HistoryValueTypeUnsigned
+
+	// HistoryValueTypeText indicates text values.
+	HistoryValueTypeText
+
+	// HistoryValueTypeBinary indicates binary values, encoded in base64.
+	// Available since Zabbix 7.0.
+	HistoryValueTypeBinary
 )
 
+var historyValueTypes = enum{"HistoryValueType", map[int]string{
+	int(HistoryValueTypeFloat):     "float",
+	int(HistoryValueTypeCharacter): "character",
+	int(HistoryValueTypeLog):       "log",
+	int(HistoryValueTypeUnsigned):  "unsigned",
+	int(HistoryValueTypeText):      "text",
+	int(HistoryValueTypeBinary):    "binary",
+}}
+
+// String returns the name of the HistoryValueType, such as float or log.
+func (t HistoryValueType) String() string {
+	return historyValueTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t HistoryValueType) MarshalText() ([]byte, error) {
+	return historyValueTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *HistoryValueType) UnmarshalText(text []byte) error {
+	return historyValueTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t HistoryValueType) MarshalJSON() ([]byte, error) {
+	return historyValueTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *HistoryValueType) UnmarshalJSON(data []byte) error {
+	return historyValueTypes.unmarshalJSON(data, (*int)(t))
+}
+
+// HistoryValueTypeError is returned by the typed History value accessors if
+// the value type of the History does not match the requested type.
+type HistoryValueTypeError struct {
+	ValueType HistoryValueType
+	Requested string
+}
+
+func (e *HistoryValueTypeError) Error() string {
+	return fmt.Sprintf("History value of type %d cannot be read as %s", e.ValueType, e.Requested)
+}
+
 // History represents a Zabbix History returned from the Zabbix API.
 //
 // See: https://www.zabbix.com/documentation/4.0/manual/api/reference/history/object
 type History struct {
 	// ItemID is the ID of the related item.
-	ItemID int `json:"itemid,string"`
+	ItemID types.ZBXInt `json:"itemid"`
 
 	// Value is the received value.
 	// Possible types: 0 - float; 1 - character; 2 - log; 3 - int; 4 - text;
 	Value string `json:"value"`
 
+	// ValueType is the type of Value. It is set by GetHistories from
+	// HistoryGetParams.History and selects the typed accessor that may be used
+	// to read Value.
+	ValueType HistoryValueType `json:"-"`
+
+	// LogTimestamp is the Unix time of the log entry.
+	LogTimestamp types.ZBXInt `json:"timestamp,omitempty"`
+
 	// LogEventID is the Windows event log entry ID.
-	LogEventID int `json:"logeventid,string,omitempty"`
+	LogEventID types.ZBXInt `json:"logeventid,omitempty"`
 
 	// Severity is the Windows event log entry level.
-	Severity int `json:"severity,string,omitempty"`
+	Severity types.ZBXInt `json:"severity,omitempty"`
 
 	// Source is the Windows event log entry source.
 	Source string `json:"source,omitempty"`
 
-	clock       int64 `json:"clock,string"`
-	nanoseconds int64 `json:"ns,string"`
+	// Clock is the Unix time when the value was received.
+	Clock types.ZBXInt `json:"clock"`
+
+	// Nanoseconds is the nanoseconds part of the time when the value was
+	// received.
+	Nanoseconds types.ZBXInt `json:"ns"`
+}
+
+// HistoryLogEntry is a log entry stored in a History of type
+// HistoryValueTypeLog.
+type HistoryLogEntry struct {
+	// Value is the log line.
+	Value string
+
+	// Timestamp is the time of the log entry.
+	Timestamp time.Time
+
+	// Source is the Windows event log entry source.
+	Source string
+
+	// Severity is the Windows event log entry level.
+	Severity int
+
+	// EventID is the Windows event log entry ID.
+	EventID int
 }
 
 // Timestamp returns time.Time depending on the seconds and nanoseconds returned
 // by Zabbix
 func (h *History) Timestamp() time.Time {
-	return time.Unix(h.clock, h.nanoseconds)
+	return time.Unix(int64(h.Clock), int64(h.Nanoseconds))
+}
+
+// Float64 returns the value of a numeric History.
+func (h *History) Float64() (float64, error) {
+	if h.ValueType != HistoryValueTypeFloat && h.ValueType != HistoryValueTypeUnsigned {
+		return 0, &HistoryValueTypeError{h.ValueType, "float64"}
+	}
+
+	return strconv.ParseFloat(h.Value, 64)
+}
+
+// Int64 returns the value of a numeric unsigned History. An error is returned
+// if the value overflows int64.
+func (h *History) Int64() (int64, error) {
+	if h.ValueType != HistoryValueTypeUnsigned {
+		return 0, &HistoryValueTypeError{h.ValueType, "int64"}
+	}
+
+	return strconv.ParseInt(h.Value, 10, 64)
+}
+
+// Uint64 returns the value of a numeric unsigned History.
+func (h *History) Uint64() (uint64, error) {
+	if h.ValueType != HistoryValueTypeUnsigned {
+		return 0, &HistoryValueTypeError{h.ValueType, "uint64"}
+	}
+
+	return strconv.ParseUint(h.Value, 10, 64)
+}
+
+// Text returns the value of a character, text or log History.
+func (h *History) Text() (string, error) {
+	switch h.ValueType {
+	case HistoryValueTypeCharacter, HistoryValueTypeText, HistoryValueTypeLog:
+		return h.Value, nil
+	}
+
+	return "", &HistoryValueTypeError{h.ValueType, "text"}
+}
+
+// Log returns the log entry of a log History.
+func (h *History) Log() (HistoryLogEntry, error) {
+	if h.ValueType != HistoryValueTypeLog {
+		return HistoryLogEntry{}, &HistoryValueTypeError{h.ValueType, "log"}
+	}
+
+	entry := HistoryLogEntry{
+		Value:    h.Value,
+		Source:   h.Source,
+		Severity: int(h.Severity),
+		EventID:  int(h.LogEventID),
+	}
+
+	if h.LogTimestamp != 0 {
+		entry.Timestamp = time.Unix(int64(h.LogTimestamp), 0)
+	}
+
+	return entry, nil
+}
+
+// TypedValue returns the value of the History as float64, uint64, string or
+// HistoryLogEntry according to its ValueType.
+func (h *History) TypedValue() (interface{}, error) {
+	switch h.ValueType {
+	case HistoryValueTypeFloat:
+		return h.Float64()
+	case HistoryValueTypeUnsigned:
+		return h.Uint64()
+	case HistoryValueTypeLog:
+		return h.Log()
+	case HistoryValueTypeCharacter, HistoryValueTypeText:
+		return h.Text()
+	}
+
+	return h.Value, nil
 }
 
 type HistoryGetParams struct {
@@ -73,5 +256,9 @@ func (c *Session) GetHistories(params HistoryGetParams) ([]History, error) {
 		return nil, ErrNotFound
 	}
 
+	for i := range histories {
+		histories[i].ValueType = HistoryValueType(params.History)
+	}
+
 	return histories, nil
 }
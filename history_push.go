@@ -0,0 +1,123 @@
+package zabbix
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HistoryPushValue is a value sent to a trapper or HTTP agent Item by a
+// `history.push` API call. The Item is identified either by ItemID or by
+// Host and Key.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/history/push
+type HistoryPushValue struct {
+	// ItemID is the ID of the Item to send the value to.
+	ItemID string `json:"itemid,omitempty"`
+
+	// Host is the technical name of the Host the Item belongs to, used with
+	// Key if ItemID is not set.
+	Host string `json:"host,omitempty"`
+
+	// Key is the key of the Item, used with Host if ItemID is not set.
+	Key string `json:"key,omitempty"`
+
+	// Value is the value to send.
+	Value string `json:"value"`
+
+	// Clock is the Unix time of the value. The time of the server is used if
+	// not set.
+	Clock int64 `json:"clock,omitempty"`
+
+	// Nanoseconds is the nanoseconds part of the time of the value.
+	Nanoseconds int64 `json:"ns,omitempty"`
+}
+
+// SetTimestamp sets Clock and Nanoseconds from the given time.
+func (v *HistoryPushValue) SetTimestamp(t time.Time) {
+	v.Clock = t.Unix()
+	v.Nanoseconds = int64(t.Nanosecond())
+}
+
+// HistoryPushItemResult is the processing result of a single pushed value.
+type HistoryPushItemResult struct {
+	// ItemID is the ID of the Item the value was sent to. It is empty if the
+	// Item could not be found.
+	ItemID string `json:"itemid,omitempty"`
+
+	// Error is the reason the value was not accepted, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// HistoryPushResult is the response of a `history.push` API call.
+type HistoryPushResult struct {
+	// Response is "success" if the values were processed by the server.
+	Response string `json:"response"`
+
+	// Data contains the processing result of each pushed value, in the order
+	// in which the values were given.
+	Data []HistoryPushItemResult `json:"data"`
+}
+
+// Failed returns the processing results of the values that were not
+// accepted by the server, keyed by the index of the value.
+func (r *HistoryPushResult) Failed() map[int]HistoryPushItemResult {
+	failed := make(map[int]HistoryPushItemResult)
+	for i, result := range r.Data {
+		if result.Error != "" {
+			failed[i] = result
+		}
+	}
+
+	return failed
+}
+
+// Err returns an error describing all values that were not accepted by the
+// server, or nil if all values were accepted.
+func (r *HistoryPushResult) Err() error {
+	errs := make([]string, 0)
+	for i, result := range r.Data {
+		if result.Error != "" {
+			errs = append(errs, fmt.Sprintf("value %d: %s", i, result.Error))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%d of %d values were not accepted: %s", len(errs), len(r.Data), strings.Join(errs, "; "))
+}
+
+// PushHistory sends the given values to trapper or HTTP agent Items.
+//
+// Values may be rejected individually, which is not reported as an error:
+// use HistoryPushResult.Err or HistoryPushResult.Failed to check for partial
+// failures.
+//
+// An UnsupportedVersionError is returned if the connected Zabbix API is older
+// than v7.0.
+// An error is returned if a transport, parsing or API error occurs.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/history/push
+func (c *Session) PushHistory(values ...HistoryPushValue) (*HistoryPushResult, error) {
+	ver, err := c.GetVersion()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve Zabbix API version: %v", err)
+	}
+
+	if ver.Compare(zabbixVersion700) < 0 {
+		return nil, &UnsupportedVersionError{
+			Method:   "history.push",
+			Required: zabbixVersion700.String(),
+			Version:  ver.String(),
+		}
+	}
+
+	var result HistoryPushResult
+	if err := c.Get("history.push", values, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
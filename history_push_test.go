@@ -0,0 +1,61 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestPushHistory(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("history.push", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`{"response":"success","data":[{"itemid":"10600"},{"error":"No permissions to referred object or it does not exist."}]}`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	value := zabbix.HistoryPushValue{ItemID: "10600", Value: "42"}
+	value.SetTimestamp(time.Unix(1683642493, 500))
+
+	result, err := session.PushHistory(
+		value,
+		zabbix.HistoryPushValue{Host: "missing", Key: "trap", Value: "1"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var params []map[string]interface{}
+	if err := json.Unmarshal(server.Calls()[0].Params.(json.RawMessage), &params); err != nil {
+		t.Fatal(err)
+	}
+
+	if params[0]["clock"] != 1683642493.0 || params[0]["ns"] != 500.0 || params[1]["key"] != "trap" {
+		t.Errorf("Unexpected history.push params: %v", params)
+	}
+
+	failed := result.Failed()
+	if len(failed) != 1 || failed[1].Error == "" {
+		t.Errorf("Expected the second value to fail, got %v", failed)
+	}
+
+	if result.Err() == nil {
+		t.Errorf("Expected an error for the partial failure")
+	}
+}
+
+func TestPushHistoryUnsupportedVersion(t *testing.T) {
+	server := test.NewServer(t)
+	session := server.Session(t, "6.4.0")
+
+	_, err := session.PushHistory(zabbix.HistoryPushValue{ItemID: "10600", Value: "42"})
+	if _, ok := err.(*zabbix.UnsupportedVersionError); !ok {
+		t.Fatalf("Expected UnsupportedVersionError but got %v", err)
+	}
+
+	if len(server.Calls()) != 0 {
+		t.Errorf("Expected no API calls on an unsupported version")
+	}
+}
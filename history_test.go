@@ -0,0 +1,130 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+// recorded history.get responses by value type
+var historyPayloads = map[int]string{
+	0: `[{"itemid":"23296","clock":"1351090996","value":"0.085","ns":"563157632"}]`,
+	1: `[{"itemid":"23298","clock":"1351090996","value":"Linux zabbix 6.1.0","ns":"0"}]`,
+	2: `[{"itemid":"23299","clock":"1351090996","timestamp":"1351090990","source":"","severity":"0","value":"Oct 24 15:03:10 zabbix sshd[1234]: Accepted publickey","logeventid":"0","ns":"247"}]`,
+	3: `[{"itemid":"10084","clock":"1351090996","value":"18446744073709551615","ns":"1"}]`,
+	4: `[{"itemid":"23300","clock":"1351090996","value":"multi\nline","ns":"0"}]`,
+}
+
+func getTestHistories(t *testing.T, valueType int) []zabbix.History {
+	server := test.NewServer(t)
+	server.Handle("history.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(historyPayloads[valueType]), nil
+	})
+
+	histories, err := server.Session(t, "7.0.0").GetHistories(zabbix.HistoryGetParams{History: valueType})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return histories
+}
+
+func TestHistoryTimestamp(t *testing.T) {
+	history := getTestHistories(t, 0)[0]
+
+	expected := time.Unix(1351090996, 563157632)
+	if !history.Timestamp().Equal(expected) {
+		t.Errorf("Expected timestamp %v but got %v", expected, history.Timestamp())
+	}
+}
+
+func TestHistoryFloat64(t *testing.T) {
+	history := getTestHistories(t, 0)[0]
+
+	value, err := history.Float64()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != 0.085 {
+		t.Errorf("Expected value 0.085 but got %v", value)
+	}
+
+	if _, err := history.Uint64(); err == nil {
+		t.Errorf("Expected an error reading a float history as uint64")
+	}
+}
+
+func TestHistoryUnsigned(t *testing.T) {
+	history := getTestHistories(t, 3)[0]
+
+	value, err := history.Uint64()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != 18446744073709551615 {
+		t.Errorf("Expected max uint64 but got %v", value)
+	}
+
+	if _, err := history.Int64(); err == nil {
+		t.Errorf("Expected an error reading max uint64 as int64")
+	}
+
+	if typed, err := history.TypedValue(); err != nil || typed != uint64(18446744073709551615) {
+		t.Errorf("Expected typed uint64 value but got %v (%v)", typed, err)
+	}
+}
+
+func TestHistoryText(t *testing.T) {
+	for _, valueType := range []int{1, 4} {
+		history := getTestHistories(t, valueType)[0]
+
+		value, err := history.Text()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if value != history.Value {
+			t.Errorf("Expected value %q but got %q", history.Value, value)
+		}
+
+		if _, err := history.Float64(); err == nil {
+			t.Errorf("Expected an error reading a text history as float64")
+		}
+	}
+}
+
+func TestHistoryLog(t *testing.T) {
+	history := getTestHistories(t, 2)[0]
+
+	entry, err := history.Log()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Value != history.Value {
+		t.Errorf("Expected log value %q but got %q", history.Value, entry.Value)
+	}
+
+	if entry.Timestamp.Unix() != 1351090990 {
+		t.Errorf("Expected log timestamp 1351090990 but got %d", entry.Timestamp.Unix())
+	}
+}
+
+func TestEventTimestamp(t *testing.T) {
+	payload := `{"eventid":"9695","source":"0","object":"0","objectid":"13926","clock":"1347970410","value":"1","acknowledged":"1","ns":"413316245","name":"MySQL is down","severity":"5","r_eventid":"0","suppressed":"0"}`
+
+	var event zabbix.Event
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := time.Unix(1347970410, 413316245)
+	if !event.Timestamp().Equal(expected) {
+		t.Errorf("Expected timestamp %v but got %v", expected, event.Timestamp())
+	}
+}
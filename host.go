@@ -1,6 +1,10 @@
 package zabbix
 
-import "strconv"
+import (
+	"strconv"
+
+	"github.com/NexonSU/go-zabbix/types"
+)
 
 const (
 	// HostSourceDefault indicates that a Host was created in the normal way.
@@ -9,15 +13,6 @@ const (
 	// HostSourceDiscovery indicates that a Host was created by Host discovery.
 	HostSourceDiscovery = 4
 
-	// HostAvailabilityUnknown Unknown availability of host, never has come online
-	HostAvailabilityUnknown = 0
-
-	// HostAvailabilityAvailable Host is available
-	HostAvailabilityAvailable = 1
-
-	// HostAvailabilityUnavailable Host is NOT available
-	HostAvailabilityUnavailable = 2
-
 	// HostInventoryModeDisabled Host inventory in disabled
 	HostInventoryModeDisabled = -1
 
@@ -35,14 +30,102 @@ const (
 
 	// HostTLSConnectCertificate connect with certificate to or from host
 	HostTLSConnectCertificate = 4
+)
 
+// HostStatus is whether a Host is monitored.
+type HostStatus int
+
+const (
 	// HostStatusMonitored Host is monitored
-	HostStatusMonitored = 0
+	HostStatusMonitored HostStatus = 0
 
 	// HostStatusUnmonitored Host is not monitored
-	HostStatusUnmonitored = 1
+	HostStatusUnmonitored HostStatus = 1
 )
 
+// Availability is the availability of a Host or a host interface.
+type Availability int
+
+const (
+	// HostAvailabilityUnknown Unknown availability of host, never has come online
+	HostAvailabilityUnknown Availability = 0
+
+	// HostAvailabilityAvailable Host is available
+	HostAvailabilityAvailable Availability = 1
+
+	// HostAvailabilityUnavailable Host is NOT available
+	HostAvailabilityUnavailable Availability = 2
+)
+
+var (
+	hostStatuses = enum{"HostStatus", map[int]string{
+		int(HostStatusMonitored):   "monitored",
+		int(HostStatusUnmonitored): "unmonitored",
+	}}
+
+	availabilities = enum{"Availability", map[int]string{
+		int(HostAvailabilityUnknown):     "unknown",
+		int(HostAvailabilityAvailable):   "available",
+		int(HostAvailabilityUnavailable): "unavailable",
+	}}
+)
+
+// String returns the name of the HostStatus.
+func (s HostStatus) String() string {
+	return hostStatuses.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s HostStatus) MarshalText() ([]byte, error) {
+	return hostStatuses.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *HostStatus) UnmarshalText(text []byte) error {
+	return hostStatuses.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s HostStatus) MarshalJSON() ([]byte, error) {
+	return hostStatuses.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *HostStatus) UnmarshalJSON(data []byte) error {
+	return hostStatuses.unmarshalJSON(data, (*int)(s))
+}
+
+// String returns the name of the Availability.
+func (a Availability) String() string {
+	return availabilities.format(int(a))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Availability) MarshalText() ([]byte, error) {
+	return availabilities.marshalText(int(a))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (a *Availability) UnmarshalText(text []byte) error {
+	return availabilities.unmarshalText(text, (*int)(a))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (a Availability) MarshalJSON() ([]byte, error) {
+	return availabilities.marshalJSON(int(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (a *Availability) UnmarshalJSON(data []byte) error {
+	return availabilities.unmarshalJSON(data, (*int)(a))
+}
+
 // Host represents a Zabbix Host returned from the Zabbix API.
 //
 // See: https://www.zabbix.com/documentation/2.2/manual/config/hosts
@@ -58,7 +141,7 @@ type Host struct {
 
 	// Source is the origin of the Host and must be one of the HostSource
 	// constants.
-	Source int `json:"flags,string,omitempty"`
+	Source types.ZBXInt `json:"flags,omitempty"`
 
 	// Macros contains all Host Macros assigned to the Host.
 	Macros []HostMacro `json:"macros,omitempty"`
@@ -66,33 +149,41 @@ type Host struct {
 	// Groups contains all Host Groups assigned to the Host.
 	Groups []Hostgroup `json:"groups,omitempty"`
 
+	// HostGroups contains all Host Groups assigned to the Host. Is filled when
+	// SelectHostGroups is used on HostGetParams
+	HostGroups []Hostgroup `json:"hostgroups,omitempty"`
+
+	// ParentTemplates contains all Templates linked to the Host. Is filled when
+	// SelectParentTemplates is used on HostGetParams
+	ParentTemplates []Template `json:"parentTemplates,omitempty"`
+
 	MaintenanceStatus string `json:"maintenance_status"`
 	MaintenanceID     string `json:"maintenanceid"`
 	MaintenanceType   string `json:"maintenance_type"`
 	MaintenanceFrom   string `json:"maintenance_from"`
 
 	// Status of the host
-	Status int `json:"status,string"`
+	Status HostStatus `json:"status"`
 
 	// Availbility of host
 	// *NOTE*: this field was removed in Zabbix 5.4
 	// See: https://support.zabbix.com/browse/ZBXNEXT-6311
-	Available int `json:"available,string,omitempty"`
+	Available Availability `json:"available,omitempty"`
 
 	// Description of host
 	Description string `json:"description"`
 
 	// Inventory mode
-	InventoryMode int `json:"inventory_mode,string"`
+	InventoryMode types.ZBXInt `json:"inventory_mode"`
 
 	// HostID of the proxy managing this host
 	ProxyHostID string `json:"proxy_hostid"`
 
 	// How should we connect to host
-	TLSConnect int `json:"tls_connect,string"`
+	TLSConnect types.ZBXInt `json:"tls_connect"`
 
 	// What type of connections we accept from host
-	TLSAccept int `json:"tls_accept,string"`
+	TLSAccept types.ZBXInt `json:"tls_accept"`
 
 	TLSIssuer      string `json:"tls_issuer"`
 	TLSSubject     string `json:"tls_subject"`
@@ -163,6 +254,10 @@ type HostGetParams struct {
 	// Deprecated: Depcreated since Zabbix 6.4 and removed in Zabbix 7.2
 	SelectGroups SelectQuery `json:"selectGroups,omitempty"`
 
+	// SelectHostGroups causes the Host Groups that each Host belongs to to be
+	// attached in the search results.
+	SelectHostGroups SelectQuery `json:"selectHostGroups,omitempty"`
+
 	// SelectApplications causes the Applications from each Host to be attached
 	// in the search results.
 	SelectApplications SelectQuery `json:"selectApplications,omitempty"`
@@ -4,22 +4,62 @@ import "github.com/NexonSU/go-zabbix/types"
 
 const (
 	// HostInterfaceAvailabilityUnknown Unknown availability of host, never has come online
-	HostInterfaceAvailabilityUnknown = 0
+	HostInterfaceAvailabilityUnknown Availability = 0
 	// HostInterfaceAvailabilityAvailable Host is available
-	HostInterfaceAvailabilityAvailable = 1
+	HostInterfaceAvailabilityAvailable Availability = 1
 	// HostInterfaceAvailabilityUnavailable Host is NOT available
-	HostInterfaceAvailabilityUnavailable = 2
+	HostInterfaceAvailabilityUnavailable Availability = 2
+)
+
+// InterfaceType is the type of a host interface.
+type InterfaceType int
 
+const (
 	// HostInterfaceTypeAgent Host interface type agent
-	HostInterfaceTypeAgent = 1
+	HostInterfaceTypeAgent InterfaceType = 1
 	// HostInterfaceTypeSNMP Host interface type SNMP
-	HostInterfaceTypeSNMP = 2
+	HostInterfaceTypeSNMP InterfaceType = 2
 	// HostInterfaceTypeIPMI Host interface type IPMI
-	HostInterfaceTypeIPMI = 3
+	HostInterfaceTypeIPMI InterfaceType = 3
 	// HostInterfaceTypeJMX Host interface type JMX
-	HostInterfaceTypeJMX = 4
+	HostInterfaceTypeJMX InterfaceType = 4
 )
 
+var interfaceTypes = enum{"InterfaceType", map[int]string{
+	int(HostInterfaceTypeAgent): "agent",
+	int(HostInterfaceTypeSNMP):  "snmp",
+	int(HostInterfaceTypeIPMI):  "ipmi",
+	int(HostInterfaceTypeJMX):   "jmx",
+}}
+
+// String returns the name of the InterfaceType.
+func (t InterfaceType) String() string {
+	return interfaceTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t InterfaceType) MarshalText() ([]byte, error) {
+	return interfaceTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *InterfaceType) UnmarshalText(text []byte) error {
+	return interfaceTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t InterfaceType) MarshalJSON() ([]byte, error) {
+	return interfaceTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *InterfaceType) UnmarshalJSON(data []byte) error {
+	return interfaceTypes.unmarshalJSON(data, (*int)(t))
+}
+
 // HostInterface  This class is designed to work with host interfaces.
 //
 // See https://www.zabbix.com/documentation/current/manual/api/reference/hostinterface/object#host_interface
@@ -28,7 +68,7 @@ type HostInterface struct {
 	InterfaceID string `json:"interfaceid"`
 
 	// (readonly) Availability of host interface.
-	Available int `json:"available,string,omitempty"`
+	Available Availability `json:"available,omitempty"`
 
 	// DNS name used by the interface.
 	DNS string `json:"dns"`
@@ -40,19 +80,19 @@ type HostInterface struct {
 	Error string `json:"error,omitempty"`
 
 	// (readonly) Time when host interface became unavailable.
-	ErrorsFrom *types.ZBXUnixTimestamp `json:"errors_from,string,omitempty"`
+	ErrorsFrom *types.ZBXUnixTimestamp `json:"errors_from,omitempty"`
 
 	// ID of the host the interface belongs to.
 	HostID string `json:"hostid"`
 
 	// Whether the interface is used as default on the host. Only one interface of some type can be set as default on a host.
-	Main types.ZBXBoolean `json:"main,string"`
+	Main types.ZBXBoolean `json:"main"`
 
 	// Interface type.
-	Type int `json:"type,string"`
+	Type InterfaceType `json:"type"`
 
 	// Whether the connection should be made via IP.
-	UseIP types.ZBXBoolean `json:"useip,string"`
+	UseIP types.ZBXBoolean `json:"useip"`
 }
 
 type HostInterfaceGetParams struct {
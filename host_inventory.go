@@ -1,26 +1,11 @@
 package zabbix
 
-import (
-	"bytes"
-	"encoding/json"
-)
+import "github.com/NexonSU/go-zabbix/types"
 
+// HostInventory is the inventory of a Host by field name. PHP returns an empty
+// array for hosts without inventory, which decodes to an empty HostInventory.
 type HostInventory map[string]string
 
-var phpEmptyArray = []byte("[]")
-
 func (hi *HostInventory) UnmarshalJSON(data []byte) error {
-	// fix for PHP maps are turned to array when they are empty
-	if bytes.Equal(data, phpEmptyArray) {
-		return nil
-	}
-
-	var inv map[string]string
-	if err := json.Unmarshal(data, &inv); err != nil {
-		return err
-	}
-
-	*hi = inv
-
-	return nil
+	return (*types.ZBXStringMap)(hi).UnmarshalJSON(data)
 }
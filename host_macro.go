@@ -1,6 +1,77 @@
 package zabbix
 
+import (
+	"fmt"
+
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// MacroType is the type of the value of a user macro.
+type MacroType int
+
+const (
+	// MacroTypeText indicates that the value of a Macro is plain text.
+	MacroTypeText MacroType = 0
+
+	// MacroTypeSecret indicates that the value of a Macro is secret text,
+	// which is never returned by the API.
+	MacroTypeSecret MacroType = 1
+
+	// MacroTypeVault indicates that the value of a Macro is a path to a secret
+	// stored in a vault, such as "secret/zabbix:password".
+	MacroTypeVault MacroType = 2
+)
+
+// MacroRedacted replaces the values of secret macros in redacted macros and
+// debug output.
+const MacroRedacted = "******"
+
+var macroTypes = enum{"MacroType", map[int]string{
+	int(MacroTypeText):   "text",
+	int(MacroTypeSecret): "secret",
+	int(MacroTypeVault):  "vault",
+}}
+
+// String returns the name of the MacroType.
+func (t MacroType) String() string {
+	return macroTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t MacroType) MarshalText() ([]byte, error) {
+	return macroTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *MacroType) UnmarshalText(text []byte) error {
+	return macroTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t MacroType) MarshalJSON() ([]byte, error) {
+	return macroTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *MacroType) UnmarshalJSON(data []byte) error {
+	return macroTypes.unmarshalJSON(data, (*int)(t))
+}
+
+const (
+	// HostMacroAutomaticUser indicates that a Host Macro is managed by a user.
+	HostMacroAutomaticUser = 0
+
+	// HostMacroAutomaticDiscovery indicates that a Host Macro is managed by a
+	// discovery rule.
+	HostMacroAutomaticDiscovery = 1
+)
+
 // HostMacro represents a Zabbix Host Macro returned from the Zabbix API.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/usermacro/object
 type HostMacro struct {
 	// HostMacroID is the unique ID of the Host Macro.
 	HostMacroID string `json:"hostmacroid"`
@@ -11,6 +82,70 @@ type HostMacro struct {
 	// Macro is the name of the Macro (e.g. '{HOST.MACRO}').
 	Macro string `json:"macro"`
 
-	// Value is the value of the Macro.
+	// Value is the value of the Macro. It is empty for secret macros read
+	// from the API, and the path of the secret for vault macros.
 	Value string `json:"value"`
+
+	// Type is the type of the Macro value and must be one of the MacroType
+	// constants. Text macros are sent without type, which is unknown to
+	// Zabbix before 5.0.
+	Type MacroType `json:"type,omitempty"`
+
+	// Description is the description of the Macro.
+	Description string `json:"description,omitempty"`
+
+	// Automatic is whether the Macro is managed by discovery and must be one
+	// of the HostMacroAutomatic constants.
+	Automatic types.ZBXInt `json:"automatic,omitempty"`
+}
+
+// GlobalMacro represents a Zabbix Global Macro returned from the Zabbix API.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/usermacro/object
+type GlobalMacro struct {
+	// GlobalMacroID is the unique ID of the Global Macro.
+	GlobalMacroID string `json:"globalmacroid,omitempty"`
+
+	// Macro is the name of the Macro (e.g. '{$SNMP_COMMUNITY}').
+	Macro string `json:"macro"`
+
+	// Value is the value of the Macro. It is empty for secret macros read
+	// from the API, and the path of the secret for vault macros.
+	Value string `json:"value"`
+
+	// Type is the type of the Macro value and must be one of the MacroType
+	// constants. Text macros are sent without type, which is unknown to
+	// Zabbix before 5.0.
+	Type MacroType `json:"type,omitempty"`
+
+	// Description is the description of the Macro.
+	Description string `json:"description,omitempty"`
+}
+
+// Redacted returns a copy of the Host Macro with its value replaced by
+// MacroRedacted if it is secret, so that it can be logged or stored.
+func (m HostMacro) Redacted() HostMacro {
+	if m.Type == MacroTypeSecret && m.Value != "" {
+		m.Value = MacroRedacted
+	}
+	return m
+}
+
+// String formats the Host Macro with its value redacted if it is secret.
+func (m HostMacro) String() string {
+	return fmt.Sprintf("%s=%s (%s)", m.Macro, m.Redacted().Value, m.Type)
+}
+
+// Redacted returns a copy of the Global Macro with its value replaced by
+// MacroRedacted if it is secret, so that it can be logged or stored.
+func (m GlobalMacro) Redacted() GlobalMacro {
+	if m.Type == MacroTypeSecret && m.Value != "" {
+		m.Value = MacroRedacted
+	}
+	return m
+}
+
+// String formats the Global Macro with its value redacted if it is secret.
+func (m GlobalMacro) String() string {
+	return fmt.Sprintf("%s=%s (%s)", m.Macro, m.Redacted().Value, m.Type)
 }
@@ -0,0 +1,173 @@
+package zabbix
+
+import "github.com/NexonSU/go-zabbix/types"
+
+// HostPrototype represents a Zabbix Host Prototype returned from the Zabbix
+// API.
+//
+// Zero values of optional fields are omitted when creating or updating a
+// Host Prototype, so the server defaults apply, or the current values are
+// kept. Enumerated fields such as Status are pointers, as their zero value is
+// valid: nil fields are omitted.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/hostprototype/object
+type HostPrototype struct {
+	// HostID is the unique ID of the Host Prototype.
+	HostID string `json:"hostid,omitempty"`
+
+	// RuleID is the ID of the Discovery Rule the Host Prototype belongs to.
+	// It is required when creating a Host Prototype.
+	RuleID string `json:"ruleid,omitempty"`
+
+	// Hostname is the technical name of the Host Prototype, which must
+	// contain LLD macros.
+	Hostname string `json:"host,omitempty"`
+
+	// DisplayName is the visible name of the Host Prototype.
+	DisplayName string `json:"name,omitempty"`
+
+	// Status is 0 if the discovered Hosts are monitored and 1 if not.
+	Status *HostStatus `json:"status,omitempty"`
+
+	// Discover is 0 if Hosts are discovered from the prototype and 1 if not.
+	Discover *types.ZBXInt `json:"discover,omitempty"`
+
+	// InventoryMode must be one of the HostInventoryMode constants.
+	InventoryMode int `json:"inventory_mode,string,omitempty"`
+
+	// CustomInterfaces is 1 if the discovered Hosts use the Interfaces of the
+	// prototype instead of the interfaces of the parent Host.
+	CustomInterfaces int `json:"custom_interfaces,string,omitempty"`
+
+	// TemplateID is the ID of the parent template Host Prototype.
+	TemplateID string `json:"templateid,omitempty"`
+
+	// UUID is the universal unique identifier of a template Host Prototype.
+	UUID string `json:"uuid,omitempty"`
+
+	// GroupLinks are the existing host groups the discovered Hosts are added
+	// to. At least one is required when creating a Host Prototype.
+	GroupLinks []HostPrototypeGroupLink `json:"groupLinks,omitempty"`
+
+	// GroupPrototypes are the host groups created for the discovered Hosts.
+	GroupPrototypes []HostPrototypeGroupPrototype `json:"groupPrototypes,omitempty"`
+
+	// Templates are the templates linked to the discovered Hosts.
+	Templates []HostPrototypeTemplate `json:"templates,omitempty"`
+
+	// Macros are the user macros of the discovered Hosts.
+	Macros []HostMacro `json:"macros,omitempty"`
+
+	// Tags are the tags of the discovered Hosts.
+	Tags []TriggerTag `json:"tags,omitempty"`
+
+	// Interfaces are the interfaces of the discovered Hosts if
+	// CustomInterfaces is 1.
+	Interfaces []HostInterface `json:"interfaces,omitempty"`
+
+	// DiscoveryRule is only populated if HostPrototypeGetParams.
+	// SelectDiscoveryRule is given in the query parameters that returned
+	// this Host Prototype.
+	DiscoveryRule *DiscoveryRule `json:"discoveryRule,omitempty"`
+}
+
+// HostPrototypeGroupLink links a Host Prototype to an existing host group.
+type HostPrototypeGroupLink struct {
+	GroupID string `json:"groupid"`
+}
+
+// HostPrototypeGroupPrototype is a host group created by a Host Prototype.
+type HostPrototypeGroupPrototype struct {
+	Name string `json:"name"`
+}
+
+// HostPrototypeTemplate is a template linked by a Host Prototype.
+type HostPrototypeTemplate struct {
+	TemplateID string `json:"templateid"`
+}
+
+// HostPrototypeGetParams is query params for hostprototype.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/hostprototype/get
+type HostPrototypeGetParams struct {
+	GetParameters
+
+	// HostIDs filters search results to Host Prototypes with the given IDs.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// DiscoveryIDs filters search results to Host Prototypes of the given
+	// Discovery Rules.
+	DiscoveryIDs []string `json:"discoveryids,omitempty"`
+
+	// Inherited filters search results to Host Prototypes inherited from a
+	// template.
+	Inherited bool `json:"inherited,omitempty"`
+
+	// SelectDiscoveryRule returns the Discovery Rule of each Host Prototype.
+	SelectDiscoveryRule SelectQuery `json:"selectDiscoveryRule,omitempty"`
+
+	// SelectGroupLinks returns the group links of each Host Prototype.
+	SelectGroupLinks SelectQuery `json:"selectGroupLinks,omitempty"`
+
+	// SelectGroupPrototypes returns the group prototypes of each Host
+	// Prototype.
+	SelectGroupPrototypes SelectQuery `json:"selectGroupPrototypes,omitempty"`
+
+	// SelectInterfaces returns the custom interfaces of each Host Prototype.
+	SelectInterfaces SelectQuery `json:"selectInterfaces,omitempty"`
+
+	// SelectMacros returns the user macros of each Host Prototype.
+	SelectMacros SelectQuery `json:"selectMacros,omitempty"`
+
+	// SelectParentHost returns the host each Host Prototype belongs to.
+	SelectParentHost SelectQuery `json:"selectParentHost,omitempty"`
+
+	// SelectTags returns the tags of each Host Prototype.
+	SelectTags SelectQuery `json:"selectTags,omitempty"`
+
+	// SelectTemplates returns the templates linked to each Host Prototype.
+	SelectTemplates SelectQuery `json:"selectTemplates,omitempty"`
+}
+
+// GetHostPrototypes queries the Zabbix API for Host Prototypes matching the
+// given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetHostPrototypes(params HostPrototypeGetParams) ([]HostPrototype, error) {
+	prototypes := make([]HostPrototype, 0)
+	err := c.Get("hostprototype.get", params, &prototypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return prototypes, nil
+}
+
+// CreateHostPrototypes creates Host Prototypes.
+// Returns a list of IDs of the created Host Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/hostprototype/create
+func (c *Session) CreateHostPrototypes(prototypes ...HostPrototype) ([]string, error) {
+	return c.GetIDs("hostprototype.create", prototypes, "hostids")
+}
+
+// UpdateHostPrototypes updates Host Prototypes.
+// Returns a list of IDs of the updated Host Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/hostprototype/update
+func (c *Session) UpdateHostPrototypes(prototypes ...HostPrototype) ([]string, error) {
+	return c.GetIDs("hostprototype.update", prototypes, "hostids")
+}
+
+// DeleteHostPrototypes deletes Host Prototypes.
+// Returns a list of IDs of the deleted Host Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/hostprototype/delete
+func (c *Session) DeleteHostPrototypes(hostIDs ...string) ([]string, error) {
+	return c.GetIDs("hostprototype.delete", hostIDs, "hostids")
+}
@@ -0,0 +1,560 @@
+// Package apigen generates Go types and API wrappers from a description of
+// Zabbix API objects, as the schema.json file of the api package.
+//
+// The schema describes each object with its fields, get method parameters and
+// methods, and the enumerated types of the fields, for the Zabbix versions it
+// lists. Fields and parameters note the listed versions they were added,
+// deprecated or removed in, so that the objects of each version are described:
+//
+//	{
+//	  "versions": ["6.0", "6.2", "6.4", "7.0"],
+//	  "enums": [{"name": "HostStatus", "values": [{"name": "Monitored", "value": 0}]}],
+//	  "objects": [{
+//	    "name": "Host", "plural": "Hosts", "api": "host", "ids": "hostids",
+//	    "methods": ["get", "create", "update", "delete"],
+//	    "fields": [{"name": "ProxyID", "json": "proxyid", "type": "id", "since": "7.0"}],
+//	    "get": [{"name": "HostIDs", "json": "hostids", "type": "[]id"}]
+//	  }]
+//	}
+//
+// Field types are string, id, integer, boolean, timestamp, duration, delay, map,
+// the name of an enum or of another object, or any of them prefixed with [] for
+// arrays. Get parameters may also be flag or select.
+//
+// Fields are only sent if set. Writable fields whose zero value is valid, such
+// as enums, integers and booleans, are pointers so that it can be sent, as the
+// API keeps the current value of the properties missing from an update.
+package apigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// Schema is the description of the Zabbix API objects.
+type Schema struct {
+	// Package is the name of the generated package.
+	Package string `json:"package"`
+
+	// Versions are the Zabbix versions described, oldest first. The
+	// documentation of the last one is linked to. Fields may be removed in a
+	// later version, as announced by the documentation.
+	Versions []string `json:"versions"`
+
+	Enums   []*Enum   `json:"enums"`
+	Objects []*Object `json:"objects"`
+}
+
+// Enum is an enumerated type.
+type Enum struct {
+	Name   string       `json:"name"`
+	Doc    string       `json:"doc"`
+	Values []*EnumValue `json:"values"`
+}
+
+// EnumValue is a value of an Enum. The constant is named after the Enum and
+// the value, as HostStatusMonitored.
+type EnumValue struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+	Doc   string `json:"doc"`
+	Since string `json:"since"`
+}
+
+// Object is an API object. Objects without API name are only used as the
+// fields of other objects.
+type Object struct {
+	Name string `json:"name"`
+	Doc  string `json:"doc"`
+
+	// Plural is the plural of Name used by the wrappers, as GetHosts.
+	Plural string `json:"plural"`
+
+	// API is the name of the object in the API methods, as host.
+	API string `json:"api"`
+
+	// IDs is the property holding the IDs returned by the create, update and
+	// delete methods, as hostids.
+	IDs string `json:"ids"`
+
+	Methods []string `json:"methods"`
+	Fields  []*Field `json:"fields"`
+	Get     []*Field `json:"get"`
+}
+
+// Field is a field of an Object or a parameter of its get method.
+type Field struct {
+	Name string `json:"name"`
+	JSON string `json:"json"`
+	Type string `json:"type"`
+	Doc  string `json:"doc"`
+
+	// ReadOnly fields are returned by the API but cannot be set.
+	ReadOnly bool `json:"readonly"`
+
+	Since      string `json:"since"`
+	Deprecated string `json:"deprecated"`
+	Removed    string `json:"removed"`
+}
+
+var (
+	identRegexp   = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	versionRegexp = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+)
+
+// Load decodes and validates a schema.
+func Load(b []byte) (*Schema, error) {
+	var s Schema
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&s); err != nil {
+		return nil, fmt.Errorf("Error decoding schema: %v", err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Schema) validate() error {
+	if s.Package == "" {
+		return fmt.Errorf("Schema has no package name")
+	}
+	if len(s.Versions) == 0 {
+		return fmt.Errorf("Schema has no versions")
+	}
+	for i, v := range s.Versions {
+		if !versionRegexp.MatchString(v) {
+			return fmt.Errorf("Invalid version %q", v)
+		}
+		if i > 0 && compareVersions(s.Versions[i-1], v) >= 0 {
+			return fmt.Errorf("Versions are not in increasing order")
+		}
+	}
+
+	names := make(map[string]bool)
+	declare := func(name string) error {
+		if !identRegexp.MatchString(name) {
+			return fmt.Errorf("Invalid type name %q", name)
+		}
+		if names[name] {
+			return fmt.Errorf("Duplicate type name %s", name)
+		}
+		names[name] = true
+		return nil
+	}
+
+	for _, e := range s.Enums {
+		if err := declare(e.Name); err != nil {
+			return err
+		}
+		for _, v := range e.Values {
+			if !identRegexp.MatchString(v.Name) {
+				return fmt.Errorf("Invalid value name %q of %s", v.Name, e.Name)
+			}
+			if v.Since != "" && !s.hasVersion(v.Since) {
+				return fmt.Errorf("Invalid version %q of %s%s", v.Since, e.Name, v.Name)
+			}
+		}
+	}
+	for _, o := range s.Objects {
+		if err := declare(o.Name); err != nil {
+			return err
+		}
+		if o.API != "" && o.Plural == "" {
+			return fmt.Errorf("Object %s has no plural name", o.Name)
+		}
+	}
+
+	for _, o := range s.Objects {
+		for _, m := range o.Methods {
+			switch m {
+			case "get", "create", "update", "delete":
+			default:
+				return fmt.Errorf("Unsupported method %s of %s", m, o.Name)
+			}
+			if m != "get" && o.IDs == "" {
+				return fmt.Errorf("Object %s has no IDs property for its %s method", o.Name, m)
+			}
+		}
+
+		for _, fields := range [][]*Field{o.Fields, o.Get} {
+			for _, f := range fields {
+				if !identRegexp.MatchString(f.Name) || f.JSON == "" {
+					return fmt.Errorf("Invalid field %q of %s", f.Name, o.Name)
+				}
+				for _, v := range []string{f.Since, f.Deprecated} {
+					if v != "" && !s.hasVersion(v) {
+						return fmt.Errorf("Invalid version %q of %s.%s", v, o.Name, f.Name)
+					}
+				}
+				if f.Removed != "" && !s.hasVersion(f.Removed) &&
+					(!versionRegexp.MatchString(f.Removed) || compareVersions(f.Removed, s.Latest()) < 0) {
+					return fmt.Errorf("Invalid version %q of %s.%s", f.Removed, o.Name, f.Name)
+				}
+			}
+		}
+		for _, f := range o.Fields {
+			if _, _, err := s.goType(f.Type, false); err != nil {
+				return fmt.Errorf("Field %s.%s: %v", o.Name, f.Name, err)
+			}
+		}
+		for _, f := range o.Get {
+			if _, _, err := s.goType(f.Type, true); err != nil {
+				return fmt.Errorf("Parameter %s.%s: %v", o.Name, f.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Latest returns the last version described by the schema.
+func (s *Schema) Latest() string {
+	return s.Versions[len(s.Versions)-1]
+}
+
+func (s *Schema) hasVersion(v string) bool {
+	for _, version := range s.Versions {
+		if version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// compareVersions compares two major.minor versions, returning -1, 0 or 1.
+func compareVersions(a, b string) int {
+	var aMajor, aMinor, bMajor, bMinor int
+	fmt.Sscanf(a, "%d.%d", &aMajor, &aMinor)
+	fmt.Sscanf(b, "%d.%d", &bMajor, &bMinor)
+	switch {
+	case aMajor < bMajor || aMajor == bMajor && aMinor < bMinor:
+		return -1
+	case aMajor == bMajor && aMinor == bMinor:
+		return 0
+	}
+	return 1
+}
+
+func (s *Schema) enum(name string) *Enum {
+	for _, e := range s.Enums {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *Schema) object(name string) *Object {
+	for _, o := range s.Objects {
+		if o.Name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// goType returns the Go type of a schema type, and whether the value is
+// encoded as a string in JSON.
+func (s *Schema) goType(typ string, param bool) (string, bool, error) {
+	if strings.HasPrefix(typ, "[]") {
+		elem, _, err := s.goType(typ[2:], param)
+		if err != nil {
+			return "", false, err
+		}
+		if strings.HasPrefix(elem, "*") {
+			elem = elem[1:]
+		}
+		return "[]" + elem, false, nil
+	}
+
+	switch typ {
+	case "string", "id":
+		return "string", false, nil
+	case "integer":
+		if param {
+			return "int", false, nil
+		}
+		// the API returns integers as numbers or strings
+		return "types.ZBXInt", false, nil
+	case "boolean":
+		return "types.ZBXBoolean", false, nil
+	case "timestamp":
+		return "*types.ZBXUnixTimestamp", false, nil
+	case "duration":
+		return "types.ZBXDuration", false, nil
+	case "delay":
+		return "schedule.Delay", false, nil
+	case "map":
+		return "map[string]interface{}", false, nil
+	}
+
+	if param {
+		switch typ {
+		case "flag":
+			return "bool", false, nil
+		case "select":
+			return "zabbix.SelectQuery", false, nil
+		}
+	}
+
+	if s.enum(typ) != nil {
+		return typ, !param, nil
+	}
+	if s.object(typ) != nil {
+		return "*" + typ, false, nil
+	}
+	return "", false, fmt.Errorf("unknown type %q", typ)
+}
+
+// fieldType returns the Go type of a field. Writable object fields are
+// pointers unless their zero value is empty, as strings, arrays and maps.
+func (s *Schema) fieldType(f *Field, param bool) string {
+	typ, _, _ := s.goType(f.Type, param)
+	if param || f.ReadOnly || typ == "string" || strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map") {
+		return typ
+	}
+	return "*" + typ
+}
+
+// tag returns the JSON struct tag of a field. Fields are only sent if set.
+func (s *Schema) tag(f *Field, param bool) string {
+	_, quoted, _ := s.goType(f.Type, param)
+	opts := ""
+	if quoted {
+		opts += ",string"
+	}
+	return fmt.Sprintf("`json:\"%s%s,omitempty\"`", f.JSON, opts)
+}
+
+// docLines returns the doc comment of a field, with the versions it was
+// added, deprecated or removed in.
+func docLines(f *Field) []string {
+	var lines []string
+	if f.Doc != "" {
+		lines = append(lines, wrap(f.Doc)...)
+	}
+
+	var notes []string
+	if f.ReadOnly {
+		notes = append(notes, "Read-only.")
+	}
+	if f.Since != "" {
+		notes = append(notes, "Available since Zabbix "+f.Since+".")
+	}
+	if f.Removed != "" {
+		notes = append(notes, "Removed in Zabbix "+f.Removed+".")
+	}
+	if len(notes) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, strings.Join(notes, " "))
+	}
+
+	if f.Deprecated != "" {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "Deprecated: Deprecated since Zabbix "+f.Deprecated+".")
+	}
+	return lines
+}
+
+// wrap splits a text into lines of at most 76 characters.
+func wrap(text string) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		if line != "" && len(line)+1+len(word) > 76 {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += word
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// snake returns the snake case of a CamelCase name, as not_classified for
+// NotClassified. Acronyms are kept together, as in snmp_trap for SNMPTrap.
+func snake(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prevLower := runes[i-1] >= 'a' && runes[i-1] <= 'z' || runes[i-1] >= '0' && runes[i-1] <= '9'
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			if prevLower || nextLower && runes[i-1] >= 'A' && runes[i-1] <= 'Z' {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteString(strings.ToLower(string(r)))
+	}
+	return b.String()
+}
+
+// Imports returns the packages imported by the generated code, with an empty
+// string separating the standard library from the other packages.
+func (s *Schema) Imports() []string {
+	imports := []string{"github.com/NexonSU/go-zabbix"}
+	if len(s.Enums) > 0 {
+		imports = append([]string{"strconv", ""}, imports...)
+	}
+
+	var usesSchedule, usesTypes bool
+	for _, o := range s.Objects {
+		for _, fields := range [][]*Field{o.Fields, o.Get} {
+			for _, f := range fields {
+				typ, _, _ := s.goType(f.Type, false)
+				usesSchedule = usesSchedule || strings.Contains(typ, "schedule.")
+				usesTypes = usesTypes || strings.Contains(typ, "types.")
+			}
+		}
+	}
+	if usesSchedule {
+		imports = append(imports, "github.com/NexonSU/go-zabbix/schedule")
+	}
+	if usesTypes {
+		imports = append(imports, "github.com/NexonSU/go-zabbix/types")
+	}
+	return imports
+}
+
+// Has returns true if the object has the given method.
+func (o *Object) Has(method string) bool {
+	for _, m := range o.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate returns the formatted Go source of the schema.
+func Generate(s *Schema) ([]byte, error) {
+	funcs := template.FuncMap{
+		"goType": s.fieldType,
+		"tag":    s.tag,
+		"doc":    docLines,
+		"wrap":   wrap,
+		"snake":  snake,
+		"quote":  func(s string) string { return fmt.Sprintf("%q", s) },
+	}
+
+	t, err := template.New("api").Funcs(funcs).Parse(sourceTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, s); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Error formatting generated code: %v\n%s", err, b.Bytes())
+	}
+	return src, nil
+}
+
+const sourceTemplate = `// Code generated by apigen from schema.json. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{range .Imports}}{{if .}}	{{quote .}}{{end}}
+{{end}})
+{{range .Enums}}{{$enum := .}}
+{{range wrap .Doc}}// {{.}}
+{{end}}type {{.Name}} int
+
+const (
+{{range .Values}}{{if .Doc}}{{range wrap .Doc}}	// {{.}}
+{{end}}{{end}}{{if .Since}}	// Available since Zabbix {{.Since}}.
+{{end}}	{{$enum.Name}}{{.Name}} {{$enum.Name}} = {{.Value}}
+{{end}})
+
+// String returns the name of the {{.Name}}.
+func (v {{.Name}}) String() string {
+	switch v {
+{{range .Values}}	case {{$enum.Name}}{{.Name}}:
+		return {{quote (snake .Name)}}
+{{end}}	}
+	return "{{.Name}}(" + strconv.Itoa(int(v)) + ")"
+}
+{{end}}
+{{range .Objects}}{{$object := .}}
+{{range wrap .Doc}}// {{.}}
+{{end}}{{if .API}}//
+// See: https://www.zabbix.com/documentation/{{$.Latest}}/en/manual/api/reference/{{.API}}/object
+{{end}}type {{.Name}} struct {
+{{range $i, $f := .Fields}}{{if $i}}
+{{end}}{{range doc $f}}	//{{if .}} {{.}}{{end}}
+{{end}}	{{$f.Name}} {{goType $f false}} {{tag $f false}}
+{{end}}}
+{{if .Has "get"}}
+// {{.Name}}GetParams are the parameters of {{.API}}.get.
+type {{.Name}}GetParams struct {
+	zabbix.GetParameters
+{{range .Get}}
+{{range doc .}}	//{{if .}} {{.}}{{end}}
+{{end}}	{{.Name}} {{goType . true}} {{tag . true}}
+{{end}}}
+
+// Get{{.Plural}} calls {{.API}}.get with the given parameters.
+//
+// zabbix.ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func Get{{.Plural}}(s *zabbix.Session, params {{.Name}}GetParams) ([]{{.Name}}, error) {
+	result := make([]{{.Name}}, 0)
+	if err := s.Get("{{.API}}.get", params, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, zabbix.ErrNotFound
+	}
+
+	return result, nil
+}
+{{end}}{{if .Has "create"}}
+// Create{{.Plural}} calls {{.API}}.create and returns the IDs of the created
+// objects.
+func Create{{.Plural}}(s *zabbix.Session, objects ...{{.Name}}) ([]string, error) {
+	return s.GetIDs("{{.API}}.create", objects, "{{.IDs}}")
+}
+{{end}}{{if .Has "update"}}
+// Update{{.Plural}} calls {{.API}}.update and returns the IDs of the updated
+// objects.
+func Update{{.Plural}}(s *zabbix.Session, objects ...{{.Name}}) ([]string, error) {
+	return s.GetIDs("{{.API}}.update", objects, "{{.IDs}}")
+}
+{{end}}{{if .Has "delete"}}
+// Delete{{.Plural}} calls {{.API}}.delete and returns the IDs of the deleted
+// objects.
+func Delete{{.Plural}}(s *zabbix.Session, ids ...string) ([]string, error) {
+	return s.GetIDs("{{.API}}.delete", ids, "{{.IDs}}")
+}
+{{end}}{{end}}
+// fields are the fields of the API objects with the versions they were added
+// and removed in.
+var fields = map[string][]field{
+{{range .Objects}}{{if .API}}	{{quote .API}}: {
+{{range .Fields}}		{ {{quote .JSON}}, {{quote .Since}}, {{quote .Removed}} },
+{{end}}	},
+{{end}}{{end}}}
+`
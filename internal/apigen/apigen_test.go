@@ -0,0 +1,98 @@
+package apigen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnake(t *testing.T) {
+	tests := map[string]string{
+		"NotClassified":     "not_classified",
+		"SNMPTrap":          "snmp_trap",
+		"IPMIAgent":         "ipmi_agent",
+		"ZabbixAgentActive": "zabbix_agent_active",
+		"PSK":               "psk",
+		"Monitored":         "monitored",
+	}
+	for name, expected := range tests {
+		if s := snake(name); s != expected {
+			t.Errorf("Expected %s to be %s but got %s", name, expected, s)
+		}
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := map[string]string{
+		`{"objects":[]}`: "no package name",
+		`{"package":"api","versions":["7.0"],"objects":[{"name":"Host","api":"host"}]}`:                                                       "no plural name",
+		`{"package":"api","versions":["7.0"],"objects":[{"name":"Host","plural":"Hosts","api":"host","methods":["create"]}]}`:                 "no IDs property",
+		`{"package":"api","versions":["7.0"],"objects":[{"name":"Host","plural":"Hosts","api":"host","methods":["massadd"]}]}`:                "Unsupported method",
+		`{"package":"api","versions":["7.0"],"objects":[{"name":"Host","fields":[{"name":"Status","json":"status","type":"HostStatus"}]}]}`:   "unknown type",
+		`{"package":"api","versions":["7.0"],"objects":[{"name":"Host","fields":[{"name":"ID","json":"hostid","type":"id","since":"7"}]}]}`:   "Invalid version",
+		`{"package":"api","versions":["7.0"],"objects":[{"name":"Host"},{"name":"Host"}]}`:                                                    "Duplicate type name",
+		`{"package":"api","versions":["7.0"],"objects":[{"name":"Host","fields":[{"name":"ID","json":"hostid","type":"id","since":"6.4"}]}]}`: "Invalid version",
+		`{"package":"api","objects":[]}`:             "no versions",
+		`{"package":"api","versions":["7.0","6.0"]}`: "increasing order",
+		`{"package":"api","unknown":true}`:           "unknown field",
+	}
+	for schema, expected := range tests {
+		_, err := Load([]byte(schema))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error %q loading %s but got %v", expected, schema, err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s, err := Load([]byte(`{
+		"package": "example",
+		"versions": ["6.0", "6.4", "7.0"],
+		"enums": [{"name": "Status", "values": [{"name": "Enabled", "value": 0}, {"name": "Disabled", "value": 1, "since": "6.0"}]}],
+		"objects": [{
+			"name": "Widget", "plural": "Widgets", "api": "widget", "ids": "widgetids", "doc": "Widget is a widget.",
+			"methods": ["get", "delete"],
+			"fields": [
+				{"name": "WidgetID", "json": "widgetid", "type": "id", "readonly": true},
+				{"name": "Status", "json": "status", "type": "Status"},
+				{"name": "Name", "json": "name", "type": "string"},
+				{"name": "Delay", "json": "delay", "type": "delay"},
+				{"name": "Old", "json": "old", "type": "string", "doc": "Old field.", "deprecated": "6.4", "removed": "7.0"},
+				{"name": "Legacy", "json": "legacy", "type": "string", "deprecated": "7.0", "removed": "7.2"}
+			],
+			"get": [{"name": "WidgetIDs", "json": "widgetids", "type": "[]id"}]
+		}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := Generate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"package example",
+		"StatusDisabled Status = 1",
+		`return "disabled"`,
+		"Status *Status `json:\"status,string,omitempty\"`",
+		"Name string `json:\"name,omitempty\"`",
+		"Delay *schedule.Delay `json:\"delay,omitempty\"`",
+		"\"github.com/NexonSU/go-zabbix/schedule\"",
+		"Old string `json:\"old,omitempty\"`",
+		"// Deprecated: Deprecated since Zabbix 6.4.",
+		"// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/widget/object",
+		"func GetWidgets(s *zabbix.Session, params WidgetGetParams) ([]Widget, error) {",
+		"func DeleteWidgets(s *zabbix.Session, ids ...string) ([]string, error) {",
+		`{"old", "", "7.0"},`,
+		`{"legacy", "", "7.2"},`,
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("Expected generated code to contain %q:\n%s", expected, src)
+		}
+	}
+
+	if strings.Contains(string(src), "func CreateWidgets") || strings.Contains(string(src), "go-zabbix/types") {
+		t.Errorf("Unexpected generated code:\n%s", src)
+	}
+}
@@ -0,0 +1,44 @@
+// Command apigen generates the Go source of a schema of Zabbix API objects.
+//
+// Usage:
+//
+//	apigen -schema schema.json -out zz_generated.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/NexonSU/go-zabbix/internal/apigen"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "schema.json", "path of the schema")
+	outPath := flag.String("out", "zz_generated.go", "path of the generated Go file")
+	flag.Parse()
+
+	if err := run(*schemaPath, *outPath); err != nil {
+		fmt.Fprintf(os.Stderr, "apigen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, outPath string) error {
+	b, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+
+	schema, err := apigen.Load(b)
+	if err != nil {
+		return err
+	}
+
+	src, err := apigen.Generate(schema)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outPath, src, 0o644)
+}
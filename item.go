@@ -1,5 +1,10 @@
 package zabbix
 
+import (
+	"github.com/NexonSU/go-zabbix/schedule"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
 // Item represents a Zabbix Item returned from the Zabbix API.
 //
 // See: https://www.zabbix.com/documentation/4.0/manual/api/reference/item/object
@@ -22,15 +27,24 @@ type Item struct {
 	// ItemDescr is the description of the Item.
 	ItemDescr string `json:"description,omitempty"`
 
+	// Delay is the update interval of the Item.
+	Delay schedule.Delay `json:"delay"`
+
+	// History is the history storage period of the Item.
+	History types.ZBXDuration `json:"history"`
+
+	// Trends is the trends storage period of the Item.
+	Trends types.ZBXDuration `json:"trends"`
+
 	// LastClock is the last Item epoh time.
-	LastClock int `json:"lastclock,string,omitempty"`
+	LastClock types.ZBXInt `json:"lastclock,omitempty"`
 
 	// LastValue is the last value of the Item.
 	LastValue string `json:"lastvalue,omitempty"`
 
 	// LastValueType is the type of LastValue
 	// 0 - float; 1 - text; 3 - int;
-	LastValueType int `json:"value_type,string"`
+	LastValueType types.ZBXInt `json:"value_type"`
 }
 
 type ItemTagFilter struct {
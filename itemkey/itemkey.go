@@ -0,0 +1,206 @@
+// Package itemkey parses, builds and validates Zabbix item keys, such as:
+//
+//	agent.ping
+//	net.if.in["eth0",bytes]
+//	vfs.fs.size[/,pfree]
+//	icmpping[,,,,[10.0.0.1,10.0.0.2]]
+//
+// Keys are validated the way the Zabbix server does, so that invalid keys are
+// detected before creating Items or sending values to trapper Items.
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/config/items/item/key
+package itemkey
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxLength is the maximum length in characters of an item key.
+const MaxLength = 2048
+
+// Key is a parsed item key.
+type Key struct {
+	// Name is the key without its parameters, such as net.if.in.
+	Name string
+
+	// Params are the parameters of the key. A key without brackets has no
+	// parameters while a key with empty brackets, such as key[], has a single
+	// empty parameter.
+	Params []Param
+}
+
+// Param is a parameter of an item key.
+type Param struct {
+	// Value is the unquoted value of the parameter.
+	Value string
+
+	// Quoted is true if the parameter is enclosed in double quotes. Values
+	// which cannot be written unquoted are quoted regardless.
+	Quoted bool
+
+	// Array holds the elements of an array parameter, such as [a,b]. Arrays
+	// always have at least one element and cannot be nested.
+	Array []Param
+}
+
+// New returns a Key with the given name and parameters.
+func New(name string, params ...string) *Key {
+	k := &Key{Name: name}
+	for _, p := range params {
+		k.Params = append(k.Params, Param{Value: p})
+	}
+	return k
+}
+
+// Array returns an array parameter with the given elements.
+func Array(values ...string) Param {
+	if len(values) == 0 {
+		values = []string{""}
+	}
+	p := Param{Array: make([]Param, len(values))}
+	for i, v := range values {
+		p.Array[i] = Param{Value: v}
+	}
+	return p
+}
+
+// IsArray returns true if the parameter is an array.
+func (p Param) IsArray() bool {
+	return p.Array != nil
+}
+
+// Param returns the value of the parameter at index i, or an empty string if
+// there is no such parameter. The elements of arrays are joined by commas.
+func (k *Key) Param(i int) string {
+	if i < 0 || i >= len(k.Params) {
+		return ""
+	}
+
+	p := k.Params[i]
+	if !p.IsArray() {
+		return p.Value
+	}
+
+	values := make([]string, len(p.Array))
+	for i, e := range p.Array {
+		values[i] = e.Value
+	}
+	return strings.Join(values, ",")
+}
+
+// String returns the item key, quoting the parameters which require it.
+// Use Validate to make sure the result is a valid key.
+func (k *Key) String() string {
+	if len(k.Params) == 0 {
+		return k.Name
+	}
+
+	var b strings.Builder
+	b.WriteString(k.Name)
+	b.WriteByte('[')
+	writeParams(&b, k.Params)
+	b.WriteByte(']')
+	return b.String()
+}
+
+func writeParams(b *strings.Builder, params []Param) {
+	for i, p := range params {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		if p.IsArray() {
+			b.WriteByte('[')
+			writeParams(b, p.Array)
+			b.WriteByte(']')
+			continue
+		}
+		if p.Quoted || needsQuotes(p.Value) {
+			b.WriteString(Quote(p.Value))
+			continue
+		}
+		b.WriteString(p.Value)
+	}
+}
+
+// needsQuotes returns true if a parameter value cannot be written unquoted.
+func needsQuotes(s string) bool {
+	if s == "" {
+		return false
+	}
+	switch s[0] {
+	case ' ', '"', '[':
+		return true
+	}
+	return strings.ContainsAny(s, ",]")
+}
+
+// Quote returns a parameter value enclosed in double quotes, with double
+// quotes escaped by a backslash.
+func Quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// Validate returns an error if the Key cannot be written as a valid item
+// key.
+func (k *Key) Validate() error {
+	if k.Name == "" {
+		return &Error{Key: k.String(), Reason: "missing key name"}
+	}
+	for i := 0; i < len(k.Name); i++ {
+		if !isNameChar(k.Name[i]) {
+			return &Error{Key: k.String(), Pos: i, Reason: fmt.Sprintf("invalid character %q in key name", k.Name[i])}
+		}
+	}
+
+	if err := k.validateParams(k.Params, false); err != nil {
+		return err
+	}
+
+	if n := utf8.RuneCountInString(k.String()); n > MaxLength {
+		return &Error{Key: k.String(), Reason: fmt.Sprintf("key of %d characters exceeds the limit of %d", n, MaxLength)}
+	}
+
+	return nil
+}
+
+func (k *Key) validateParams(params []Param, inArray bool) error {
+	for _, p := range params {
+		if p.IsArray() {
+			if inArray {
+				return &Error{Key: k.String(), Reason: "nested arrays are not supported"}
+			}
+			if len(p.Array) == 0 {
+				return &Error{Key: k.String(), Reason: "empty array"}
+			}
+			if err := k.validateParams(p.Array, true); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// a trailing backslash would escape the closing quote
+		if (p.Quoted || needsQuotes(p.Value)) && strings.HasSuffix(p.Value, `\`) {
+			return &Error{Key: k.String(), Reason: fmt.Sprintf("quoted parameter %q cannot end with a backslash", p.Value)}
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the given string is not a valid item key.
+func Validate(key string) error {
+	if _, err := Parse(key); err != nil {
+		return err
+	}
+
+	if n := utf8.RuneCountInString(key); n > MaxLength {
+		return &Error{Key: key, Reason: fmt.Sprintf("key of %d characters exceeds the limit of %d", n, MaxLength)}
+	}
+
+	return nil
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-'
+}
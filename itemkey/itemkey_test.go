@@ -0,0 +1,145 @@
+package itemkey_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix/itemkey"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]*itemkey.Key{
+		"agent.ping": {Name: "agent.ping"},
+		"key[]":      {Name: "key", Params: []itemkey.Param{{}}},
+		`net.if.in["eth0",bytes]`: {Name: "net.if.in", Params: []itemkey.Param{
+			{Value: "eth0", Quoted: true},
+			{Value: "bytes"},
+		}},
+		"vfs.fs.size[/,pfree]": {Name: "vfs.fs.size", Params: []itemkey.Param{{Value: "/"}, {Value: "pfree"}}},
+		"key[a, b ,  \"c\" ]": {Name: "key", Params: []itemkey.Param{
+			{Value: "a"},
+			{Value: "b "},
+			{Value: "c", Quoted: true},
+		}},
+		`log[/var/log/app.log,"error: \"disk\", [full]",,,skip]`: {Name: "log", Params: []itemkey.Param{
+			{Value: "/var/log/app.log"},
+			{Value: `error: "disk", [full]`, Quoted: true},
+			{}, {}, {Value: "skip"},
+		}},
+		`icmpping[,,,,[10.0.0.1, "a,b"],x]`: {Name: "icmpping", Params: []itemkey.Param{
+			{}, {}, {}, {},
+			{Array: []itemkey.Param{{Value: "10.0.0.1"}, {Value: "a,b", Quoted: true}}},
+			{Value: "x"},
+		}},
+		"key[[]]":                {Name: "key", Params: []itemkey.Param{{Array: []itemkey.Param{{}}}}},
+		`key["C:\temp\"x"]`:      {Name: "key", Params: []itemkey.Param{{Value: `C:\temp"x`, Quoted: true}}},
+		"key[{$MACRO},{#LLD}]":   {Name: "key", Params: []itemkey.Param{{Value: "{$MACRO}"}, {Value: "{#LLD}"}}},
+		"web.page.get[a[b,c]":    {Name: "web.page.get", Params: []itemkey.Param{{Value: "a[b"}, {Value: "c"}}},
+		"custom-key_1.test[x\"]": {Name: "custom-key_1.test", Params: []itemkey.Param{{Value: `x"`}}},
+	}
+
+	for s, expected := range tests {
+		k, err := itemkey.Parse(s)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(k, expected) {
+			t.Errorf("Unexpected key for %s: %#v", s, k)
+		}
+
+		// formatted keys parse to the same key
+		again, err := itemkey.Parse(k.String())
+		if err != nil || !reflect.DeepEqual(again, k) {
+			t.Errorf("Key %s does not round-trip: %s (%v)", s, k, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"[a]",
+		"key name",
+		"key[a",
+		"key[a]b",
+		"key[a][b]",
+		`key["a]`,
+		`key["a"b]`,
+		"key[[a,[b]]]",
+		"key[[a]b]",
+		"key(a)",
+		"ключ",
+		`key["C:\temp\"]`,
+	} {
+		if _, err := itemkey.Parse(s); err == nil {
+			t.Errorf("Expected error parsing %q", s)
+		} else if _, ok := err.(*itemkey.Error); !ok {
+			t.Errorf("Expected Error parsing %q, got %v", s, err)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := map[string]*itemkey.Key{
+		"agent.ping":                      itemkey.New("agent.ping"),
+		"key[]":                           itemkey.New("key", ""),
+		`net.if.in[eth0,bytes]`:           itemkey.New("net.if.in", "eth0", "bytes"),
+		`log[/var/log/app.log,"a, b"]`:    itemkey.New("log", "/var/log/app.log", "a, b"),
+		`key["[a]","x]"," a","\"q\"",b"]`: itemkey.New("key", "[a]", "x]", " a", `"q"`, `b"`),
+		`key[a,[b,"c,d"],]`: {Name: "key", Params: []itemkey.Param{
+			{Value: "a"},
+			itemkey.Array("b", "c,d"),
+			{},
+		}},
+	}
+
+	for expected, k := range tests {
+		if s := k.String(); s != expected {
+			t.Errorf("Expected %s, got %s", expected, s)
+		}
+		if err := k.Validate(); err != nil {
+			t.Errorf("Unexpected error validating %s: %v", k, err)
+		}
+		if err := itemkey.Validate(k.String()); err != nil {
+			t.Errorf("Built key %s is invalid: %v", k, err)
+		}
+	}
+}
+
+func TestParam(t *testing.T) {
+	k, err := itemkey.Parse(`key[a,"b",[c,d]]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []string{"a", "b", "c,d", ""} {
+		if p := k.Param(i); p != expected {
+			t.Errorf("Expected %q for parameter %d, got %q", expected, i, p)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, k := range []*itemkey.Key{
+		itemkey.New(""),
+		itemkey.New("key name"),
+		itemkey.New("key", `a,b\`),
+		itemkey.New("key", strings.Repeat("x", itemkey.MaxLength)),
+		{Name: "key", Params: []itemkey.Param{{Array: []itemkey.Param{itemkey.Array("a")}}}},
+		{Name: "key", Params: []itemkey.Param{{Array: []itemkey.Param{}}}},
+	} {
+		if err := k.Validate(); err == nil {
+			t.Errorf("Expected error validating %#v", k)
+		}
+	}
+
+	if err := itemkey.Validate("key[" + strings.Repeat("x", itemkey.MaxLength) + "]"); err == nil {
+		t.Errorf("Expected error validating a long key")
+	}
+
+	// unquoted parameters may end with a backslash
+	if err := itemkey.New("key", `C:\temp\`).Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package itemkey
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is returned when an item key is invalid.
+type Error struct {
+	Key string
+
+	// Pos is the byte offset of the error in the key.
+	Pos int
+
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Invalid item key %q at position %d: %s", e.Key, e.Pos, e.Reason)
+}
+
+// Parse parses an item key. Spaces before parameters and after quoted
+// parameters are ignored, while spaces after unquoted parameters are part of
+// their value.
+func Parse(s string) (*Key, error) {
+	p := &parser{s: s}
+
+	for p.pos < len(s) && isNameChar(s[p.pos]) {
+		p.pos++
+	}
+	if p.pos == 0 {
+		return nil, p.errorf("missing key name")
+	}
+
+	k := &Key{Name: s[:p.pos]}
+	if p.pos == len(s) {
+		return k, nil
+	}
+	if s[p.pos] != '[' {
+		return nil, p.errorf("invalid character %q in key name", s[p.pos])
+	}
+
+	p.pos++
+	params, err := p.parseParams(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(s) {
+		return nil, p.errorf("unexpected %q after parameters", s[p.pos:])
+	}
+
+	k.Params = params
+	return k, nil
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, a ...interface{}) error {
+	return &Error{Key: p.s, Pos: p.pos, Reason: fmt.Sprintf(format, a...)}
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// parseParams parses comma separated parameters up to and including the
+// closing bracket.
+func (p *parser) parseParams(inArray bool) ([]Param, error) {
+	var params []Param
+	for {
+		param, err := p.parseParam(inArray)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+
+		if p.pos == len(p.s) {
+			return nil, p.errorf("missing closing bracket")
+		}
+
+		c := p.s[p.pos]
+		p.pos++
+		if c == ']' {
+			return params, nil
+		}
+	}
+}
+
+// parseParam parses a parameter, leaving the position on the comma or the
+// closing bracket following it.
+func (p *parser) parseParam(inArray bool) (Param, error) {
+	p.skipSpace()
+	if p.pos == len(p.s) {
+		return Param{}, p.errorf("missing closing bracket")
+	}
+
+	var param Param
+	switch p.s[p.pos] {
+	case '"':
+		value, err := p.scanQuoted()
+		if err != nil {
+			return param, err
+		}
+		param = Param{Value: value, Quoted: true}
+
+	case '[':
+		if inArray {
+			return param, p.errorf("nested arrays are not supported")
+		}
+		p.pos++
+		array, err := p.parseParams(true)
+		if err != nil {
+			return param, err
+		}
+		param = Param{Array: array}
+
+	default:
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != ']' {
+			p.pos++
+		}
+		return Param{Value: p.s[start:p.pos]}, nil
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != ']' {
+		return param, p.errorf("unexpected %q after parameter", p.s[p.pos])
+	}
+	return param, nil
+}
+
+// scanQuoted scans a quoted parameter, where only double quotes are escaped.
+func (p *parser) scanQuoted() (string, error) {
+	start := p.pos
+	for p.pos++; p.pos < len(p.s); p.pos++ {
+		switch {
+		case p.s[p.pos] == '\\' && p.pos+1 < len(p.s) && p.s[p.pos+1] == '"':
+			p.pos++
+		case p.s[p.pos] == '"':
+			p.pos++
+			return strings.ReplaceAll(p.s[start+1:p.pos-1], `\"`, `"`), nil
+		}
+	}
+
+	p.pos = start
+	return "", p.errorf("missing closing quote")
+}
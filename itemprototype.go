@@ -0,0 +1,203 @@
+package zabbix
+
+import (
+	"github.com/NexonSU/go-zabbix/schedule"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// ItemPrototype represents a Zabbix Item Prototype returned from the Zabbix
+// API.
+//
+// Zero values of optional fields are omitted when creating or updating an
+// Item Prototype, so the server defaults apply, or the current values are
+// kept. Enumerated fields such as Status are pointers, as their zero value is
+// valid: nil fields are omitted.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/itemprototype/object
+type ItemPrototype struct {
+	// ItemID is the unique ID of the Item Prototype.
+	ItemID string `json:"itemid,omitempty"`
+
+	// RuleID is the ID of the Discovery Rule the Item Prototype belongs to.
+	// It is required when creating an Item Prototype.
+	RuleID string `json:"ruleid,omitempty"`
+
+	// HostID is the ID of the Host or Template the Item Prototype belongs to.
+	HostID string `json:"hostid,omitempty"`
+
+	// InterfaceID is the ID of the host interface used by the Item
+	// Prototype.
+	InterfaceID string `json:"interfaceid,omitempty"`
+
+	// TemplateID is the ID of the parent template Item Prototype.
+	TemplateID string `json:"templateid,omitempty"`
+
+	// Key is the item key, which must contain LLD macros.
+	Key string `json:"key_,omitempty"`
+
+	// Name is the name of the Item Prototype.
+	Name string `json:"name,omitempty"`
+
+	// Type is the item type of the Item Prototype.
+	Type *types.ZBXInt `json:"type,omitempty"`
+
+	// ValueType is the type of the values of the discovered Items.
+	ValueType *types.ZBXInt `json:"value_type,omitempty"`
+
+	// Delay is the update interval of the Item Prototype.
+	Delay *schedule.Delay `json:"delay,omitempty"`
+
+	// History is the history storage period.
+	History *types.ZBXDuration `json:"history,omitempty"`
+
+	// Trends is the trends storage period.
+	Trends *types.ZBXDuration `json:"trends,omitempty"`
+
+	// Units are the value units.
+	Units string `json:"units,omitempty"`
+
+	// Description is the description of the Item Prototype.
+	Description string `json:"description,omitempty"`
+
+	// Status is 0 if the Item Prototype is enabled and 1 if disabled.
+	Status *types.ZBXInt `json:"status,omitempty"`
+
+	// Discover is 0 if Items are discovered from the prototype and 1 if not.
+	Discover *types.ZBXInt `json:"discover,omitempty"`
+
+	// MasterItemID is the ID of the master Item of a dependent Item
+	// Prototype.
+	MasterItemID string `json:"master_itemid,omitempty"`
+
+	// Params are the additional parameters of the item type.
+	Params string `json:"params,omitempty"`
+
+	// Timeout is the timeout of the item check.
+	Timeout string `json:"timeout,omitempty"`
+
+	// ValueMapID is the ID of the associated value map.
+	ValueMapID string `json:"valuemapid,omitempty"`
+
+	// UUID is the universal unique identifier of a template Item Prototype.
+	UUID string `json:"uuid,omitempty"`
+
+	// Tags are the Item Prototype tags.
+	Tags []ItemTag `json:"tags,omitempty"`
+
+	// Preprocessing are the preprocessing steps of the Item Prototype.
+	Preprocessing []ItemPreprocessing `json:"preprocessing,omitempty"`
+
+	// DiscoveryRule is only populated if ItemPrototypeGetParams.
+	// SelectDiscoveryRule is given in the query parameters that returned
+	// this Item Prototype.
+	DiscoveryRule *DiscoveryRule `json:"discoveryRule,omitempty"`
+}
+
+// ItemTag is item tag
+type ItemTag struct {
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
+}
+
+// ItemPrototypeGetParams is query params for itemprototype.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/itemprototype/get
+type ItemPrototypeGetParams struct {
+	GetParameters
+
+	// ItemIDs filters search results to Item Prototypes with the given IDs.
+	ItemIDs []string `json:"itemids,omitempty"`
+
+	// DiscoveryIDs filters search results to Item Prototypes of the given
+	// Discovery Rules.
+	DiscoveryIDs []string `json:"discoveryids,omitempty"`
+
+	// GraphIDs filters search results to Item Prototypes used in the given
+	// graph prototypes.
+	GraphIDs []string `json:"graphids,omitempty"`
+
+	// HostIDs filters search results to Item Prototypes of the given hosts.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// TemplateIDs filters search results to Item Prototypes of the given
+	// templates.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// TriggerIDs filters search results to Item Prototypes used in the given
+	// trigger prototypes.
+	TriggerIDs []string `json:"triggerids,omitempty"`
+
+	// Inherited filters search results to Item Prototypes inherited from a
+	// template.
+	Inherited bool `json:"inherited,omitempty"`
+
+	// Templated filters search results to Item Prototypes of templates.
+	Templated bool `json:"templated,omitempty"`
+
+	// Monitored filters search results to enabled Item Prototypes of
+	// monitored hosts.
+	Monitored bool `json:"monitored,omitempty"`
+
+	// SelectDiscoveryRule returns the Discovery Rule of each Item Prototype.
+	SelectDiscoveryRule SelectQuery `json:"selectDiscoveryRule,omitempty"`
+
+	// SelectHosts returns the host the Item Prototype belongs to.
+	SelectHosts SelectQuery `json:"selectHosts,omitempty"`
+
+	// SelectTags returns the tags of each Item Prototype.
+	SelectTags SelectQuery `json:"selectTags,omitempty"`
+
+	// SelectPreprocessing returns the preprocessing steps of each Item
+	// Prototype.
+	SelectPreprocessing SelectQuery `json:"selectPreprocessing,omitempty"`
+
+	// SelectTriggers returns the trigger prototypes using each Item
+	// Prototype.
+	SelectTriggers SelectQuery `json:"selectTriggers,omitempty"`
+
+	// SelectGraphs returns the graph prototypes using each Item Prototype.
+	SelectGraphs SelectQuery `json:"selectGraphs,omitempty"`
+}
+
+// GetItemPrototypes queries the Zabbix API for Item Prototypes matching the
+// given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetItemPrototypes(params ItemPrototypeGetParams) ([]ItemPrototype, error) {
+	prototypes := make([]ItemPrototype, 0)
+	err := c.Get("itemprototype.get", params, &prototypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return prototypes, nil
+}
+
+// CreateItemPrototypes creates Item Prototypes.
+// Returns a list of IDs of the created Item Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/itemprototype/create
+func (c *Session) CreateItemPrototypes(prototypes ...ItemPrototype) ([]string, error) {
+	return c.GetIDs("itemprototype.create", prototypes, "itemids")
+}
+
+// UpdateItemPrototypes updates Item Prototypes.
+// Returns a list of IDs of the updated Item Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/itemprototype/update
+func (c *Session) UpdateItemPrototypes(prototypes ...ItemPrototype) ([]string, error) {
+	return c.GetIDs("itemprototype.update", prototypes, "itemids")
+}
+
+// DeleteItemPrototypes deletes Item Prototypes.
+// Returns a list of IDs of the deleted Item Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/itemprototype/delete
+func (c *Session) DeleteItemPrototypes(itemIDs ...string) ([]string, error) {
+	return c.GetIDs("itemprototype.delete", itemIDs, "prototypeids")
+}
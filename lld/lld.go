@@ -0,0 +1,221 @@
+// Package lld builds low-level discovery payloads, the JSON values sent to
+// discovery rules through trapper Items:
+//
+//	{"data":[{"{#FSNAME}":"/","{#FSTYPE}":"ext4"}]}
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/discovery/low_level_discovery
+package lld
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/sender"
+)
+
+// DefaultMaxSize is the default size limit of an encoded payload.
+const DefaultMaxSize = 16 << 20
+
+var macroRegexp = regexp.MustCompile(`^\{#[A-Z0-9_.]+\}$`)
+
+var (
+	// ErrEmptyRow is returned when adding a row without macros.
+	ErrEmptyRow = errors.New("LLD row has no macros")
+
+	// ErrDuplicateRow is returned when adding a row equal to a row already in
+	// the payload.
+	ErrDuplicateRow = errors.New("Duplicate LLD row")
+)
+
+// MacroError is returned when a row contains an invalid LLD macro name.
+type MacroError struct {
+	Macro string
+}
+
+func (e *MacroError) Error() string {
+	return fmt.Sprintf("Invalid LLD macro %q: must match {#NAME} with uppercase letters, digits, '_' or '.'", e.Macro)
+}
+
+// SizeError is returned when a payload exceeds its size limit.
+type SizeError struct {
+	Size    int
+	MaxSize int
+}
+
+func (e *SizeError) Error() string {
+	return fmt.Sprintf("LLD payload of %d bytes exceeds the limit of %d bytes", e.Size, e.MaxSize)
+}
+
+// ValidateMacro returns a MacroError if the given name is not a valid LLD
+// macro such as {#IFNAME}.
+func ValidateMacro(macro string) error {
+	if !macroRegexp.MatchString(macro) {
+		return &MacroError{Macro: macro}
+	}
+
+	return nil
+}
+
+// Row is a discovered entity, mapping LLD macros to their values.
+type Row map[string]string
+
+// key returns a canonical representation of the row for duplicate detection.
+func (r Row) key() string {
+	macros := make([]string, 0, len(r))
+	for macro := range r {
+		macros = append(macros, macro)
+	}
+	sort.Strings(macros)
+
+	var b strings.Builder
+	for _, macro := range macros {
+		b.WriteString(macro)
+		b.WriteByte(0)
+		b.WriteString(r[macro])
+		b.WriteByte(0)
+	}
+
+	return b.String()
+}
+
+// Payload is a low-level discovery payload.
+type Payload struct {
+	// MaxSize is the size limit of the encoded payload. DefaultMaxSize is used
+	// if zero.
+	MaxSize int
+
+	rows []Row
+	seen map[string]bool
+}
+
+// New returns an empty Payload.
+func New() *Payload {
+	return &Payload{
+		MaxSize: DefaultMaxSize,
+		seen:    make(map[string]bool),
+	}
+}
+
+// Add validates the macro names of the given row and adds it to the
+// payload. Rows equal to a row already added are rejected with
+// ErrDuplicateRow.
+func (p *Payload) Add(row Row) error {
+	if len(row) == 0 {
+		return ErrEmptyRow
+	}
+
+	for macro := range row {
+		if err := ValidateMacro(macro); err != nil {
+			return err
+		}
+	}
+
+	if p.seen == nil {
+		p.seen = make(map[string]bool)
+	}
+
+	key := row.key()
+	if p.seen[key] {
+		return ErrDuplicateRow
+	}
+	p.seen[key] = true
+
+	copied := make(Row, len(row))
+	for macro, value := range row {
+		copied[macro] = value
+	}
+	p.rows = append(p.rows, copied)
+
+	return nil
+}
+
+// Rows returns the rows of the payload.
+func (p *Payload) Rows() []Row {
+	return append([]Row{}, p.rows...)
+}
+
+// Len returns the number of rows in the payload.
+func (p *Payload) Len() int {
+	return len(p.rows)
+}
+
+// MarshalJSON encodes the payload, returning a SizeError if it exceeds
+// MaxSize.
+func (p *Payload) MarshalJSON() ([]byte, error) {
+	rows := p.rows
+	if rows == nil {
+		rows = []Row{}
+	}
+
+	b, err := json.Marshal(struct {
+		Data []Row `json:"data"`
+	}{rows})
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize := p.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxSize
+	}
+
+	if len(b) > maxSize {
+		return nil, &SizeError{Size: len(b), MaxSize: maxSize}
+	}
+
+	return b, nil
+}
+
+// String returns the encoded payload, or an empty string if it is invalid.
+func (p *Payload) String() string {
+	b, err := p.MarshalJSON()
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// Push sends the payload to the discovery rule with the given Item ID through
+// the `history.push` API method, available since Zabbix 7.0.
+func (p *Payload) Push(session *zabbix.Session, itemID string) (*zabbix.HistoryPushResult, error) {
+	b, err := p.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := session.PushHistory(zabbix.HistoryPushValue{
+		ItemID: itemID,
+		Value:  string(b),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, result.Err()
+}
+
+// Send sends the payload to the discovery rule with the given key on the
+// given Host through the sender protocol.
+func (p *Payload) Send(s *sender.Sender, host, key string) (*sender.Response, error) {
+	b, err := p.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Send(sender.NewItem(host, key, string(b)))
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.Failed > 0 {
+		return resp, fmt.Errorf("LLD payload was not accepted: %s", resp.Info)
+	}
+
+	return resp, nil
+}
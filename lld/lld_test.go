@@ -0,0 +1,122 @@
+package lld
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix/sender"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestValidateMacro(t *testing.T) {
+	tests := map[string]bool{
+		"{#FSNAME}":     true,
+		"{#IF.NAME_2}":  true,
+		"{#fsname}":     false,
+		"{FSNAME}":      false,
+		"{$FSNAME}":     false,
+		"{#}":           false,
+		"{#FS NAME}":    false,
+		"#FSNAME":       false,
+		"{#FSNAME}x":    false,
+		"{#FSNAME-BAD}": false,
+	}
+
+	for macro, valid := range tests {
+		err := ValidateMacro(macro)
+		if valid && err != nil {
+			t.Errorf("Expected %q to be valid, got %v", macro, err)
+		}
+
+		if !valid && err == nil {
+			t.Errorf("Expected %q to be invalid", macro)
+		}
+	}
+}
+
+func TestPayload(t *testing.T) {
+	p := New()
+
+	if err := p.Add(Row{"{#FSNAME}": "/", "{#FSTYPE}": "ext4"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Add(Row{"{#FSNAME}": "/boot", "{#FSTYPE}": "ext4"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Add(Row{"{#FSTYPE}": "ext4", "{#FSNAME}": "/"}); err != ErrDuplicateRow {
+		t.Errorf("Expected ErrDuplicateRow but got %v", err)
+	}
+
+	if _, ok := p.Add(Row{"{#fsname}": "/tmp"}).(*MacroError); !ok {
+		t.Errorf("Expected a MacroError for a lowercase macro")
+	}
+
+	if err := p.Add(Row{}); err != ErrEmptyRow {
+		t.Errorf("Expected ErrEmptyRow but got %v", err)
+	}
+
+	expected := `{"data":[{"{#FSNAME}":"/","{#FSTYPE}":"ext4"},{"{#FSNAME}":"/boot","{#FSTYPE}":"ext4"}]}`
+	if p.String() != expected {
+		t.Errorf("Expected payload %s but got %s", expected, p.String())
+	}
+}
+
+func TestEmptyPayload(t *testing.T) {
+	if s := New().String(); s != `{"data":[]}` {
+		t.Errorf("Expected empty payload, got %s", s)
+	}
+}
+
+func TestPayloadSize(t *testing.T) {
+	p := New()
+	p.MaxSize = 64
+	p.Add(Row{"{#NAME}": strings.Repeat("x", 64)})
+
+	if _, err := p.MarshalJSON(); err == nil {
+		t.Errorf("Expected a SizeError")
+	} else if _, ok := err.(*SizeError); !ok {
+		t.Errorf("Expected a SizeError but got %v", err)
+	}
+}
+
+func TestSend(t *testing.T) {
+	var received sender.Request
+	address := test.NewTCPServer(t, false, func(req []byte) []byte {
+		json.Unmarshal(req, &received)
+		return []byte(`{"response":"success","info":"processed: 1; failed: 0; total: 1; seconds spent: 0.000055"}`)
+	})
+
+	p := New()
+	p.Add(Row{"{#QUEUE}": "orders"})
+
+	if _, err := p.Send(sender.New(address), "web01", "queue.discovery"); err != nil {
+		t.Fatal(err)
+	}
+
+	if received.Data[0].Key != "queue.discovery" || received.Data[0].Value != p.String() {
+		t.Errorf("Unexpected sender request %+v", received)
+	}
+}
+
+func TestPush(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("history.push", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`{"response":"success","data":[{"itemid":"10600"}]}`), nil
+	})
+
+	p := New()
+	p.Add(Row{"{#QUEUE}": "orders"})
+
+	if _, err := p.Push(server.Session(t, "7.0.0"), "10600"); err != nil {
+		t.Fatal(err)
+	}
+
+	var values []map[string]string
+	json.Unmarshal(server.Calls()[0].Params.(json.RawMessage), &values)
+	if values[0]["itemid"] != "10600" || values[0]["value"] != p.String() {
+		t.Errorf("Unexpected history.push params %v", values)
+	}
+}
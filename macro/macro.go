@@ -0,0 +1,188 @@
+// Package macro parses Zabbix user macros and resolves their effective value
+// for a host, the way the Zabbix server does:
+//
+//	{$MACRO}
+//	{$MACRO:"context"}
+//	{$MACRO:regex:"^context$"}
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/config/macros/user_macros_context
+package macro
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Macro is a parsed user macro.
+type Macro struct {
+	// Name is the macro name without braces and context, such as LOW_SPACE.
+	Name string
+
+	// Context is the unquoted context of the macro.
+	Context string
+
+	// HasContext is true if the macro has a context, which may be empty.
+	HasContext bool
+
+	// Regex is true if Context is a regular expression. Only macro
+	// definitions may have a regex context.
+	Regex bool
+}
+
+// SyntaxError is returned when parsing an invalid user macro.
+type SyntaxError struct {
+	Macro  string
+	Reason string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("Invalid user macro %q: %s", e.Macro, e.Reason)
+}
+
+// Parse parses a user macro such as {$LOW_SPACE:"/var"}.
+func Parse(s string) (Macro, error) {
+	m, n, err := parse(s)
+	if err != nil {
+		return Macro{}, err
+	}
+
+	if n != len(s) {
+		return Macro{}, &SyntaxError{Macro: s, Reason: "unexpected text after the macro"}
+	}
+
+	return m, nil
+}
+
+// parse parses the user macro at the start of s and returns its length.
+func parse(s string) (Macro, int, error) {
+	if !strings.HasPrefix(s, "{$") {
+		return Macro{}, 0, &SyntaxError{Macro: s, Reason: "must start with {$"}
+	}
+
+	i := 2
+	for i < len(s) && isNameChar(s[i]) {
+		i++
+	}
+
+	m := Macro{Name: s[2:i]}
+	if m.Name == "" {
+		return Macro{}, 0, &SyntaxError{Macro: s, Reason: "missing name"}
+	}
+
+	if i == len(s) {
+		return Macro{}, 0, &SyntaxError{Macro: s, Reason: "missing closing brace"}
+	}
+
+	switch s[i] {
+	case '}':
+		return m, i + 1, nil
+	case ':':
+	default:
+		return Macro{}, 0, &SyntaxError{Macro: s, Reason: fmt.Sprintf("invalid character %q in name", s[i])}
+	}
+
+	m.HasContext = true
+	i++
+	if strings.HasPrefix(s[i:], "regex:") {
+		m.Regex = true
+		i += len("regex:")
+	}
+
+	// leading spaces of the context are ignored
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+
+	if i < len(s) && s[i] == '"' {
+		var b strings.Builder
+		for i++; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) && s[i+1] == '"' {
+				i++
+			}
+			b.WriteByte(s[i])
+		}
+		if i == len(s) {
+			return Macro{}, 0, &SyntaxError{Macro: s, Reason: "unterminated quoted context"}
+		}
+		m.Context = b.String()
+
+		// trailing spaces after a quoted context are ignored
+		for i++; i < len(s) && s[i] == ' '; i++ {
+		}
+		if i == len(s) || s[i] != '}' {
+			return Macro{}, 0, &SyntaxError{Macro: s, Reason: "missing closing brace"}
+		}
+		return m, i + 1, nil
+	}
+
+	end := strings.IndexByte(s[i:], '}')
+	if end < 0 {
+		return Macro{}, 0, &SyntaxError{Macro: s, Reason: "missing closing brace"}
+	}
+	m.Context = s[i : i+end]
+
+	return m, i + end + 1, nil
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.'
+}
+
+// String formats the macro with a quoted context.
+func (m Macro) String() string {
+	if !m.HasContext {
+		return "{$" + m.Name + "}"
+	}
+
+	prefix := ""
+	if m.Regex {
+		prefix = "regex:"
+	}
+
+	return fmt.Sprintf(`{$%s:%s"%s"}`, m.Name, prefix, strings.ReplaceAll(m.Context, `"`, `\"`))
+}
+
+// matches returns true if the macro definition d applies to the macro
+// reference m, and whether it matches its context exactly.
+func (d Macro) matches(m Macro) (match bool, exact bool, err error) {
+	if d.Name != m.Name || d.HasContext != m.HasContext {
+		return false, false, nil
+	}
+
+	if !d.HasContext {
+		return true, true, nil
+	}
+
+	if !d.Regex {
+		return d.Context == m.Context, d.Context == m.Context, nil
+	}
+
+	re, err := regexp.Compile(d.Context)
+	if err != nil {
+		return false, false, fmt.Errorf("Invalid regex context of user macro %s: %v", d, err)
+	}
+
+	return re.MatchString(m.Context), false, nil
+}
+
+// Find returns the user macros referenced in s, in order of appearance.
+// Invalid macros are skipped.
+func Find(s string) []Macro {
+	var macros []Macro
+	for {
+		i := strings.Index(s, "{$")
+		if i < 0 {
+			return macros
+		}
+
+		m, n, err := parse(s[i:])
+		if err != nil {
+			s = s[i+2:]
+			continue
+		}
+
+		macros = append(macros, m)
+		s = s[i+n:]
+	}
+}
@@ -0,0 +1,76 @@
+package macro_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix/macro"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]macro.Macro{
+		"{$MACRO}":                     {Name: "MACRO"},
+		"{$LOW.SPACE_1}":               {Name: "LOW.SPACE_1"},
+		"{$MACRO:}":                    {Name: "MACRO", HasContext: true},
+		"{$MACRO:/var}":                {Name: "MACRO", Context: "/var", HasContext: true},
+		"{$MACRO:  /var}":              {Name: "MACRO", Context: "/var", HasContext: true},
+		`{$MACRO:"/var"}`:              {Name: "MACRO", Context: "/var", HasContext: true},
+		`{$MACRO: "a}b" }`:             {Name: "MACRO", Context: "a}b", HasContext: true},
+		`{$MACRO:"say \"hi\""}`:        {Name: "MACRO", Context: `say "hi"`, HasContext: true},
+		`{$MACRO:regex:"^/var"}`:       {Name: "MACRO", Context: "^/var", HasContext: true, Regex: true},
+		`{$MACRO:regex:^[a-z]+$}`:      {Name: "MACRO", Context: "^[a-z]+$", HasContext: true, Regex: true},
+		`{$MACRO:"regex:not a regex"}`: {Name: "MACRO", Context: "regex:not a regex", HasContext: true},
+	}
+
+	for s, expected := range tests {
+		m, err := macro.Parse(s)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", s, err)
+			continue
+		}
+		if !reflect.DeepEqual(m, expected) {
+			t.Errorf("Unexpected macro for %s: %+v", s, m)
+		}
+	}
+
+	for _, s := range []string{"", "$MACRO", "{$}", "{$macro}", "{$MACRO", `{$MACRO:"ctx}`, `{$MACRO:"ctx"x}`, "{$MACRO}x"} {
+		if _, err := macro.Parse(s); err == nil {
+			t.Errorf("Expected error parsing %q", s)
+		} else if _, ok := err.(*macro.SyntaxError); !ok {
+			t.Errorf("Expected SyntaxError parsing %q, got %T", s, err)
+		}
+	}
+}
+
+func TestMacroString(t *testing.T) {
+	tests := map[string]string{
+		"{$MACRO}":               "{$MACRO}",
+		"{$MACRO:/var}":          `{$MACRO:"/var"}`,
+		`{$MACRO:"say \"hi\""}`:  `{$MACRO:"say \"hi\""}`,
+		`{$MACRO:regex:^/var$}`:  `{$MACRO:regex:"^/var$"}`,
+		`{$MACRO:regex: "^/v" }`: `{$MACRO:regex:"^/v"}`,
+	}
+
+	for s, expected := range tests {
+		m, err := macro.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.String() != expected {
+			t.Errorf("Unexpected string for %s: %s", s, m)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	macros := macro.Find(`Free space on {$MOUNT:"/var"} is below {$LOW_SPACE:"/var"}% {$ broken {$TOTAL}`)
+
+	expected := []macro.Macro{
+		{Name: "MOUNT", Context: "/var", HasContext: true},
+		{Name: "LOW_SPACE", Context: "/var", HasContext: true},
+		{Name: "TOTAL"},
+	}
+	if !reflect.DeepEqual(macros, expected) {
+		t.Errorf("Unexpected macros: %+v", macros)
+	}
+}
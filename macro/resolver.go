@@ -0,0 +1,446 @@
+package macro
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NexonSU/go-zabbix"
+)
+
+// Level is the level a user macro is defined on.
+type Level int
+
+const (
+	// LevelHost is a macro defined on the host.
+	LevelHost Level = iota
+
+	// LevelTemplate is a macro defined on a template linked to the host,
+	// directly or through other templates.
+	LevelTemplate
+
+	// LevelGlobal is a global macro.
+	LevelGlobal
+)
+
+// String returns the name of the Level.
+func (l Level) String() string {
+	switch l {
+	case LevelHost:
+		return "host"
+	case LevelTemplate:
+		return "template"
+	case LevelGlobal:
+		return "global"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// Origin is the definition a resolved user macro takes its value from.
+type Origin struct {
+	Level Level
+
+	// ID is the ID of the host or template defining the macro. It is empty
+	// for global macros.
+	ID string
+
+	// Name is the technical name of the host or template defining the macro.
+	Name string
+
+	// Macro is the macro as defined, such as {$LOW_SPACE:regex:"^/var"}.
+	Macro string
+}
+
+// String describes the Origin.
+func (o Origin) String() string {
+	if o.Level == LevelGlobal {
+		return fmt.Sprintf("global macro %s", o.Macro)
+	}
+	return fmt.Sprintf("%s %q macro %s", o.Level, o.Name, o.Macro)
+}
+
+// Resolution is the effective value of a user macro.
+type Resolution struct {
+	// Macro is the resolved macro reference.
+	Macro string
+
+	// Value is the value of the macro definition. It is empty for secret
+	// macros, and the path of the secret for vault macros.
+	Value string
+
+	Type   zabbix.MacroType
+	Origin Origin
+}
+
+// String formats the Resolution with the value of secret and vault macros
+// redacted.
+func (r *Resolution) String() string {
+	value := r.Value
+	if r.Type != zabbix.MacroTypeText {
+		value = zabbix.MacroRedacted
+	}
+	return fmt.Sprintf("%s=%s (%s)", r.Macro, value, r.Origin)
+}
+
+// UndefinedError is returned when no definition applies to a user macro.
+type UndefinedError struct {
+	Macro string
+}
+
+func (e *UndefinedError) Error() string {
+	return fmt.Sprintf("User macro %s is not defined", e.Macro)
+}
+
+// Scope is a host or template with its user macros and linked templates.
+type Scope struct {
+	ID        string
+	Name      string
+	Macros    []zabbix.HostMacro
+	Templates []*Scope
+}
+
+// levels returns the templates linked to the host, level by level, each level
+// sorted by ID, as the Zabbix server searches them.
+func (s *Scope) levels() []*Scope {
+	var levels []*Scope
+	seen := map[string]bool{s.ID: true}
+
+	next := s.Templates
+	for len(next) > 0 {
+		level := make([]*Scope, 0, len(next))
+		for _, t := range next {
+			if !seen[t.ID] {
+				seen[t.ID] = true
+				level = append(level, t)
+			}
+		}
+		sort.Slice(level, func(i, j int) bool {
+			return lessID(level[i].ID, level[j].ID)
+		})
+		levels = append(levels, level...)
+
+		next = nil
+		for _, t := range level {
+			next = append(next, t.Templates...)
+		}
+	}
+
+	return levels
+}
+
+// lessID compares numeric IDs.
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// Resolve returns the effective value of the given user macro on a host.
+//
+// The host macros are searched first, then the macros of the linked
+// templates, level by level, and then the global macros. A macro with context
+// takes the value of the first definition with the exact context, or with a
+// regex context matching it, on the same level. If none exists on any level,
+// it takes the value of the macro without context.
+//
+// An UndefinedError is returned if no definition applies.
+func Resolve(host *Scope, global []zabbix.HostMacro, macro string) (*Resolution, error) {
+	ref, err := Parse(macro)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref.Regex {
+		return nil, &SyntaxError{Macro: macro, Reason: "regex context is only allowed in macro definitions"}
+	}
+
+	templates := host.levels()
+	for {
+		res, err := resolve(host, templates, global, ref)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			res.Macro = macro
+			return res, nil
+		}
+
+		if !ref.HasContext {
+			return nil, &UndefinedError{Macro: macro}
+		}
+
+		// fall back to the macro without context
+		ref = Macro{Name: ref.Name}
+	}
+}
+
+func resolve(host *Scope, templates []*Scope, global []zabbix.HostMacro, ref Macro) (*Resolution, error) {
+	res, err := find(host.Macros, ref)
+	if err != nil || res != nil {
+		if res != nil {
+			res.Origin.ID, res.Origin.Name = host.ID, host.Name
+		}
+		return res, err
+	}
+
+	for _, t := range templates {
+		res, err := find(t.Macros, ref)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			res.Origin.Level, res.Origin.ID, res.Origin.Name = LevelTemplate, t.ID, t.Name
+			return res, nil
+		}
+	}
+
+	res, err = find(global, ref)
+	if res != nil {
+		res.Origin.Level = LevelGlobal
+	}
+	return res, err
+}
+
+// find returns the definition with the exact context of the reference, or
+// else the first definition with a matching regex context.
+func find(macros []zabbix.HostMacro, ref Macro) (*Resolution, error) {
+	var regex *zabbix.HostMacro
+	for i := range macros {
+		d, err := Parse(macros[i].Macro)
+		if err != nil {
+			continue
+		}
+
+		match, exact, err := d.matches(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if exact {
+			return &Resolution{Value: macros[i].Value, Type: macros[i].Type, Origin: Origin{Macro: macros[i].Macro}}, nil
+		}
+
+		if match && regex == nil {
+			regex = &macros[i]
+		}
+	}
+
+	if regex == nil {
+		return nil, nil
+	}
+
+	return &Resolution{Value: regex.Value, Type: regex.Type, Origin: Origin{Macro: regex.Macro}}, nil
+}
+
+// Expand replaces the user macros in text with their effective value on a
+// host. Undefined macros are left unchanged, as the Zabbix server does.
+//
+// A SecretError is returned if text refers to a secret or vault macro.
+func Expand(host *Scope, global []zabbix.HostMacro, text string) (string, error) {
+	return expand(host, global, text, nil)
+}
+
+func expand(host *Scope, global []zabbix.HostMacro, text string, vault VaultResolver) (string, error) {
+	var b strings.Builder
+	for {
+		i := strings.Index(text, "{$")
+		if i < 0 {
+			b.WriteString(text)
+			return b.String(), nil
+		}
+
+		b.WriteString(text[:i])
+		text = text[i:]
+
+		_, n, err := parse(text)
+		if err != nil {
+			b.WriteString("{$")
+			text = text[2:]
+			continue
+		}
+
+		res, err := Resolve(host, global, text[:n])
+		switch err.(type) {
+		case nil:
+			value, err := reveal(res, vault)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(value)
+		case *UndefinedError, *SyntaxError:
+			b.WriteString(text[:n])
+		default:
+			return "", err
+		}
+		text = text[n:]
+	}
+}
+
+// Resolver resolves the user macros of hosts configured on a Zabbix server.
+// Templates and global macros are cached, see Reset. Values read from the
+// Vault are never cached.
+type Resolver struct {
+	Session *zabbix.Session
+
+	// Vault reads the value of vault macros. If nil, vault macros resolve to
+	// the path of their secret and cannot be expanded.
+	Vault VaultResolver
+
+	templates map[string]*Scope
+	global    []zabbix.HostMacro
+}
+
+// NewResolver returns a Resolver reading macros with the given Session.
+func NewResolver(session *zabbix.Session) *Resolver {
+	return &Resolver{Session: session}
+}
+
+// Reset clears the cached templates and global macros.
+func (r *Resolver) Reset() {
+	r.templates = nil
+	r.global = nil
+}
+
+// Resolve returns the effective value of the given user macro on a host. See
+// the Resolve function for the resolution rules.
+//
+// Vault macros are read with the Vault if set. The value of secret macros is
+// always empty.
+func (r *Resolver) Resolve(hostID, macro string) (*Resolution, error) {
+	host, global, err := r.load(hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := Resolve(host, global, macro)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Type == zabbix.MacroTypeVault && r.Vault != nil {
+		if res.Value, err = reveal(res, r.Vault); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Expand replaces the user macros in text with their effective value on a
+// host. Undefined macros are left unchanged.
+//
+// A SecretError is returned if text refers to a secret macro, or to a vault
+// macro and the Vault is not set.
+func (r *Resolver) Expand(hostID, text string) (string, error) {
+	host, global, err := r.load(hostID)
+	if err != nil {
+		return "", err
+	}
+
+	return expand(host, global, text, r.Vault)
+}
+
+// Scope returns a host with its macros and linked templates.
+//
+// ErrNotFound is returned if the host does not exist.
+func (r *Resolver) Scope(hostID string) (*Scope, error) {
+	hosts, err := r.Session.GetHosts(zabbix.HostGetParams{
+		HostIDs:               []string{hostID},
+		SelectMacros:          zabbix.SelectExtendedOutput,
+		SelectParentTemplates: zabbix.SelectFields{"templateid"},
+		GetParameters: zabbix.GetParameters{
+			OutputFields: zabbix.SelectFields{"hostid", "host"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	host := &Scope{ID: hosts[0].HostID, Name: hosts[0].Hostname, Macros: hosts[0].Macros}
+	var templateIDs []string
+	for _, t := range hosts[0].ParentTemplates {
+		templateIDs = append(templateIDs, t.TemplateID)
+	}
+
+	if host.Templates, err = r.loadTemplates(templateIDs); err != nil {
+		return nil, err
+	}
+
+	return host, nil
+}
+
+func (r *Resolver) load(hostID string) (*Scope, []zabbix.HostMacro, error) {
+	host, err := r.Scope(hostID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.global == nil {
+		global, err := r.Session.GetUserMacro(zabbix.UserMacroGetParams{GlobalMacro: true})
+		if err != nil && err != zabbix.ErrNotFound {
+			return nil, nil, err
+		}
+		r.global = append([]zabbix.HostMacro{}, global...)
+	}
+
+	return host, r.global, nil
+}
+
+// loadTemplates returns the templates with the given IDs, reading the
+// templates which are not cached yet and the templates linked to them.
+func (r *Resolver) loadTemplates(ids []string) ([]*Scope, error) {
+	if r.templates == nil {
+		r.templates = make(map[string]*Scope)
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if _, ok := r.templates[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	links := make(map[string][]string)
+	for len(missing) > 0 {
+		templates, err := r.Session.GetTemplates(zabbix.TemplateGetParams{
+			TemplateIDs:           missing,
+			SelectMacros:          zabbix.SelectExtendedOutput,
+			SelectParentTemplates: zabbix.SelectFields{"templateid"},
+			GetParameters: zabbix.GetParameters{
+				OutputFields: zabbix.SelectFields{"templateid", "host"},
+			},
+		})
+		if err != nil && err != zabbix.ErrNotFound {
+			return nil, err
+		}
+
+		missing = nil
+		for _, t := range templates {
+			r.templates[t.TemplateID] = &Scope{ID: t.TemplateID, Name: t.Hostname, Macros: t.Macros}
+			for _, parent := range t.ParentTemplates {
+				links[t.TemplateID] = append(links[t.TemplateID], parent.TemplateID)
+				if _, ok := r.templates[parent.TemplateID]; !ok {
+					missing = append(missing, parent.TemplateID)
+				}
+			}
+		}
+	}
+
+	for id, parents := range links {
+		for _, parentID := range parents {
+			if parent, ok := r.templates[parentID]; ok {
+				r.templates[id].Templates = append(r.templates[id].Templates, parent)
+			}
+		}
+	}
+
+	scopes := make([]*Scope, 0, len(ids))
+	for _, id := range ids {
+		if t, ok := r.templates[id]; ok {
+			scopes = append(scopes, t)
+		}
+	}
+
+	return scopes, nil
+}
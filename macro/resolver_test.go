@@ -0,0 +1,245 @@
+package macro_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/macro"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func macros(defs ...string) []zabbix.HostMacro {
+	var macros []zabbix.HostMacro
+	for i := 0; i < len(defs); i += 2 {
+		macros = append(macros, zabbix.HostMacro{Macro: defs[i], Value: defs[i+1]})
+	}
+	return macros
+}
+
+func testScope() (*macro.Scope, []zabbix.HostMacro) {
+	base := &macro.Scope{ID: "10001", Name: "Base", Macros: macros(
+		"{$LOW_SPACE}", "10",
+		"{$TIMEOUT}", "base",
+	)}
+	linux := &macro.Scope{ID: "10100", Name: "Linux", Macros: macros(
+		`{$LOW_SPACE:regex:"^/(var|tmp)"}`, "20",
+		`{$LOW_SPACE:"/var/log"}`, "30",
+		"{$TIMEOUT}", "linux",
+	), Templates: []*macro.Scope{base}}
+	app := &macro.Scope{ID: "10050", Name: "App", Macros: macros(
+		"{$TIMEOUT}", "app",
+	), Templates: []*macro.Scope{base}}
+	host := &macro.Scope{ID: "20001", Name: "server", Macros: macros(
+		`{$LOW_SPACE:"/home"}`, "40",
+	), Templates: []*macro.Scope{linux, app}}
+
+	global := macros(
+		"{$LOW_SPACE}", "5",
+		"{$SNMP_COMMUNITY}", "public",
+		`{$PORT:regex:"^http"}`, "80",
+	)
+
+	return host, global
+}
+
+func TestResolve(t *testing.T) {
+	host, global := testScope()
+
+	tests := []struct {
+		Macro  string
+		Value  string
+		Origin macro.Origin
+	}{
+		{`{$LOW_SPACE:"/home"}`, "40", macro.Origin{Level: macro.LevelHost, ID: "20001", Name: "server", Macro: `{$LOW_SPACE:"/home"}`}},
+		{`{$LOW_SPACE:/var/log}`, "30", macro.Origin{Level: macro.LevelTemplate, ID: "10100", Name: "Linux", Macro: `{$LOW_SPACE:"/var/log"}`}},
+		{`{$LOW_SPACE:"/tmp"}`, "20", macro.Origin{Level: macro.LevelTemplate, ID: "10100", Name: "Linux", Macro: `{$LOW_SPACE:regex:"^/(var|tmp)"}`}},
+		{`{$LOW_SPACE:"/opt"}`, "10", macro.Origin{Level: macro.LevelTemplate, ID: "10001", Name: "Base", Macro: "{$LOW_SPACE}"}},
+		{`{$LOW_SPACE}`, "10", macro.Origin{Level: macro.LevelTemplate, ID: "10001", Name: "Base", Macro: "{$LOW_SPACE}"}},
+		{`{$TIMEOUT}`, "app", macro.Origin{Level: macro.LevelTemplate, ID: "10050", Name: "App", Macro: "{$TIMEOUT}"}},
+		{`{$SNMP_COMMUNITY}`, "public", macro.Origin{Level: macro.LevelGlobal, Macro: "{$SNMP_COMMUNITY}"}},
+		{`{$PORT:"https"}`, "80", macro.Origin{Level: macro.LevelGlobal, Macro: `{$PORT:regex:"^http"}`}},
+	}
+
+	for _, test := range tests {
+		res, err := macro.Resolve(host, global, test.Macro)
+		if err != nil {
+			t.Errorf("Error resolving %s: %v", test.Macro, err)
+			continue
+		}
+
+		if res.Macro != test.Macro || res.Value != test.Value || res.Origin != test.Origin {
+			t.Errorf("Unexpected resolution of %s: %+v", test.Macro, res)
+		}
+	}
+
+	for _, m := range []string{"{$MISSING}", `{$PORT:"ftp"}`} {
+		if _, err := macro.Resolve(host, global, m); err == nil {
+			t.Errorf("Expected error resolving %s", m)
+		} else if _, ok := err.(*macro.UndefinedError); !ok {
+			t.Errorf("Expected UndefinedError resolving %s, got %v", m, err)
+		}
+	}
+
+	if _, err := macro.Resolve(host, global, `{$PORT:regex:"^h"}`); err == nil {
+		t.Error("Expected error resolving a regex context")
+	}
+}
+
+func TestResolveInvalidRegex(t *testing.T) {
+	host := &macro.Scope{ID: "1", Macros: macros(`{$M:regex:"("}`, "1")}
+
+	if _, err := macro.Resolve(host, nil, `{$M:"a"}`); err == nil {
+		t.Error("Expected error for an invalid regex context")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	host, global := testScope()
+
+	s, err := macro.Expand(host, global, `Free space on /tmp is below {$LOW_SPACE:"/tmp"}% ({$UNKNOWN}, {$ x, {$TIMEOUT})`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Free space on /tmp is below 20% ({$UNKNOWN}, {$ x, app)"; s != expected {
+		t.Errorf("Unexpected expansion: %s", s)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+		return []map[string]interface{}{{
+			"hostid":          "20001",
+			"host":            "server",
+			"macros":          []map[string]string{{"macro": "{$HOST}", "value": "h"}},
+			"parentTemplates": []map[string]string{{"templateid": "10100"}},
+		}}, nil
+	})
+	server.Handle("template.get", func(params json.RawMessage) (interface{}, error) {
+		var p struct {
+			TemplateIDs []string `json:"templateids"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		templates := map[string]map[string]interface{}{
+			"10100": {
+				"templateid":      "10100",
+				"host":            "Linux",
+				"macros":          []map[string]string{{"macro": "{$LINUX}", "value": "l"}},
+				"parentTemplates": []map[string]string{{"templateid": "10001"}},
+			},
+			"10001": {
+				"templateid":      "10001",
+				"host":            "Base",
+				"macros":          []map[string]string{{"macro": "{$BASE}", "value": "b"}},
+				"parentTemplates": []map[string]string{},
+			},
+		}
+
+		var result []map[string]interface{}
+		for _, id := range p.TemplateIDs {
+			result = append(result, templates[id])
+		}
+		return result, nil
+	})
+	server.Handle("usermacro.get", func(params json.RawMessage) (interface{}, error) {
+		return []map[string]string{{"globalmacroid": "1", "macro": "{$GLOBAL}", "value": "g"}}, nil
+	})
+
+	resolver := macro.NewResolver(server.Session(t, "7.0.0"))
+
+	s, err := resolver.Expand("20001", "{$HOST}/{$LINUX}/{$BASE}/{$GLOBAL}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "h/l/b/g" {
+		t.Errorf("Unexpected expansion: %s", s)
+	}
+
+	res, err := resolver.Resolve("20001", "{$BASE}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Origin.Level != macro.LevelTemplate || res.Origin.Name != "Base" {
+		t.Errorf("Unexpected origin: %s", res.Origin)
+	}
+
+	// templates and global macros are cached
+	counts := make(map[string]int)
+	for _, call := range server.Calls() {
+		counts[call.Method]++
+	}
+	if counts["host.get"] != 2 || counts["template.get"] != 2 || counts["usermacro.get"] != 1 {
+		t.Errorf("Unexpected calls: %v", counts)
+	}
+}
+
+func TestSecretMacros(t *testing.T) {
+	host := &macro.Scope{ID: "20001", Name: "server", Macros: []zabbix.HostMacro{
+		{Macro: "{$PASSWORD}", Type: zabbix.MacroTypeSecret},
+		{Macro: "{$TOKEN}", Value: "secret/zabbix:token", Type: zabbix.MacroTypeVault},
+		{Macro: "{$USER}", Value: "zabbix"},
+	}}
+
+	res, err := macro.Resolve(host, nil, "{$TOKEN}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Type != zabbix.MacroTypeVault || res.Value != "secret/zabbix:token" {
+		t.Errorf("Unexpected vault macro resolution: %+v", res)
+	}
+	if s := res.String(); s != `{$TOKEN}=****** (host "server" macro {$TOKEN})` {
+		t.Errorf("Unexpected resolution string: %s", s)
+	}
+
+	for _, text := range []string{"{$USER}:{$PASSWORD}", "{$USER}:{$TOKEN}"} {
+		if _, err := macro.Expand(host, nil, text); err == nil {
+			t.Errorf("Expected error expanding %s", text)
+		} else if _, ok := err.(*macro.SecretError); !ok {
+			t.Errorf("Expected SecretError expanding %s, got %v", text, err)
+		}
+	}
+}
+
+func TestResolverVault(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{"hostid":"20001","host":"server","parentTemplates":[],"macros":[
+			{"macro":"{$USER}","value":"zabbix","type":"0"},
+			{"macro":"{$TOKEN}","value":"secret/zabbix:token","type":"2"}
+		]}]`), nil
+	})
+	server.Handle("usermacro.get", func(params json.RawMessage) (interface{}, error) {
+		return []interface{}{}, nil
+	})
+
+	resolver := macro.NewResolver(server.Session(t, "7.0.0"))
+	resolver.Vault = macro.VaultResolverFunc(func(path string) (string, error) {
+		if path != "secret/zabbix:token" {
+			return "", fmt.Errorf("Unknown secret %s", path)
+		}
+		return "s3cr3t", nil
+	})
+
+	s, err := resolver.Expand("20001", "{$USER}:{$TOKEN}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "zabbix:s3cr3t" {
+		t.Errorf("Unexpected expansion: %s", s)
+	}
+
+	res, err := resolver.Resolve("20001", "{$TOKEN}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Value != "s3cr3t" || strings.Contains(res.String(), "s3cr3t") {
+		t.Errorf("Unexpected resolution: %+v", res)
+	}
+}
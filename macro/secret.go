@@ -0,0 +1,57 @@
+package macro
+
+import (
+	"fmt"
+
+	"github.com/NexonSU/go-zabbix"
+)
+
+// VaultResolver reads the value of vault macros from a secret backend, such
+// as HashiCorp Vault or CyberArk.
+type VaultResolver interface {
+	// ReadVault returns the secret stored at the path given as the value of a
+	// vault macro, such as "secret/zabbix:password".
+	ReadVault(path string) (string, error)
+}
+
+// VaultResolverFunc is a function implementing VaultResolver.
+type VaultResolverFunc func(path string) (string, error)
+
+// ReadVault calls f(path).
+func (f VaultResolverFunc) ReadVault(path string) (string, error) {
+	return f(path)
+}
+
+// SecretError is returned when the value of a secret or vault macro is
+// required but not available. The Zabbix API never returns the value of
+// secret macros, and vault macros require a VaultResolver.
+type SecretError struct {
+	Macro string
+	Type  zabbix.MacroType
+}
+
+func (e *SecretError) Error() string {
+	return fmt.Sprintf("Value of %s user macro %s is not available", e.Type, e.Macro)
+}
+
+// reveal returns the value of a resolved macro, reading vault macros with the
+// given VaultResolver.
+func reveal(res *Resolution, vault VaultResolver) (string, error) {
+	switch res.Type {
+	case zabbix.MacroTypeSecret:
+		return "", &SecretError{Macro: res.Macro, Type: res.Type}
+
+	case zabbix.MacroTypeVault:
+		if vault == nil {
+			return "", &SecretError{Macro: res.Macro, Type: res.Type}
+		}
+
+		value, err := vault.ReadVault(res.Value)
+		if err != nil {
+			return "", fmt.Errorf("Failed to read vault macro %s: %v", res.Macro, err)
+		}
+		return value, nil
+	}
+
+	return res.Value, nil
+}
@@ -7,32 +7,186 @@ import (
 	"github.com/NexonSU/go-zabbix/types"
 )
 
+// MaintenanceType is whether data is collected during a Maintenance.
 type MaintenanceType int
+
+// TagsEvaltype is how the problem tags of a Maintenance are evaluated.
 type TagsEvaltype int
 
+// MaintenanceTimeperiodType is the recurrence of a maintenance time period.
+type MaintenanceTimeperiodType int
+
 var ErrMaintenanceHostNotFound = errors.New("Failed to find ID by host name")
 
 const (
-	withDataCollection MaintenanceType = iota
-	withoutDataCollection
+	// MaintenanceWithDataCollection collects data during the Maintenance.
+	MaintenanceWithDataCollection MaintenanceType = 0
+
+	// MaintenanceWithoutDataCollection does not collect data during the
+	// Maintenance.
+	MaintenanceWithoutDataCollection MaintenanceType = 1
+)
+
+const (
+	// TagsEvaltypeAndOr matches problems with all the tags, or any of the
+	// tags with the same name.
+	TagsEvaltypeAndOr TagsEvaltype = 0
+
+	// TagsEvaltypeOr matches problems with any of the tags.
+	TagsEvaltypeOr TagsEvaltype = 2
+)
+
+const (
+	// MaintenanceTimeperiodOnce is a one time period.
+	MaintenanceTimeperiodOnce MaintenanceTimeperiodType = 0
+
+	// MaintenanceTimeperiodDaily is a period repeated every Every days.
+	MaintenanceTimeperiodDaily MaintenanceTimeperiodType = 2
+
+	// MaintenanceTimeperiodWeekly is a period repeated every Every weeks.
+	MaintenanceTimeperiodWeekly MaintenanceTimeperiodType = 3
+
+	// MaintenanceTimeperiodMonthly is a period repeated every month.
+	MaintenanceTimeperiodMonthly MaintenanceTimeperiodType = 4
+)
+
+const (
+	// Deprecated: use MaintenanceTimeperiodOnce.
+	Once = MaintenanceTimeperiodOnce
+
+	// Deprecated: use MaintenanceTimeperiodDaily.
+	EveryDay = MaintenanceTimeperiodDaily
+
+	// Deprecated: use MaintenanceTimeperiodWeekly.
+	EveryWeek = MaintenanceTimeperiodWeekly
+
+	// Deprecated: use MaintenanceTimeperiodMonthly.
+	EveryMonth = MaintenanceTimeperiodMonthly
+)
+
+var (
+	maintenanceTypes = enum{"MaintenanceType", map[int]string{
+		int(MaintenanceWithDataCollection):    "with_data_collection",
+		int(MaintenanceWithoutDataCollection): "without_data_collection",
+	}}
 
-	and TagsEvaltype = iota * 2
-	or
+	tagsEvaltypes = enum{"TagsEvaltype", map[int]string{
+		int(TagsEvaltypeAndOr): "and_or",
+		int(TagsEvaltypeOr):    "or",
+	}}
 
-	Once int = iota
-	EveryDay
-	EveryWeek
-	EveryMonth
+	maintenanceTimeperiodTypes = enum{"MaintenanceTimeperiodType", map[int]string{
+		int(MaintenanceTimeperiodOnce):    "once",
+		int(MaintenanceTimeperiodDaily):   "daily",
+		int(MaintenanceTimeperiodWeekly):  "weekly",
+		int(MaintenanceTimeperiodMonthly): "monthly",
+	}}
 )
 
+// String returns the name of the MaintenanceType.
+func (t MaintenanceType) String() string {
+	return maintenanceTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t MaintenanceType) MarshalText() ([]byte, error) {
+	return maintenanceTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *MaintenanceType) UnmarshalText(text []byte) error {
+	return maintenanceTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t MaintenanceType) MarshalJSON() ([]byte, error) {
+	return maintenanceTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *MaintenanceType) UnmarshalJSON(data []byte) error {
+	return maintenanceTypes.unmarshalJSON(data, (*int)(t))
+}
+
+// String returns the name of the TagsEvaltype.
+func (t TagsEvaltype) String() string {
+	return tagsEvaltypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TagsEvaltype) MarshalText() ([]byte, error) {
+	return tagsEvaltypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *TagsEvaltype) UnmarshalText(text []byte) error {
+	return tagsEvaltypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t TagsEvaltype) MarshalJSON() ([]byte, error) {
+	return tagsEvaltypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *TagsEvaltype) UnmarshalJSON(data []byte) error {
+	return tagsEvaltypes.unmarshalJSON(data, (*int)(t))
+}
+
+// String returns the name of the MaintenanceTimeperiodType.
+func (t MaintenanceTimeperiodType) String() string {
+	return maintenanceTimeperiodTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t MaintenanceTimeperiodType) MarshalText() ([]byte, error) {
+	return maintenanceTimeperiodTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *MaintenanceTimeperiodType) UnmarshalText(text []byte) error {
+	return maintenanceTimeperiodTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t MaintenanceTimeperiodType) MarshalJSON() ([]byte, error) {
+	return maintenanceTimeperiodTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *MaintenanceTimeperiodType) UnmarshalJSON(data []byte) error {
+	return maintenanceTimeperiodTypes.unmarshalJSON(data, (*int)(t))
+}
+
 type Maintenance struct {
 	MaintenanceID       string                 `json:"maintenanceid"`
 	Name                string                 `json:"name"`
-	ActiveSince         types.ZBXUnixTimestamp `json:"active_since,string"`
-	ActiveTill          types.ZBXUnixTimestamp `json:"active_till,string"`
+	ActiveSince         types.ZBXUnixTimestamp `json:"active_since"`
+	ActiveTill          types.ZBXUnixTimestamp `json:"active_till"`
 	Description         string                 `json:"description"`
-	Type                MaintenanceType        `json:"tags_evaltype,string"`
-	ActionEvalTypeAndOr TagsEvaltype           `json:"maintenance_type,string"`
+	Type                MaintenanceType        `json:"maintenance_type"`
+	ActionEvalTypeAndOr TagsEvaltype           `json:"tags_evaltype"`
+
+	// Hosts is filled when SelectHosts is used on MaintenanceGetParams
+	Hosts []Host `json:"hosts,omitempty"`
+
+	// Groups is filled when SelectGroups is used on MaintenanceGetParams
+	Groups []Hostgroup `json:"groups,omitempty"`
+
+	// HostGroups is filled when SelectHostGroups is used on MaintenanceGetParams
+	HostGroups []Hostgroup `json:"hostgroups,omitempty"`
+
+	// Timeperiods is filled when SelectTimeperiods is used on MaintenanceGetParams
+	Timeperiods []MaintenanceTimeperiods `json:"timeperiods,omitempty"`
 }
 
 type MaintenanceGetParams struct {
@@ -52,6 +206,9 @@ type MaintenanceGetParams struct {
 	// Deprecated: Depcreated since Zabbix 6.4 and removed in Zabbix 7.2
 	SelectGroups SelectQuery `json:"selectGroups,omitempty"`
 
+	// Return host groups assigned to the maintenance in the hostgroups property.
+	SelectHostGroups SelectQuery `json:"selectHostGroups,omitempty"`
+
 	// Return only maintenances with the given IDs.
 	Maintenanceids []string `json:"maintenanceids,omitempty"`
 
@@ -78,11 +235,14 @@ type MaintenanceCreateParams struct {
 }
 
 type MaintenanceTimeperiods struct {
-	TimeperiodType int `json:"timeperiod_type,int"`
-	Every          int `json:"every,string"`
-	Dayofweek      int `json:"dayofweek,string"`
-	StartTime      int `json:"start_time,string"`
-	Period         int `json:"period,string"`
+	TimeperiodType MaintenanceTimeperiodType `json:"timeperiod_type"`
+	Every          types.ZBXInt              `json:"every"`
+	Month          types.ZBXInt              `json:"month,omitempty"`
+	Dayofweek      types.ZBXInt              `json:"dayofweek"`
+	Day            types.ZBXInt              `json:"day,omitempty"`
+	StartTime      types.ZBXInt              `json:"start_time"`
+	Period         types.ZBXInt              `json:"period"`
+	StartDate      types.ZBXInt              `json:"start_date,omitempty"`
 }
 
 type MaintenanceCreateResponse struct {
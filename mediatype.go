@@ -1,5 +1,10 @@
 package zabbix
 
+import (
+	"github.com/NexonSU/go-zabbix/schedule"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
 // MediaType represents a Zabbix media type returned from the Zabbix API.
 //
 // See: https://www.zabbix.com/documentation/7.4/en/manual/api/reference/mediatype/object
@@ -17,7 +22,7 @@ type MediaType struct {
 	// 1 - Script;
 	// 2 - SMS;
 	// 4 - Webhook.
-	Type int `json:"type,string"`
+	Type types.ZBXInt `json:"type"`
 
 	// Name of the script file (e.g., notification.sh) that is located in
 	// the directory specified in the AlertScriptsPath server configuration parameter.
@@ -37,7 +42,7 @@ type MediaType struct {
 	// 2 - Gmail relay;
 	// 3 - Office365;
 	// 4 - Office365 relay.
-	Provider int `json:"provider,string"`
+	Provider types.ZBXInt `json:"provider"`
 
 	// Email address from which notifications will be sent.
 	SmtpEmail string `json:"smtp_email"`
@@ -49,7 +54,7 @@ type MediaType struct {
 	SmtpServer string `json:"smtp_server"`
 
 	// SMTP server port to connect to.
-	SmtpPort int `json:"smtp_port,string"`
+	SmtpPort types.ZBXInt `json:"smtp_port"`
 
 	// SMTP connection security level to use.
 	//
@@ -57,21 +62,21 @@ type MediaType struct {
 	// 0 - (default) None;
 	// 1 - STARTTLS;
 	// 2 - SSL/TLS.
-	SmtpSecurity int `json:"smtp_security,string"`
+	SmtpSecurity types.ZBXInt `json:"smtp_security"`
 
 	// SSL verify host for SMTP.
 	//
 	// Possible values:
 	// 0 - (default) No;
 	// 1 - Yes.
-	SmtpVerifyHost int `json:"smtp_verify_host,string"`
+	SmtpVerifyHost types.ZBXInt `json:"smtp_verify_host"`
 
 	// SSL verify peer for SMTP.
 	//
 	// Possible values:
 	// 0 - (default) No;
 	// 1 - Yes.
-	SmtpVerifyPeer int `json:"smtp_verify_peer,string"`
+	SmtpVerifyPeer types.ZBXInt `json:"smtp_verify_peer"`
 
 	// SMTP authentication method to use.
 	//
@@ -80,7 +85,7 @@ type MediaType struct {
 	// 1 - Normal password;
 	// 2 - OAuth token.
 	// OAuth authentication is not allowed for Office365 relay email provider.
-	SmtpAuthentication int `json:"smtp_authentication,string"`
+	SmtpAuthentication types.ZBXInt `json:"smtp_authentication"`
 
 	// Zabbix frontend URL to redirect back OAuth authorization.
 	//
@@ -109,21 +114,21 @@ type MediaType struct {
 	// 1 - Access token contains valid value
 	// 2 - Refresh token contains valid value
 	// 3 - Both tokens contain valid value.
-	TokensStatus int `json:"tokens_status,string"`
+	TokensStatus types.ZBXInt `json:"tokens_status"`
 
 	// OAuth access token value.
 	AccessToken string `json:"access_token"`
 
 	// Timestamp of last modification of access_token done by server when refreshing
 	// with refresh_token or API on token changes.
-	AccessTokenUpdated int `json:"access_token_updated,string"`
+	AccessTokenUpdated types.ZBXInt `json:"access_token_updated"`
 
 	// Time in seconds when access_token will become outdated and will require to make
 	// request to refresh_url.
 	// Is set by Zabbix server on access_token refresh or by API on token changes.
 	//
 	// Timestamp is calculated by adding value of access_token_updated.
-	AccessExpiresIn int `json:"access_expires_in,string"`
+	AccessExpiresIn types.ZBXInt `json:"access_expires_in"`
 
 	// OAuth refresh token value.
 	RefreshToken string `json:"refresh_token"`
@@ -133,7 +138,7 @@ type MediaType struct {
 	// Possible values:
 	// 0 - (default) Enabled;
 	// 1 - Disabled.
-	Status int `json:"status,string"`
+	Status types.ZBXInt `json:"status"`
 
 	// User name.
 	Username string `json:"username"`
@@ -145,14 +150,14 @@ type MediaType struct {
 	// Possible values if type is set to 'Email', 'Script', or 'Webhook': 0-100.
 	//
 	// Default: 1.
-	MaxSessions int `json:"maxsessions,string"`
+	MaxSessions types.ZBXInt `json:"maxsessions"`
 
 	// The maximum number of attempts to send an alert.
 	//
 	// Possible values: 1-100.
 	//
 	// Default: 3.
-	MaxAttempts int `json:"maxattempts,string"`
+	MaxAttempts types.ZBXInt `json:"maxattempts"`
 
 	// The interval between retry attempts.
 	// Accepts seconds and time unit with suffix.
@@ -167,7 +172,7 @@ type MediaType struct {
 	// Possible values:
 	// 0 - Plain text;
 	// 1 - (default) HTML.
-	MessageFormat int `json:"message_format,string"`
+	MessageFormat types.ZBXInt `json:"message_format"`
 
 	// Webhook script body (JavaScript).
 	Script string `json:"script"`
@@ -186,7 +191,7 @@ type MediaType struct {
 	// Possible values:
 	// 0 - (default) Ignore webhook script response;
 	// 1 - Process webhook script response as tags.
-	ProcessTags int `json:"process_tags,string"`
+	ProcessTags types.ZBXInt `json:"process_tags"`
 
 	// Include an entry in the event menu that links to a custom URL. Also adds the urls
 	// property to the output of problem.get and event.get.
@@ -194,7 +199,7 @@ type MediaType struct {
 	// Possible values:
 	// 0 - (default) Do not include event menu entry or urls property;
 	// 1 - Include event menu entry and urls property.
-	ShowEventMenu int `json:"show_event_menu,string"`
+	ShowEventMenu types.ZBXInt `json:"show_event_menu"`
 
 	// URL used in the event menu entry and in the urls property returned by problem.get
 	// and event.get.
@@ -231,9 +236,9 @@ type MediaType struct {
 
 // MediaTypeParameters is params for Webhook and Script types
 type MediaTypeParameters struct {
-	Name      string `json:"name"`
-	SortOrder int    `json:"sortorder,string"`
-	Value     string `json:"value"`
+	Name      string       `json:"name"`
+	SortOrder types.ZBXInt `json:"sortorder"`
+	Value     string       `json:"value"`
 }
 
 // MediaTypeMessageTemplate is media type templates
@@ -246,7 +251,7 @@ type MediaTypeMessageTemplate struct {
 	// 2 - Autoregistration;
 	// 3 - Internal;
 	// 4 - Services.
-	EventSource int `json:"eventsource,string"`
+	EventSource EventSource `json:"eventsource"`
 
 	// Operation mode.
 	//
@@ -254,7 +259,7 @@ type MediaTypeMessageTemplate struct {
 	// 0 - Operations;
 	// 1 - Recovery operations;
 	// 2 - Update operations.
-	Recovery int `json:"recovery,string"`
+	Recovery types.ZBXInt `json:"recovery"`
 
 	// Message subject.
 	Subject string `json:"subject"`
@@ -282,7 +287,7 @@ type Media struct {
 	// Possible values:
 	// 0 - (default) enabled;
 	// 1 - disabled.
-	Active int `json:"active,string"`
+	Active types.ZBXInt `json:"active"`
 
 	// Trigger severities to send notifications about.
 	//
@@ -297,9 +302,10 @@ type Media struct {
 	// This is a bitmask field; any sum of possible bitmap values is acceptable (for example, 48 for Average, High, and Disaster).
 	//
 	// Default: 63.
-	Severity int `json:"severity,string"`
+	Severity SeverityMask `json:"severity"`
 
 	// Time when the notifications can be sent as a time period or user macros separated by a semicolon.
+	// It can be parsed and evaluated with ParsedPeriod.
 	//
 	// Default: 1-7,00:00-24:00.
 	Period string `json:"period"`
@@ -309,12 +315,25 @@ type Media struct {
 	// Possible values:
 	// 0 - not provisioned;
 	// 1 - provisioned.
-	Provisioned int `json:"provisioned,string"`
+	Provisioned types.ZBXInt `json:"provisioned"`
 
 	// User directory media mapping ID for provisioned media.
 	UserDirectoryMediaId string `json:"userdirectory_mediaid"`
 }
 
+// mediaDefaultPeriod is the Period of Media which do not set one.
+const mediaDefaultPeriod = "1-7,00:00-24:00"
+
+// ParsedPeriod parses the Period of the Media, or the default period if it is
+// empty. Periods holding user macros must be expanded with
+// schedule.Period.Expand before they are evaluated.
+func (m Media) ParsedPeriod() (schedule.Period, error) {
+	if m.Period == "" {
+		return schedule.ParsePeriod(mediaDefaultPeriod)
+	}
+	return schedule.ParsePeriod(m.Period)
+}
+
 // MediaTypeGetParams is params for mediatype.get query
 type MediaTypeGetParams struct {
 	GetParameters
@@ -0,0 +1,36 @@
+package zabbix_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+)
+
+func TestMediaParsedPeriod(t *testing.T) {
+	tests := map[string]string{
+		"1-5,09:00-18:00;6-7,10:00-16:00": "1-5,09:00-18:00;6-7,10:00-16:00",
+		"":                                "1-7,00:00-24:00",
+	}
+
+	for period, expected := range tests {
+		p, err := zabbix.Media{Period: period}.ParsedPeriod()
+		if err != nil {
+			t.Errorf("Error parsing period %q: %v", period, err)
+			continue
+		}
+		if p.String() != expected {
+			t.Errorf("Expected period %s but got %s", expected, p)
+		}
+	}
+
+	// Saturday 2024-05-04 12:00 UTC
+	p, _ := zabbix.Media{Period: "1-5,09:00-18:00"}.ParsedPeriod()
+	if active, err := p.IsActive(time.Date(2024, 5, 4, 12, 0, 0, 0, time.UTC)); err != nil || active {
+		t.Errorf("Expected the period to be inactive on Saturday but got %v, %v", active, err)
+	}
+
+	if _, err := (zabbix.Media{Period: "8,00:00-24:00"}).ParsedPeriod(); err == nil {
+		t.Errorf("Expected an error for an invalid period")
+	}
+}
@@ -1,18 +1,18 @@
 package zabbix
 
-import (
-	"bytes"
-	"encoding/json"
+import "github.com/NexonSU/go-zabbix/types"
 
-	"github.com/NexonSU/go-zabbix/types"
-)
+// ProxyStatus is whether a Proxy is active or passive.
+type ProxyStatus int
 
 const (
 	// ProxyStatusActive active proxy
-	ProxyStatusActive = 5
+	ProxyStatusActive ProxyStatus = 5
 	// ProxyStatusPassive passive proxy
-	ProxyStatusPassive = 6
+	ProxyStatusPassive ProxyStatus = 6
+)
 
+const (
 	// ProxyTLSConnectUnencryped connect unencrypted to or from host
 	ProxyTLSConnectUnencryped = 1
 
@@ -23,18 +23,51 @@ const (
 	ProxyTLSConnectCertificate = 4
 )
 
+var proxyStatuses = enum{"ProxyStatus", map[int]string{
+	int(ProxyStatusActive):  "active",
+	int(ProxyStatusPassive): "passive",
+}}
+
+// String returns the name of the ProxyStatus.
+func (s ProxyStatus) String() string {
+	return proxyStatuses.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ProxyStatus) MarshalText() ([]byte, error) {
+	return proxyStatuses.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *ProxyStatus) UnmarshalText(text []byte) error {
+	return proxyStatuses.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s ProxyStatus) MarshalJSON() ([]byte, error) {
+	return proxyStatuses.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *ProxyStatus) UnmarshalJSON(data []byte) error {
+	return proxyStatuses.unmarshalJSON(data, (*int)(s))
+}
+
 // Proxy Proxy infomation returned from Zabbix API
 type Proxy struct {
-	ProxyID     string `json:"proxyid"`
-	Host        string `json:"host"`
-	Status      int    `json:"status,string"`
-	Description string `json:"description"`
+	ProxyID     string      `json:"proxyid"`
+	Host        string      `json:"host"`
+	Status      ProxyStatus `json:"status"`
+	Description string      `json:"description"`
 
 	// How should we connect to proxy
-	TLSConnect int `json:"tls_connect,string"`
+	TLSConnect types.ZBXInt `json:"tls_connect"`
 
 	// What type of connections we accept from proxy
-	TLSAccept int `json:"tls_accept,string"`
+	TLSAccept types.ZBXInt `json:"tls_accept"`
 
 	TLSIssuer  string `json:"tls_issuer"`
 	TLSSubject string `json:"tls_subject"`
@@ -64,19 +97,17 @@ type ProxyInterface struct {
 	IP string `json:"ip"`
 
 	// Whether the connection should be made via IP.
-	UseIP types.ZBXBoolean `json:"useip,string"`
+	UseIP types.ZBXBoolean `json:"useip"`
 
-	Port int `json:"port,string"`
+	Port types.ZBXInt `json:"port"`
 }
 
-// Handle PHPs empty associative arrays are arrays when turning them into JSON
+// UnmarshalJSON decodes the interface, which PHP returns as an empty array for
+// proxies without interface.
 func (t *ProxyInterface) UnmarshalJSON(in []byte) error {
-	if bytes.Equal(in, []byte("[]")) {
-		return nil
-	}
-
-	m := (*ProxyInterface)(t)
-	return json.Unmarshal(in, m)
+	// proxyInterface has no UnmarshalJSON method, to not call this one again
+	type proxyInterface ProxyInterface
+	return types.UnmarshalObject(in, (*proxyInterface)(t))
 }
 
 type ProxyGetParams struct {
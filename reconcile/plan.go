@@ -0,0 +1,188 @@
+package reconcile
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the type of a Zabbix object, named like its API methods.
+type Kind string
+
+const (
+	KindHostGroup     Kind = "hostgroup"
+	KindTemplateGroup Kind = "templategroup"
+	KindTemplate      Kind = "template"
+	KindHost          Kind = "host"
+	KindMaintenance   Kind = "maintenance"
+)
+
+// idsKey returns the key of the IDs returned by create, update and delete
+// methods of the Kind.
+func (k Kind) idsKey() string {
+	switch k {
+	case KindHostGroup, KindTemplateGroup:
+		return "groupids"
+	default:
+		return string(k) + "ids"
+	}
+}
+
+// Action is the change made to an object.
+type Action int
+
+const (
+	// ActionCreate creates a missing object.
+	ActionCreate Action = iota
+
+	// ActionUpdate updates an object which differs from its desired state.
+	ActionUpdate
+
+	// ActionDelete deletes an object which is desired to be absent.
+	ActionDelete
+)
+
+// String returns the name of the API method of the Action.
+func (a Action) String() string {
+	switch a {
+	case ActionCreate:
+		return "create"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+func (a Action) symbol() string {
+	switch a {
+	case ActionCreate:
+		return "+"
+	case ActionUpdate:
+		return "~"
+	}
+	return "-"
+}
+
+// Diff is a difference between a field of an object and its desired state.
+type Diff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Change is a change of a single object.
+type Change struct {
+	Action Action
+	Kind   Kind
+	Name   string
+
+	// ID is the ID of the changed object. It is set for created objects once
+	// the Change is applied.
+	ID string
+
+	// Diffs are the changed fields of an updated object.
+	Diffs []Diff
+
+	// params builds the API method params once the IDs of the referenced
+	// objects are known.
+	params func(ids idMap) (interface{}, error)
+}
+
+// Method returns the API method applying the Change.
+func (c *Change) Method() string {
+	return string(c.Kind) + "." + c.Action.String()
+}
+
+// String returns a human readable description of the Change.
+func (c *Change) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s %q\n", c.Action.symbol(), c.Kind, c.Name)
+	for _, d := range c.Diffs {
+		fmt.Fprintf(&b, "    %s: %s => %s\n", d.Field, d.Old, d.New)
+	}
+	return b.String()
+}
+
+// Plan is the list of changes required to reach a desired State, in the order
+// they are applied. Objects are created and updated before the objects
+// referring to them, and deleted after.
+type Plan struct {
+	Changes []Change
+
+	// ids are the IDs of existing objects by kind and name, completed with
+	// the IDs of created objects as the plan is applied.
+	ids idMap
+}
+
+// Empty returns true if the desired State is already reached.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns the diff of all changes of the Plan.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	var b strings.Builder
+	for i := range p.Changes {
+		b.WriteString(p.Changes[i].String())
+	}
+	return b.String()
+}
+
+// ChangeError describes a Change which failed to apply. Changes before it in
+// the Plan have been applied.
+type ChangeError struct {
+	Change *Change
+	Err    error
+}
+
+func (e *ChangeError) Error() string {
+	return fmt.Sprintf("Failed to %s %s %q: %v", e.Change.Action, e.Change.Kind, e.Change.Name, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ChangeError) Unwrap() error {
+	return e.Err
+}
+
+// idMap holds object IDs by kind and name.
+type idMap map[Kind]map[string]string
+
+func (m idMap) set(kind Kind, name, id string) {
+	if m[kind] == nil {
+		m[kind] = make(map[string]string)
+	}
+	m[kind][name] = id
+}
+
+// list returns the IDs of the named objects.
+func (m idMap) list(kind Kind, names []string) ([]string, error) {
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		id, ok := m[kind][name]
+		if !ok {
+			return nil, fmt.Errorf("Unknown %s %q", kind, name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// objects returns the list of {"<key>": id} objects referring to the named
+// objects.
+func (m idMap) objects(kind Kind, key string, names []string) ([]map[string]string, error) {
+	ids, err := m.list(kind, names)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]map[string]string, 0, len(ids))
+	for _, id := range ids {
+		objects = append(objects, map[string]string{key: id})
+	}
+	return objects, nil
+}
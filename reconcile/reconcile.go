@@ -0,0 +1,1079 @@
+// Package reconcile brings the objects of a Zabbix server to a desired State
+// described in Go or YAML.
+//
+// A Reconciler reads the current state of the described objects with the
+// get wrappers of the zabbix package, computes a Plan of the required creates,
+// updates and deletes and applies it in dependency order:
+//
+//	state, err := reconcile.LoadFile("zabbix.yaml")
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	r := reconcile.New(session)
+//	plan, err := r.Plan(state)
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	fmt.Print(plan)
+//	if err := r.Apply(plan); err != nil {
+//		panic(err)
+//	}
+package reconcile
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+var (
+	zabbixVersion600 *types.ZBXVersion
+	zabbixVersion620 *types.ZBXVersion
+
+	timePeriodTypes = map[string]zabbix.MaintenanceTimeperiodType{
+		TimePeriodOnce:    zabbix.MaintenanceTimeperiodOnce,
+		TimePeriodDaily:   zabbix.MaintenanceTimeperiodDaily,
+		TimePeriodWeekly:  zabbix.MaintenanceTimeperiodWeekly,
+		TimePeriodMonthly: zabbix.MaintenanceTimeperiodMonthly,
+	}
+)
+
+func init() {
+	zabbixVersion600, _ = types.NewZBXVersion("6.0.0")
+	zabbixVersion620, _ = types.NewZBXVersion("6.2.0")
+}
+
+// Reconciler plans and applies the changes bringing a Zabbix server to a
+// desired State.
+type Reconciler struct {
+	Session *zabbix.Session
+
+	// DryRun causes Reconcile to return the Plan without applying it.
+	DryRun bool
+}
+
+// New returns a Reconciler for the given Session.
+func New(session *zabbix.Session) *Reconciler {
+	return &Reconciler{Session: session}
+}
+
+// Reconcile plans the changes required to reach the desired State and
+// applies them, unless DryRun is set. The Plan is returned even if applying it
+// fails, a ChangeError tells which Change failed.
+func (r *Reconciler) Reconcile(desired *State) (*Plan, error) {
+	plan, err := r.Plan(desired)
+	if err != nil || r.DryRun {
+		return plan, err
+	}
+
+	return plan, r.Apply(plan)
+}
+
+// Plan computes the changes required to reach the desired State, without
+// changing anything.
+//
+// An error is returned if the State is invalid, refers to unknown objects or
+// if a transport, parsing or API error occurs.
+func (r *Reconciler) Plan(desired *State) (*Plan, error) {
+	if err := desired.Validate(); err != nil {
+		return nil, err
+	}
+
+	ver, err := r.Session.GetVersion()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve Zabbix API version: %v", err)
+	}
+
+	p := &planner{
+		session:       r.Session,
+		desired:       desired,
+		templateGroup: KindTemplateGroup,
+		splitGroups:   ver.Compare(zabbixVersion620) >= 0,
+		linkObjects:   ver.Compare(zabbixVersion600) >= 0,
+		ids:           make(idMap),
+		known:         make(map[Kind]map[string]bool),
+	}
+	if !p.splitGroups {
+		// template groups are host groups before Zabbix 6.2
+		p.templateGroup = KindHostGroup
+	}
+
+	if err := p.fetch(); err != nil {
+		return nil, err
+	}
+
+	if err := p.plan(); err != nil {
+		return nil, err
+	}
+
+	return &Plan{Changes: p.changes, ids: p.ids}, nil
+}
+
+// Apply applies the changes of a Plan made by the Reconciler in order. It
+// stops at the first Change which fails and returns a ChangeError.
+func (r *Reconciler) Apply(plan *Plan) error {
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+
+		params, err := change.params(plan.ids)
+		if err != nil {
+			return &ChangeError{Change: change, Err: err}
+		}
+
+		ids, err := r.Session.GetIDs(change.Method(), params, change.Kind.idsKey())
+		if err != nil {
+			return &ChangeError{Change: change, Err: err}
+		}
+
+		switch change.Action {
+		case ActionCreate:
+			if len(ids) == 0 {
+				return &ChangeError{Change: change, Err: fmt.Errorf("No ID returned")}
+			}
+			change.ID = ids[0]
+			plan.ids.set(change.Kind, change.Name, change.ID)
+		case ActionDelete:
+			delete(plan.ids[change.Kind], change.Name)
+		}
+	}
+
+	return nil
+}
+
+// planner computes a Plan.
+type planner struct {
+	session *zabbix.Session
+	desired *State
+
+	// templateGroup is the kind of the groups of templates.
+	templateGroup Kind
+	splitGroups   bool
+
+	// linkObjects is whether maintenances refer to hosts and groups with
+	// objects instead of IDs, as they do since Zabbix 6.0.
+	linkObjects bool
+
+	templates    map[string]zabbix.Template
+	hosts        map[string]zabbix.Host
+	maintenances map[string]zabbix.Maintenance
+
+	// ids are the IDs of the existing objects.
+	ids idMap
+
+	// known are the objects which exist or are created by the plan, and may
+	// be referred to.
+	known map[Kind]map[string]bool
+
+	changes []Change
+	deletes [][]Change
+}
+
+// fetch reads the existing objects which are described by, or referred to by
+// the desired State.
+func (p *planner) fetch() error {
+	hostGroups, templateGroups, templates, hosts := nameSet{}, nameSet{}, nameSet{}, nameSet{}
+	maintenances := nameSet{}
+
+	for _, g := range p.desired.HostGroups {
+		hostGroups.add(g.Name)
+	}
+	tgroups := templateGroups
+	if !p.splitGroups {
+		tgroups = hostGroups
+	}
+	for _, g := range p.desired.TemplateGroups {
+		tgroups.add(g.Name)
+	}
+	for _, t := range p.desired.Templates {
+		templates.add(t.Name)
+		templates.add(t.Templates...)
+		tgroups.add(t.Groups...)
+	}
+	for _, h := range p.desired.Hosts {
+		hosts.add(h.Name)
+		hostGroups.add(h.Groups...)
+		templates.add(h.Templates...)
+	}
+	for _, m := range p.desired.Maintenances {
+		maintenances.add(m.Name)
+		hosts.add(m.Hosts...)
+		hostGroups.add(m.Groups...)
+	}
+
+	if len(hostGroups) > 0 {
+		groups, err := p.session.GetHostgroups(zabbix.HostgroupGetParams{
+			GetParameters: zabbix.GetParameters{
+				Filter:       map[string]interface{}{"name": hostGroups.list()},
+				OutputFields: zabbix.SelectFields{"groupid", "name"},
+			},
+		})
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		for _, g := range groups {
+			p.exists(KindHostGroup, g.Name, g.GroupID)
+		}
+	}
+
+	if len(templateGroups) > 0 {
+		groups, err := p.session.GetTemplateGroups(zabbix.TemplateGroupGetParams{
+			GetParameters: zabbix.GetParameters{
+				Filter:       map[string]interface{}{"name": templateGroups.list()},
+				OutputFields: zabbix.SelectFields{"groupid", "name"},
+			},
+		})
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		for _, g := range groups {
+			p.exists(KindTemplateGroup, g.Name, g.GroupID)
+		}
+	}
+
+	p.templates = make(map[string]zabbix.Template)
+	if len(templates) > 0 {
+		params := zabbix.TemplateGetParams{
+			GetParameters: zabbix.GetParameters{
+				Filter: map[string]interface{}{"host": templates.list()},
+			},
+			SelectMacros:          zabbix.SelectExtendedOutput,
+			SelectParentTemplates: zabbix.SelectFields{"templateid", "host"},
+		}
+		if p.splitGroups {
+			params.SelectTemplateGroups = zabbix.SelectFields{"groupid", "name"}
+		} else {
+			params.SelectGroups = zabbix.SelectFields{"groupid", "name"}
+		}
+		result, err := p.session.GetTemplates(params)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		for _, t := range result {
+			p.templates[t.Hostname] = t
+			p.exists(KindTemplate, t.Hostname, t.TemplateID)
+		}
+	}
+
+	p.hosts = make(map[string]zabbix.Host)
+	if len(hosts) > 0 {
+		params := zabbix.HostGetParams{
+			GetParameters: zabbix.GetParameters{
+				Filter: map[string]interface{}{"host": hosts.list()},
+			},
+			SelectMacros:          zabbix.SelectExtendedOutput,
+			SelectParentTemplates: zabbix.SelectFields{"templateid", "host"},
+		}
+		if p.splitGroups {
+			params.SelectHostGroups = zabbix.SelectFields{"groupid", "name"}
+		} else {
+			params.SelectGroups = zabbix.SelectFields{"groupid", "name"}
+		}
+		result, err := p.session.GetHosts(params)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		for _, h := range result {
+			p.hosts[h.Hostname] = h
+			p.exists(KindHost, h.Hostname, h.HostID)
+		}
+	}
+
+	p.maintenances = make(map[string]zabbix.Maintenance)
+	if len(maintenances) > 0 {
+		params := &zabbix.MaintenanceGetParams{
+			GetParameters: zabbix.GetParameters{
+				Filter: map[string]interface{}{"name": maintenances.list()},
+			},
+			SelectHosts:       zabbix.SelectFields{"hostid", "host"},
+			SelectTimeperiods: zabbix.SelectExtendedOutput,
+		}
+		if p.splitGroups {
+			params.SelectHostGroups = zabbix.SelectFields{"groupid", "name"}
+		} else {
+			params.SelectGroups = zabbix.SelectFields{"groupid", "name"}
+		}
+		result, err := p.session.GetMaintenance(params)
+		if err != nil && !isNotFound(err) {
+			return err
+		}
+		for _, m := range result {
+			p.maintenances[m.Name] = m
+			p.exists(KindMaintenance, m.Name, m.MaintenanceID)
+		}
+	}
+
+	return nil
+}
+
+func (p *planner) exists(kind Kind, name, id string) {
+	p.ids.set(kind, name, id)
+	p.setKnown(kind, name, true)
+}
+
+func (p *planner) setKnown(kind Kind, name string, known bool) {
+	if p.known[kind] == nil {
+		p.known[kind] = make(map[string]bool)
+	}
+	p.known[kind][name] = known
+}
+
+// plan computes the changes of all objects. Objects which are deleted are
+// forgotten first, so that desired objects can not refer to them.
+func (p *planner) plan() error {
+	p.forgetAbsent()
+
+	steps := []func() error{
+		p.planHostGroups,
+		p.planTemplateGroups,
+		p.planTemplates,
+		p.planHosts,
+		p.planMaintenances,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	// delete referring objects first
+	for i := len(p.deletes) - 1; i >= 0; i-- {
+		p.changes = append(p.changes, p.deletes[i]...)
+	}
+
+	return nil
+}
+
+func (p *planner) forgetAbsent() {
+	for _, g := range p.desired.HostGroups {
+		if g.Absent {
+			p.setKnown(KindHostGroup, g.Name, false)
+		}
+	}
+	for _, g := range p.desired.TemplateGroups {
+		if g.Absent {
+			p.setKnown(p.templateGroup, g.Name, false)
+		}
+	}
+	for _, t := range p.desired.Templates {
+		if t.Absent {
+			p.setKnown(KindTemplate, t.Name, false)
+		}
+	}
+	for _, h := range p.desired.Hosts {
+		if h.Absent {
+			p.setKnown(KindHost, h.Name, false)
+		}
+	}
+}
+
+// refer checks that the named objects exist or are created by the plan.
+func (p *planner) refer(from string, kind Kind, names []string) error {
+	for _, name := range names {
+		if !p.known[kind][name] {
+			return fmt.Errorf("%s refers to unknown %s %q", from, kind, name)
+		}
+	}
+	return nil
+}
+
+func (p *planner) create(kind Kind, name string, params func(ids idMap) (interface{}, error)) {
+	p.setKnown(kind, name, true)
+	p.changes = append(p.changes, Change{
+		Action: ActionCreate,
+		Kind:   kind,
+		Name:   name,
+		params: params,
+	})
+}
+
+func (p *planner) update(kind Kind, name string, diffs []Diff, params func(ids idMap) (interface{}, error)) {
+	if len(diffs) == 0 {
+		return
+	}
+	p.changes = append(p.changes, Change{
+		Action: ActionUpdate,
+		Kind:   kind,
+		Name:   name,
+		ID:     p.ids[kind][name],
+		Diffs:  diffs,
+		params: params,
+	})
+}
+
+// remove plans the deletion of the named objects which exist.
+func (p *planner) remove(kind Kind, names []string) {
+	var deletes []Change
+	for _, name := range names {
+		id, ok := p.ids[kind][name]
+		if !ok {
+			continue
+		}
+		deletes = append(deletes, Change{
+			Action: ActionDelete,
+			Kind:   kind,
+			Name:   name,
+			ID:     id,
+			params: func(idMap) (interface{}, error) {
+				return []string{id}, nil
+			},
+		})
+	}
+	p.deletes = append(p.deletes, deletes)
+}
+
+func (p *planner) planGroups(kind Kind, names []string, absent []bool) {
+	var deletes []string
+	for i, name := range names {
+		if absent[i] {
+			deletes = append(deletes, name)
+			continue
+		}
+		if _, ok := p.ids[kind][name]; ok {
+			continue
+		}
+
+		name := name
+		p.create(kind, name, func(idMap) (interface{}, error) {
+			return map[string]string{"name": name}, nil
+		})
+	}
+	p.remove(kind, deletes)
+}
+
+func (p *planner) planHostGroups() error {
+	var names []string
+	var absent []bool
+	for _, g := range p.desired.HostGroups {
+		names = append(names, g.Name)
+		absent = append(absent, g.Absent)
+	}
+	if !p.splitGroups {
+		seen := nameSet{}
+		seen.add(names...)
+		for _, g := range p.desired.TemplateGroups {
+			if !seen[g.Name] {
+				names = append(names, g.Name)
+				absent = append(absent, g.Absent)
+			}
+		}
+	}
+
+	p.planGroups(KindHostGroup, names, absent)
+	return nil
+}
+
+func (p *planner) planTemplateGroups() error {
+	if !p.splitGroups {
+		return nil
+	}
+
+	var names []string
+	var absent []bool
+	for _, g := range p.desired.TemplateGroups {
+		names = append(names, g.Name)
+		absent = append(absent, g.Absent)
+	}
+
+	p.planGroups(KindTemplateGroup, names, absent)
+	return nil
+}
+
+func (p *planner) planTemplates() error {
+	ordered, err := sortTemplates(p.desired.Templates)
+	if err != nil {
+		return err
+	}
+
+	var deletes []string
+	for _, t := range p.desired.Templates {
+		if t.Absent {
+			deletes = append(deletes, t.Name)
+		}
+	}
+
+	for _, t := range ordered {
+		desired := object{
+			name:        t.Name,
+			displayName: displayName(t.DisplayName, t.Name),
+			description: t.Description,
+			groups:      t.Groups,
+			templates:   t.Templates,
+			macros:      desiredMacros(t.Macros),
+		}
+		if err := p.referObject("Template", p.templateGroup, desired); err != nil {
+			return err
+		}
+
+		current, ok := p.templates[t.Name]
+		if !ok {
+			p.create(KindTemplate, t.Name, func(ids idMap) (interface{}, error) {
+				params := map[string]interface{}{"host": desired.name}
+				return params, p.objectParams(ids, params, p.templateGroup, desired, nil)
+			})
+			continue
+		}
+
+		groups := make([]string, 0)
+		if p.splitGroups {
+			for _, g := range current.TemplateGroups {
+				groups = append(groups, g.Name)
+			}
+		} else {
+			for _, g := range current.Groups {
+				groups = append(groups, g.Name)
+			}
+		}
+		templates := make([]string, 0)
+		for _, parent := range current.ParentTemplates {
+			templates = append(templates, parent.Hostname)
+		}
+
+		currentMacros := macroMap(current.Macros)
+		desired.macros = keepMacroTypes(desired.macros, currentMacros)
+		d := diffObject(object{
+			displayName: current.DisplayName,
+			description: current.Description,
+			groups:      groups,
+			templates:   templates,
+			macros:      currentMacros,
+		}, desired)
+		p.update(KindTemplate, t.Name, d.diffs, func(ids idMap) (interface{}, error) {
+			params := map[string]interface{}{"templateid": ids[KindTemplate][desired.name]}
+			return params, p.objectParams(ids, params, p.templateGroup, desired, d.fields)
+		})
+	}
+
+	p.remove(KindTemplate, deletes)
+	return nil
+}
+
+func (p *planner) planHosts() error {
+	var deletes []string
+	for _, h := range p.desired.Hosts {
+		if h.Absent {
+			deletes = append(deletes, h.Name)
+			continue
+		}
+
+		desired := object{
+			name:        h.Name,
+			displayName: displayName(h.DisplayName, h.Name),
+			description: h.Description,
+			groups:      h.Groups,
+			templates:   h.Templates,
+			macros:      desiredMacros(h.Macros),
+		}
+		if err := p.referObject("Host", KindHostGroup, desired); err != nil {
+			return err
+		}
+
+		status := zabbix.HostStatusMonitored
+		if h.Disabled {
+			status = zabbix.HostStatusUnmonitored
+		}
+
+		current, ok := p.hosts[h.Name]
+		if !ok {
+			p.create(KindHost, h.Name, func(ids idMap) (interface{}, error) {
+				params := map[string]interface{}{"host": desired.name, "status": status}
+				return params, p.objectParams(ids, params, KindHostGroup, desired, nil)
+			})
+			continue
+		}
+
+		hostGroups := current.Groups
+		if p.splitGroups {
+			hostGroups = current.HostGroups
+		}
+		groups := make([]string, 0)
+		for _, g := range hostGroups {
+			groups = append(groups, g.Name)
+		}
+		templates := make([]string, 0)
+		for _, t := range current.ParentTemplates {
+			templates = append(templates, t.Hostname)
+		}
+
+		currentMacros := macroMap(current.Macros)
+		desired.macros = keepMacroTypes(desired.macros, currentMacros)
+		d := diffObject(object{
+			displayName: current.DisplayName,
+			description: current.Description,
+			groups:      groups,
+			templates:   templates,
+			macros:      currentMacros,
+		}, desired)
+		d.string("status", current.Status.String(), status.String())
+		p.update(KindHost, h.Name, d.diffs, func(ids idMap) (interface{}, error) {
+			params := map[string]interface{}{"hostid": ids[KindHost][desired.name]}
+			if d.fields["status"] {
+				params["status"] = status
+			}
+			return params, p.objectParams(ids, params, KindHostGroup, desired, d.fields)
+		})
+	}
+
+	p.remove(KindHost, deletes)
+	return nil
+}
+
+func (p *planner) planMaintenances() error {
+	var deletes []string
+	for _, m := range p.desired.Maintenances {
+		m := m
+		if m.Absent {
+			deletes = append(deletes, m.Name)
+			continue
+		}
+
+		from := fmt.Sprintf("Maintenance %q", m.Name)
+		if err := p.refer(from, KindHostGroup, m.Groups); err != nil {
+			return err
+		}
+		if err := p.refer(from, KindHost, m.Hosts); err != nil {
+			return err
+		}
+
+		maintenanceType := 0
+		if m.WithoutData {
+			maintenanceType = 1
+		}
+		periods := make([]zabbix.MaintenanceTimeperiods, 0, len(m.TimePeriods))
+		for _, tp := range m.TimePeriods {
+			periods = append(periods, tp.timeperiod())
+		}
+
+		params := func(ids idMap) (map[string]interface{}, error) {
+			params := map[string]interface{}{
+				"name":             m.Name,
+				"description":      m.Description,
+				"maintenance_type": maintenanceType,
+				"active_since":     m.ActiveSince.Unix(),
+				"active_till":      m.ActiveTill.Unix(),
+				"timeperiods":      periods,
+			}
+
+			if !p.linkObjects {
+				hostIDs, err := ids.list(KindHost, m.Hosts)
+				if err != nil {
+					return nil, err
+				}
+				groupIDs, err := ids.list(KindHostGroup, m.Groups)
+				if err != nil {
+					return nil, err
+				}
+				params["hostids"], params["groupids"] = hostIDs, groupIDs
+				return params, nil
+			}
+
+			hosts, err := ids.objects(KindHost, "hostid", m.Hosts)
+			if err != nil {
+				return nil, err
+			}
+			groups, err := ids.objects(KindHostGroup, "groupid", m.Groups)
+			if err != nil {
+				return nil, err
+			}
+			params["hosts"], params["groups"] = hosts, groups
+			return params, nil
+		}
+
+		current, ok := p.maintenances[m.Name]
+		if !ok {
+			p.create(KindMaintenance, m.Name, func(ids idMap) (interface{}, error) {
+				return params(ids)
+			})
+			continue
+		}
+
+		hosts := make([]string, 0)
+		for _, h := range current.Hosts {
+			hosts = append(hosts, h.Hostname)
+		}
+		hostGroups := current.Groups
+		if p.splitGroups {
+			hostGroups = current.HostGroups
+		}
+		groups := make([]string, 0)
+		for _, g := range hostGroups {
+			groups = append(groups, g.Name)
+		}
+		currentPeriods := make([]string, 0)
+		for _, tp := range current.Timeperiods {
+			currentPeriods = append(currentPeriods, formatTimePeriod(tp))
+		}
+		desiredPeriods := make([]string, 0)
+		for _, tp := range periods {
+			desiredPeriods = append(desiredPeriods, formatTimePeriod(tp))
+		}
+
+		d := &differ{}
+		d.string("description", current.Description, m.Description)
+		d.string("maintenance type", maintenanceTypes[int(current.Type)], maintenanceTypes[maintenanceType])
+		d.string("active since", formatTime(time.Time(current.ActiveSince)), formatTime(m.ActiveSince))
+		d.string("active till", formatTime(time.Time(current.ActiveTill)), formatTime(m.ActiveTill))
+		d.set("hosts", hosts, m.Hosts)
+		d.set("groups", groups, m.Groups)
+		d.set("time periods", currentPeriods, desiredPeriods)
+
+		// maintenance.update replaces hosts, groups and time periods, so
+		// the whole maintenance is sent
+		p.update(KindMaintenance, m.Name, d.diffs, func(ids idMap) (interface{}, error) {
+			params, err := params(ids)
+			if err != nil {
+				return nil, err
+			}
+			params["maintenanceid"] = ids[KindMaintenance][m.Name]
+			return params, nil
+		})
+	}
+
+	p.remove(KindMaintenance, deletes)
+	return nil
+}
+
+// object holds the fields hosts and templates have in common.
+type object struct {
+	name        string
+	displayName string
+	description string
+	groups      []string
+	templates   []string
+	macros      map[string]zabbix.HostMacro
+}
+
+// referObject checks the references of a desired host or template.
+func (p *planner) referObject(kind string, groupKind Kind, o object) error {
+	from := fmt.Sprintf("%s %q", kind, o.name)
+	if err := p.refer(from, groupKind, o.groups); err != nil {
+		return err
+	}
+	return p.refer(from, KindTemplate, o.templates)
+}
+
+// objectParams sets the params of the changed fields of a host or template,
+// or of all fields if fields is nil. Linked templates and macros are only set
+// if they are managed.
+func (p *planner) objectParams(ids idMap, params map[string]interface{}, groupKind Kind, o object, fields map[string]bool) error {
+	all := fields == nil
+
+	if all || fields["name"] {
+		params["name"] = o.displayName
+	}
+	if all || fields["description"] {
+		params["description"] = o.description
+	}
+	if all || fields["groups"] {
+		groups, err := ids.objects(groupKind, "groupid", o.groups)
+		if err != nil {
+			return err
+		}
+		params["groups"] = groups
+	}
+	if o.templates != nil && (all || fields["templates"]) {
+		templates, err := ids.objects(KindTemplate, "templateid", o.templates)
+		if err != nil {
+			return err
+		}
+		params["templates"] = templates
+	}
+	if o.macros != nil && (all || fields["macros"]) {
+		params["macros"] = macroList(o.macros)
+	}
+
+	return nil
+}
+
+// diffObject compares the current and desired fields of a host or template.
+func diffObject(current, desired object) *differ {
+	d := &differ{}
+	d.string("name", current.displayName, desired.displayName)
+	d.string("description", current.description, desired.description)
+	d.set("groups", current.groups, desired.groups)
+	if desired.templates != nil {
+		d.set("templates", current.templates, desired.templates)
+	}
+	if desired.macros != nil {
+		d.macros(current.macros, desired.macros)
+	}
+	return d
+}
+
+// differ collects the differences between the current and desired fields of
+// an object.
+type differ struct {
+	diffs  []Diff
+	fields map[string]bool
+}
+
+func (d *differ) add(field, label, current, desired string) {
+	if d.fields == nil {
+		d.fields = make(map[string]bool)
+	}
+	d.fields[field] = true
+	d.diffs = append(d.diffs, Diff{Field: label, Old: current, New: desired})
+}
+
+func (d *differ) string(field, current, desired string) {
+	if current != desired {
+		d.add(field, field, fmt.Sprintf("%q", current), fmt.Sprintf("%q", desired))
+	}
+}
+
+func (d *differ) set(field string, current, desired []string) {
+	a, b := sortedCopy(current), sortedCopy(desired)
+	if strings.Join(a, "\x00") != strings.Join(b, "\x00") {
+		d.add(field, field, formatList(a), formatList(b))
+	}
+}
+
+func (d *differ) macros(current, desired map[string]zabbix.HostMacro) {
+	names := nameSet{}
+	for name := range current {
+		names.add(name)
+	}
+	for name := range desired {
+		names.add(name)
+	}
+
+	for _, name := range names.list() {
+		old, hasOld := current[name]
+		new, hasNew := desired[name]
+		switch {
+		case !hasOld:
+			d.add("macros", "macro "+name, "(none)", formatMacro(new))
+		case !hasNew:
+			d.add("macros", "macro "+name, formatMacro(old), "(none)")
+		case old.Type == zabbix.MacroTypeSecret:
+			// the values of secret macros cannot be read back
+		case old.Value != new.Value:
+			d.add("macros", "macro "+name, formatMacro(old), formatMacro(new))
+		}
+	}
+}
+
+// sortTemplates orders the desired templates so that linked templates come
+// before the templates linking them.
+func sortTemplates(templates []Template) ([]Template, error) {
+	byName := make(map[string]Template)
+	for _, t := range templates {
+		if !t.Absent {
+			byName[t.Name] = t
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	ordered := make([]Template, 0, len(byName))
+
+	var visit func(t Template) error
+	visit = func(t Template) error {
+		switch state[t.Name] {
+		case visiting:
+			return fmt.Errorf("Template %q is linked to itself", t.Name)
+		case visited:
+			return nil
+		}
+
+		state[t.Name] = visiting
+		for _, name := range t.Templates {
+			if parent, ok := byName[name]; ok {
+				if err := visit(parent); err != nil {
+					return err
+				}
+			}
+		}
+		state[t.Name] = visited
+		ordered = append(ordered, t)
+		return nil
+	}
+
+	for _, t := range templates {
+		if t.Absent {
+			continue
+		}
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+func (tp TimePeriod) timeperiod() zabbix.MaintenanceTimeperiods {
+	period := zabbix.MaintenanceTimeperiods{
+		TimeperiodType: timePeriodTypes[tp.Type],
+		Every:          types.ZBXInt(tp.Every),
+		Month:          types.ZBXInt(tp.Month),
+		Dayofweek:      types.ZBXInt(tp.DayOfWeek),
+		Day:            types.ZBXInt(tp.Day),
+		Period:         types.ZBXInt(tp.Period / time.Second),
+	}
+
+	if tp.Type == TimePeriodOnce {
+		period.StartDate = types.ZBXInt(tp.StartDate.Unix())
+	} else {
+		period.StartTime = types.ZBXInt(tp.StartTime / time.Second)
+	}
+
+	if period.Every == 0 {
+		period.Every = 1
+	}
+
+	return period
+}
+
+// formatTimePeriod describes the fields of a maintenance time period which
+// are relevant for its type.
+func formatTimePeriod(tp zabbix.MaintenanceTimeperiods) string {
+	seconds := func(s types.ZBXInt) time.Duration {
+		return time.Duration(s) * time.Second
+	}
+
+	switch tp.TimeperiodType {
+	case timePeriodTypes[TimePeriodOnce]:
+		return fmt.Sprintf("once at %s for %s", formatTime(time.Unix(int64(tp.StartDate), 0)), seconds(tp.Period))
+	case timePeriodTypes[TimePeriodDaily]:
+		return fmt.Sprintf("every %d days at %s for %s", tp.Every, seconds(tp.StartTime), seconds(tp.Period))
+	case timePeriodTypes[TimePeriodWeekly]:
+		return fmt.Sprintf("every %d weeks on days %07b at %s for %s", tp.Every, tp.Dayofweek, seconds(tp.StartTime), seconds(tp.Period))
+	case timePeriodTypes[TimePeriodMonthly]:
+		if tp.Day != 0 {
+			return fmt.Sprintf("day %d of months %012b at %s for %s", tp.Day, tp.Month, seconds(tp.StartTime), seconds(tp.Period))
+		}
+		return fmt.Sprintf("week %d days %07b of months %012b at %s for %s", tp.Every, tp.Dayofweek, tp.Month, seconds(tp.StartTime), seconds(tp.Period))
+	}
+
+	return fmt.Sprintf("%+v", tp)
+}
+
+var maintenanceTypes = map[int]string{
+	0: "with data collection",
+	1: "without data collection",
+}
+
+func displayName(display, name string) string {
+	if display == "" {
+		return name
+	}
+	return display
+}
+
+func macroMap(macros []zabbix.HostMacro) map[string]zabbix.HostMacro {
+	m := make(map[string]zabbix.HostMacro)
+	for _, macro := range macros {
+		m[macro.Macro] = macro
+	}
+	return m
+}
+
+// desiredMacros returns the desired macros of a State, which are text macros.
+func desiredMacros(values map[string]string) map[string]zabbix.HostMacro {
+	if values == nil {
+		return nil
+	}
+
+	m := make(map[string]zabbix.HostMacro)
+	for name, value := range values {
+		m[name] = zabbix.HostMacro{Macro: name, Value: value}
+	}
+	return m
+}
+
+// keepMacroTypes returns the desired macros with the types of the current
+// macros, so that secret and vault macros are not turned into text macros.
+// Current secret macros are referred to by ID, as their value cannot be read
+// back and is kept.
+func keepMacroTypes(desired, current map[string]zabbix.HostMacro) map[string]zabbix.HostMacro {
+	if desired == nil {
+		return nil
+	}
+
+	m := make(map[string]zabbix.HostMacro)
+	for name, macro := range desired {
+		if old, ok := current[name]; ok {
+			macro.Type = old.Type
+			if old.Type == zabbix.MacroTypeSecret {
+				macro = zabbix.HostMacro{HostMacroID: old.HostMacroID, Macro: name, Type: old.Type}
+			}
+		}
+		m[name] = macro
+	}
+	return m
+}
+
+func macroList(macros map[string]zabbix.HostMacro) []map[string]string {
+	names := nameSet{}
+	for name := range macros {
+		names.add(name)
+	}
+
+	list := make([]map[string]string, 0, len(macros))
+	for _, name := range names.list() {
+		macro := macros[name]
+		switch {
+		case macro.HostMacroID != "":
+			list = append(list, map[string]string{"hostmacroid": macro.HostMacroID})
+		case macro.Type != zabbix.MacroTypeText:
+			list = append(list, map[string]string{"macro": name, "value": macro.Value, "type": strconv.Itoa(int(macro.Type))})
+		default:
+			list = append(list, map[string]string{"macro": name, "value": macro.Value})
+		}
+	}
+	return list
+}
+
+// formatMacro describes the value of a macro, hiding secret values.
+func formatMacro(macro zabbix.HostMacro) string {
+	if macro.Type == zabbix.MacroTypeSecret {
+		return "(secret)"
+	}
+	return fmt.Sprintf("%q", macro.Value)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatList(names []string) string {
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func sortedCopy(names []string) []string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func isNotFound(err error) bool {
+	_, ok := err.(*zabbix.NotFoundError)
+	return ok
+}
+
+// nameSet is a set of object names.
+type nameSet map[string]bool
+
+func (s nameSet) add(names ...string) {
+	for _, name := range names {
+		s[name] = true
+	}
+}
+
+func (s nameSet) list() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,422 @@
+package reconcile_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/reconcile"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+const stateYAML = `
+host_groups:
+  - name: Linux servers
+  - name: Web servers
+  - name: Old servers
+    absent: true
+template_groups:
+  - name: Templates/Web
+templates:
+  - name: Nginx
+    groups: [Templates/Web]
+  - name: Web app
+    display_name: Web application
+    groups: [Templates/Web]
+    templates: [Nginx]
+hosts:
+  - name: web01
+    description: Frontend
+    groups: [Linux servers, Web servers]
+    templates: [Web app]
+    macros:
+      "{$PORT}": "8080"
+  - name: web02
+    groups: [Web servers]
+  - name: old01
+    absent: true
+maintenances:
+  - name: Patching
+    active_since: 2024-05-01T00:00:00Z
+    active_till: 2024-06-01T00:00:00Z
+    hosts: [web02]
+    time_periods:
+      - type: weekly
+        day_of_week: 64
+        start_time: 2h
+        period: 1h30m
+`
+
+// newServer returns a fake API server holding the current state used by the
+// tests. Write methods return sequential IDs starting at 100.
+func newServer(t *testing.T) *test.Server {
+	server := test.NewServer(t)
+	server.Handle("hostgroup.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{"groupid":"2","name":"Linux servers"},{"groupid":"9","name":"Old servers"}]`), nil
+	})
+	server.Handle("templategroup.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[]`), nil
+	})
+	server.Handle("template.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[]`), nil
+	})
+	server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[
+			{"hostid":"10084","host":"web01","name":"web01","description":"","status":"0",
+				"hostgroups":[{"groupid":"2","name":"Linux servers"}],
+				"parentTemplates":[],
+				"macros":[{"hostmacroid":"1","hostid":"10084","macro":"{$PORT}","value":"80"},{"hostmacroid":"2","hostid":"10084","macro":"{$OLD}","value":"1"}]},
+			{"hostid":"10099","host":"old01","name":"old01","description":"","status":"0","hostgroups":[],"parentTemplates":[],"macros":[]}
+		]`), nil
+	})
+	server.Handle("maintenance.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[]`), nil
+	})
+
+	id := 100
+	for _, kind := range []string{"hostgroup", "templategroup", "template", "host", "maintenance"} {
+		key := kind + "ids"
+		if strings.HasSuffix(kind, "group") {
+			key = "groupids"
+		}
+		for _, action := range []string{"create", "update", "delete"} {
+			key := key
+			server.Handle(kind+"."+action, func(params json.RawMessage) (interface{}, error) {
+				id++
+				return map[string][]string{key: {fmt.Sprint(id - 1)}}, nil
+			})
+		}
+	}
+
+	return server
+}
+
+func loadState(t *testing.T) *reconcile.State {
+	state, err := reconcile.Load(strings.NewReader(stateYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+// writes returns the write calls received by the server as method and
+// params.
+func writes(server *test.Server) []string {
+	var calls []string
+	for _, call := range server.Calls() {
+		if !strings.HasSuffix(call.Method, ".get") && call.Method != "apiinfo.version" {
+			calls = append(calls, call.Method+" "+string(call.Params.(json.RawMessage)))
+		}
+	}
+	return calls
+}
+
+func TestLoad(t *testing.T) {
+	state := loadState(t)
+
+	if len(state.Hosts) != 3 || state.Hosts[0].Macros["{$PORT}"] != "8080" {
+		t.Fatalf("Unexpected hosts: %+v", state.Hosts)
+	}
+
+	period := state.Maintenances[0].TimePeriods[0]
+	if period.StartTime != 2*time.Hour || period.Period != 90*time.Minute || period.DayOfWeek != 64 {
+		t.Errorf("Unexpected time period: %+v", period)
+	}
+
+	if !state.Maintenances[0].ActiveSince.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected active since: %v", state.Maintenances[0].ActiveSince)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]string{
+		"hosts: [{name: a, groups: [g]}, {name: a, groups: [g]}]": `Duplicate host "a"`,
+		"hosts: [{name: a}]":                   `Host "a" has no groups`,
+		"templates: [{groups: [g]}]":           "Missing template name",
+		"host_groups: [{name: a, color: red}]": "field color not found",
+		"maintenances: [{name: m, hosts: [a], time_periods: [{type: once, period: 1h}]}]":                                                                          "must be active till after",
+		`maintenances: [{name: m, hosts: [a], active_since: 2024-05-01T00:00:00Z, active_till: 2024-05-02T00:00:00Z, time_periods: [{type: yearly, period: 1h}]}]`: `unknown type "yearly"`,
+	}
+
+	for input, expected := range tests {
+		_, err := reconcile.Load(strings.NewReader(input))
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error containing %q for %s but got %v", expected, input, err)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	server := newServer(t)
+	r := reconcile.New(server.Session(t, "7.0.0"))
+
+	plan, err := r.Plan(loadState(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `+ hostgroup "Web servers"
++ templategroup "Templates/Web"
++ template "Nginx"
++ template "Web app"
+~ host "web01"
+    description: "" => "Frontend"
+    groups: [Linux servers] => [Linux servers, Web servers]
+    templates: [] => [Web app]
+    macro {$OLD}: "1" => (none)
+    macro {$PORT}: "80" => "8080"
++ host "web02"
++ maintenance "Patching"
+- host "old01"
+- hostgroup "Old servers"
+`
+	if plan.String() != expected {
+		t.Errorf("Expected plan:\n%s\nbut got:\n%s", expected, plan)
+	}
+
+	if calls := writes(server); len(calls) != 0 {
+		t.Errorf("Expected no changes while planning but got %v", calls)
+	}
+}
+
+func TestApply(t *testing.T) {
+	server := newServer(t)
+	r := reconcile.New(server.Session(t, "7.0.0"))
+
+	plan, err := r.Reconcile(loadState(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`hostgroup.create {"name":"Web servers"}`,
+		`templategroup.create {"name":"Templates/Web"}`,
+		`template.create {"description":"","groups":[{"groupid":"101"}],"host":"Nginx","name":"Nginx"}`,
+		`template.create {"description":"","groups":[{"groupid":"101"}],"host":"Web app","name":"Web application","templates":[{"templateid":"102"}]}`,
+		`host.update {"description":"Frontend","groups":[{"groupid":"2"},{"groupid":"100"}],"hostid":"10084","macros":[{"macro":"{$PORT}","value":"8080"}],"templates":[{"templateid":"103"}]}`,
+		`host.create {"description":"","groups":[{"groupid":"100"}],"host":"web02","name":"web02","status":"0"}`,
+		`maintenance.create {"active_since":1714521600,"active_till":1717200000,"description":"","groups":[],"hosts":[{"hostid":"105"}],"maintenance_type":0,"name":"Patching","timeperiods":[{"timeperiod_type":"3","every":"1","dayofweek":"64","start_time":"7200","period":"5400"}]}`,
+		`host.delete ["10099"]`,
+		`hostgroup.delete ["9"]`,
+	}
+
+	calls := writes(server)
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected calls:\n%s\nbut got:\n%s", strings.Join(expected, "\n"), strings.Join(calls, "\n"))
+	}
+
+	if plan.Changes[0].ID != "100" {
+		t.Errorf("Expected created host group ID 100 but got %q", plan.Changes[0].ID)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	server := newServer(t)
+	r := reconcile.New(server.Session(t, "7.0.0"))
+	r.DryRun = true
+
+	plan, err := r.Reconcile(loadState(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plan.Empty() {
+		t.Errorf("Expected changes")
+	}
+
+	if calls := writes(server); len(calls) != 0 {
+		t.Errorf("Expected no changes in dry-run mode but got %v", calls)
+	}
+}
+
+func TestApplyLegacyGroups(t *testing.T) {
+	server := newServer(t)
+	r := reconcile.New(server.Session(t, "6.0.0"))
+
+	state := &reconcile.State{
+		TemplateGroups: []reconcile.TemplateGroup{{Name: "Templates/Web"}},
+		Templates:      []reconcile.Template{{Name: "Nginx", Groups: []string{"Templates/Web"}}},
+	}
+	if _, err := r.Reconcile(state); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`hostgroup.create {"name":"Templates/Web"}`,
+		`template.create {"description":"","groups":[{"groupid":"100"}],"host":"Nginx","name":"Nginx"}`,
+	}
+	if calls := writes(server); strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected calls:\n%s\nbut got:\n%s", strings.Join(expected, "\n"), strings.Join(calls, "\n"))
+	}
+
+	for _, call := range server.Calls() {
+		if call.Method == "templategroup.get" {
+			t.Errorf("Template groups do not exist before Zabbix 6.2")
+		}
+	}
+}
+
+func TestApplyLegacyMaintenance(t *testing.T) {
+	server := newServer(t)
+	r := reconcile.New(server.Session(t, "5.4.0"))
+
+	state, err := reconcile.Load(strings.NewReader(`
+maintenances:
+  - name: Patching
+    active_since: 2024-05-01T00:00:00Z
+    active_till: 2024-06-01T00:00:00Z
+    hosts: [web01]
+    groups: [Linux servers]
+    time_periods:
+      - type: daily
+        start_time: 2h
+        period: 1h
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(state); err != nil {
+		t.Fatal(err)
+	}
+
+	// hosts and groups objects replaced the IDs in Zabbix 6.0
+	expected := []string{
+		`maintenance.create {"active_since":1714521600,"active_till":1717200000,"description":"","groupids":["2"],"hostids":["10084"],"maintenance_type":0,"name":"Patching","timeperiods":[{"timeperiod_type":"2","every":"1","dayofweek":"0","start_time":"7200","period":"3600"}]}`,
+	}
+	if calls := writes(server); strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected calls:\n%s\nbut got:\n%s", strings.Join(expected, "\n"), strings.Join(calls, "\n"))
+	}
+}
+
+func TestApplyMacroTypes(t *testing.T) {
+	server := newServer(t)
+	server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[
+			{"hostid":"10084","host":"web01","name":"web01","description":"","status":"0",
+				"hostgroups":[{"groupid":"2","name":"Linux servers"}],
+				"parentTemplates":[],
+				"macros":[
+					{"hostmacroid":"1","hostid":"10084","macro":"{$PORT}","value":"80","type":"0"},
+					{"hostmacroid":"3","hostid":"10084","macro":"{$PASSWORD}","type":"1"},
+					{"hostmacroid":"4","hostid":"10084","macro":"{$TOKEN}","value":"secret/zabbix:token","type":"2"}
+				]}
+		]`), nil
+	})
+	r := reconcile.New(server.Session(t, "7.0.0"))
+
+	host := reconcile.Host{
+		Name:   "web01",
+		Groups: []string{"Linux servers"},
+		Macros: map[string]string{"{$PORT}": "80", "{$PASSWORD}": "hunter2", "{$TOKEN}": "secret/zabbix:token"},
+	}
+
+	// secret values are not read back, so they are not compared
+	plan, err := r.Plan(&reconcile.State{Hosts: []reconcile.Host{host}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !plan.Empty() {
+		t.Errorf("Expected no changes but got:\n%s", plan)
+	}
+
+	host.Macros["{$TOKEN}"] = "secret/zabbix:other"
+	plan, err = r.Reconcile(&reconcile.State{Hosts: []reconcile.Host{host}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPlan := `~ host "web01"
+    macro {$TOKEN}: "secret/zabbix:token" => "secret/zabbix:other"
+`
+	if plan.String() != expectedPlan {
+		t.Errorf("Expected plan:\n%s\nbut got:\n%s", expectedPlan, plan)
+	}
+
+	// the secret macro is kept by ID and the vault macro keeps its type
+	expected := []string{
+		`host.update {"hostid":"10084","macros":[{"hostmacroid":"3"},{"macro":"{$PORT}","value":"80"},{"macro":"{$TOKEN}","type":"2","value":"secret/zabbix:other"}]}`,
+	}
+	if calls := writes(server); strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected calls:\n%s\nbut got:\n%s", strings.Join(expected, "\n"), strings.Join(calls, "\n"))
+	}
+}
+
+func TestPlanNoChanges(t *testing.T) {
+	server := newServer(t)
+	r := reconcile.New(server.Session(t, "7.0.0"))
+
+	plan, err := r.Plan(&reconcile.State{
+		Hosts: []reconcile.Host{{
+			Name:   "web01",
+			Groups: []string{"Linux servers"},
+			Macros: map[string]string{"{$PORT}": "80", "{$OLD}": "1"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !plan.Empty() || plan.String() != "No changes.\n" {
+		t.Errorf("Expected no changes but got:\n%s", plan)
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	tests := map[string]*reconcile.State{
+		`Host "web03" refers to unknown hostgroup "Missing"`: {
+			Hosts: []reconcile.Host{{Name: "web03", Groups: []string{"Missing"}}},
+		},
+		`Host "web03" refers to unknown hostgroup "Old servers"`: {
+			HostGroups: []reconcile.HostGroup{{Name: "Old servers", Absent: true}},
+			Hosts:      []reconcile.Host{{Name: "web03", Groups: []string{"Old servers"}}},
+		},
+		`Template "A" is linked to itself`: {
+			TemplateGroups: []reconcile.TemplateGroup{{Name: "T"}},
+			Templates: []reconcile.Template{
+				{Name: "A", Groups: []string{"T"}, Templates: []string{"B"}},
+				{Name: "B", Groups: []string{"T"}, Templates: []string{"A"}},
+			},
+		},
+	}
+
+	for expected, state := range tests {
+		server := newServer(t)
+		_, err := reconcile.New(server.Session(t, "7.0.0")).Plan(state)
+		if err == nil || err.Error() != expected {
+			t.Errorf("Expected error %q but got %v", expected, err)
+		}
+	}
+}
+
+func TestApplyError(t *testing.T) {
+	server := newServer(t)
+	server.Handle("host.create", func(params json.RawMessage) (interface{}, error) {
+		return nil, &zabbix.APIError{Code: -32602, Message: "Invalid params.", Data: `Host with the same name "web02" already exists.`}
+	})
+	r := reconcile.New(server.Session(t, "7.0.0"))
+
+	_, err := r.Reconcile(loadState(t))
+
+	var changeErr *reconcile.ChangeError
+	if !errors.As(err, &changeErr) {
+		t.Fatalf("Expected a ChangeError but got %v", err)
+	}
+
+	if changeErr.Change.Kind != reconcile.KindHost || changeErr.Change.Name != "web02" {
+		t.Errorf("Unexpected failed change: %+v", changeErr.Change)
+	}
+
+	if !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Expected the API error to be wrapped but got %v", err)
+	}
+
+	for _, call := range writes(server) {
+		if strings.HasPrefix(call, "maintenance.") || strings.HasSuffix(call, ".delete") {
+			t.Errorf("Unexpected change after the failed change: %s", call)
+		}
+	}
+}
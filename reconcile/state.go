@@ -0,0 +1,265 @@
+package reconcile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State is the desired state of the objects of a Zabbix server. Objects are
+// identified by name and objects on the server which are not part of the
+// State are left alone.
+type State struct {
+	HostGroups     []HostGroup     `yaml:"host_groups,omitempty"`
+	TemplateGroups []TemplateGroup `yaml:"template_groups,omitempty"`
+	Templates      []Template      `yaml:"templates,omitempty"`
+	Hosts          []Host          `yaml:"hosts,omitempty"`
+	Maintenances   []Maintenance   `yaml:"maintenances,omitempty"`
+}
+
+// HostGroup is the desired state of a host group.
+type HostGroup struct {
+	Name string `yaml:"name"`
+
+	// Absent causes the host group to be deleted.
+	Absent bool `yaml:"absent,omitempty"`
+}
+
+// TemplateGroup is the desired state of a template group. Before Zabbix 6.2
+// template groups are host groups.
+type TemplateGroup struct {
+	Name string `yaml:"name"`
+
+	// Absent causes the template group to be deleted.
+	Absent bool `yaml:"absent,omitempty"`
+}
+
+// Template is the desired state of a template.
+type Template struct {
+	// Name is the technical name of the template.
+	Name string `yaml:"name"`
+
+	// DisplayName is the visible name of the template. It defaults to Name.
+	DisplayName string `yaml:"display_name,omitempty"`
+
+	Description string `yaml:"description,omitempty"`
+
+	// Groups are the names of the template groups of the template.
+	Groups []string `yaml:"groups,omitempty"`
+
+	// Templates are the names of the templates linked to the template. The
+	// linked templates are not managed if Templates is nil.
+	Templates []string `yaml:"templates,omitempty"`
+
+	// Macros are the user macros of the template. The macros are not
+	// managed if Macros is nil. Existing macros keep their type, and the
+	// values of secret macros are kept as they cannot be compared.
+	Macros map[string]string `yaml:"macros,omitempty"`
+
+	// Absent causes the template to be deleted.
+	Absent bool `yaml:"absent,omitempty"`
+}
+
+// Host is the desired state of a host.
+type Host struct {
+	// Name is the technical name of the host.
+	Name string `yaml:"name"`
+
+	// DisplayName is the visible name of the host. It defaults to Name.
+	DisplayName string `yaml:"display_name,omitempty"`
+
+	Description string `yaml:"description,omitempty"`
+
+	// Disabled causes the host not to be monitored.
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Groups are the names of the host groups of the host.
+	Groups []string `yaml:"groups,omitempty"`
+
+	// Templates are the names of the templates linked to the host. The linked
+	// templates are not managed if Templates is nil.
+	Templates []string `yaml:"templates,omitempty"`
+
+	// Macros are the user macros of the host. The macros are not managed if
+	// Macros is nil. Existing macros keep their type, and the values of
+	// secret macros are kept as they cannot be compared.
+	Macros map[string]string `yaml:"macros,omitempty"`
+
+	// Absent causes the host to be deleted.
+	Absent bool `yaml:"absent,omitempty"`
+}
+
+// Maintenance is the desired state of a maintenance period.
+type Maintenance struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+
+	// WithoutData causes no data to be collected during the maintenance.
+	WithoutData bool `yaml:"without_data,omitempty"`
+
+	ActiveSince time.Time `yaml:"active_since"`
+	ActiveTill  time.Time `yaml:"active_till"`
+
+	// Hosts are the names of the hosts in maintenance.
+	Hosts []string `yaml:"hosts,omitempty"`
+
+	// Groups are the names of the host groups in maintenance.
+	Groups []string `yaml:"groups,omitempty"`
+
+	TimePeriods []TimePeriod `yaml:"time_periods"`
+
+	// Absent causes the maintenance to be deleted.
+	Absent bool `yaml:"absent,omitempty"`
+}
+
+const (
+	// TimePeriodOnce is a one time maintenance period starting at StartDate.
+	TimePeriodOnce = "once"
+
+	// TimePeriodDaily is a maintenance period repeated every Every days.
+	TimePeriodDaily = "daily"
+
+	// TimePeriodWeekly is a maintenance period repeated on DayOfWeek every
+	// Every weeks.
+	TimePeriodWeekly = "weekly"
+
+	// TimePeriodMonthly is a maintenance period repeated on Day, or on
+	// DayOfWeek of the Every week, of the months in Month.
+	TimePeriodMonthly = "monthly"
+)
+
+// TimePeriod is a time period of a maintenance.
+type TimePeriod struct {
+	// Type must be one of the TimePeriod constants.
+	Type string `yaml:"type"`
+
+	// StartDate is the start of a TimePeriodOnce period.
+	StartDate time.Time `yaml:"start_date,omitempty"`
+
+	// StartTime is the time of day repeated periods start at.
+	StartTime time.Duration `yaml:"start_time,omitempty"`
+
+	// Period is the duration of the maintenance period.
+	Period time.Duration `yaml:"period"`
+
+	Every int `yaml:"every,omitempty"`
+
+	// DayOfWeek is a bitmask of week days, starting with 1 for Monday.
+	DayOfWeek int `yaml:"day_of_week,omitempty"`
+
+	// Day is the day of month.
+	Day int `yaml:"day,omitempty"`
+
+	// Month is a bitmask of months, starting with 1 for January.
+	Month int `yaml:"month,omitempty"`
+}
+
+// Load decodes a YAML or JSON encoded State and validates it.
+func Load(r io.Reader) (*State, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	state := &State{}
+	if err := dec.Decode(state); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("Error decoding state: %v", err)
+	}
+
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// LoadFile decodes a YAML or JSON encoded State from the named file and
+// validates it.
+func LoadFile(name string) (*State, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Validate checks that the State is complete and has no duplicate objects.
+// References to objects which are not part of the State are checked against
+// the server when the plan is made.
+func (s *State) Validate() error {
+	names := make(map[Kind]map[string]bool)
+	unique := func(kind Kind, name string) error {
+		if name == "" {
+			return fmt.Errorf("Missing %s name", kind)
+		}
+		if names[kind] == nil {
+			names[kind] = make(map[string]bool)
+		}
+		if names[kind][name] {
+			return fmt.Errorf("Duplicate %s %q", kind, name)
+		}
+		names[kind][name] = true
+		return nil
+	}
+
+	for _, g := range s.HostGroups {
+		if err := unique(KindHostGroup, g.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range s.TemplateGroups {
+		if err := unique(KindTemplateGroup, g.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range s.Templates {
+		if err := unique(KindTemplate, t.Name); err != nil {
+			return err
+		}
+		if !t.Absent && len(t.Groups) == 0 {
+			return fmt.Errorf("Template %q has no groups", t.Name)
+		}
+	}
+
+	for _, h := range s.Hosts {
+		if err := unique(KindHost, h.Name); err != nil {
+			return err
+		}
+		if !h.Absent && len(h.Groups) == 0 {
+			return fmt.Errorf("Host %q has no groups", h.Name)
+		}
+	}
+
+	for _, m := range s.Maintenances {
+		if err := unique(KindMaintenance, m.Name); err != nil {
+			return err
+		}
+		if m.Absent {
+			continue
+		}
+		if len(m.Hosts) == 0 && len(m.Groups) == 0 {
+			return fmt.Errorf("Maintenance %q has no hosts or groups", m.Name)
+		}
+		if !m.ActiveTill.After(m.ActiveSince) {
+			return fmt.Errorf("Maintenance %q must be active till after it is active since", m.Name)
+		}
+		if len(m.TimePeriods) == 0 {
+			return fmt.Errorf("Maintenance %q has no time periods", m.Name)
+		}
+		for _, p := range m.TimePeriods {
+			if _, ok := timePeriodTypes[p.Type]; !ok {
+				return fmt.Errorf("Maintenance %q has a time period of unknown type %q", m.Name, p.Type)
+			}
+			if p.Period <= 0 {
+				return fmt.Errorf("Maintenance %q has a time period without duration", m.Name)
+			}
+		}
+	}
+
+	return nil
+}
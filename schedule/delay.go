@@ -0,0 +1,393 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// Delay is the update interval of an item, such as
+// 1m;10s/1-5,09:00-18:00;wd1-5h9.
+type Delay struct {
+	// Interval is the default update interval, such as 1m or {$DELAY}.
+	Interval types.ZBXDuration
+
+	// Flexible are the intervals overriding Interval within time periods.
+	Flexible []Flexible
+
+	// Scheduling are the times the item is additionally checked at.
+	Scheduling []Schedule
+}
+
+// Flexible is a flexible interval, such as 10s/1-5,09:00-18:00.
+type Flexible struct {
+	// Interval is the update interval within Period. 0 disables checks.
+	Interval types.ZBXDuration
+
+	Period Range
+}
+
+// String returns the Flexible interval in the Zabbix format.
+func (f Flexible) String() string {
+	return f.Interval.String() + "/" + f.Period.String()
+}
+
+// ParseDelay parses the update interval of an item.
+func ParseDelay(s string) (*Delay, error) {
+	parts := strings.Split(s, ";")
+
+	interval, err := types.ParseDuration(parts[0])
+	if err != nil {
+		return nil, &Error{Value: s, Reason: fmt.Sprintf("invalid update interval %q", parts[0])}
+	}
+	d := &Delay{Interval: interval}
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		// flexible intervals start with a number, or with a macro
+		// followed by a slash
+		if i := strings.IndexByte(part, '/'); i > 0 && (part[0] >= '0' && part[0] <= '9' || isMacro(part[:i])) {
+			interval, err := types.ParseDuration(part[:i])
+			if err != nil {
+				return nil, &Error{Value: s, Reason: fmt.Sprintf("invalid flexible interval %q", part[:i])}
+			}
+			f := Flexible{Interval: interval}
+
+			r, err := ParseRange(part[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			f.Period = r
+
+			d.Flexible = append(d.Flexible, f)
+			continue
+		}
+
+		sched, err := ParseSchedule(part)
+		if err != nil {
+			return nil, err
+		}
+		d.Scheduling = append(d.Scheduling, *sched)
+	}
+
+	return d, nil
+}
+
+// String returns the Delay in the Zabbix format.
+func (d *Delay) String() string {
+	parts := []string{d.Interval.String()}
+	for _, f := range d.Flexible {
+		parts = append(parts, f.String())
+	}
+	for _, s := range d.Scheduling {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, ";")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Delay) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Delay) UnmarshalText(text []byte) error {
+	delay, err := ParseDelay(string(text))
+	if err != nil {
+		return err
+	}
+	*d = *delay
+	return nil
+}
+
+// IntervalAt returns the update interval at time t: the smallest of the
+// active flexible intervals, or else the default interval. A MacroError is
+// returned if an interval or a period holds a macro.
+func (d *Delay) IntervalAt(t time.Time) (time.Duration, error) {
+	if d.Interval.IsMacro() {
+		return 0, &MacroError{Macro: d.Interval.Macro}
+	}
+	interval := d.Interval.Duration
+
+	flexible := false
+	for _, f := range d.Flexible {
+		active, err := f.Period.IsActive(t)
+		if err != nil {
+			return 0, err
+		}
+		if !active {
+			continue
+		}
+
+		if f.Interval.IsMacro() {
+			return 0, &MacroError{Macro: f.Interval.Macro}
+		}
+		if !flexible || f.Interval.Duration < interval {
+			interval, flexible = f.Interval.Duration, true
+		}
+	}
+
+	return interval, nil
+}
+
+// Filter is a range of values of a Schedule, such as 1-5 or 0-59/5.
+type Filter struct {
+	From int
+	To   int
+
+	// Step is the step between the values from From. 1 if zero.
+	Step int
+}
+
+// String returns the Filter in the Zabbix format.
+func (f Filter) String() string {
+	s := strconv.Itoa(f.From)
+	if f.To != f.From {
+		s += "-" + strconv.Itoa(f.To)
+	}
+	if f.Step > 1 {
+		s += "/" + strconv.Itoa(f.Step)
+	}
+	return s
+}
+
+func (f Filter) match(n int) bool {
+	step := f.Step
+	if step < 1 {
+		step = 1
+	}
+	return n >= f.From && n <= f.To && (n-f.From)%step == 0
+}
+
+// Schedule is a scheduling interval, such as wd1-5h9m30, or a macro.
+//
+// Units without filters match any value if they are larger than the
+// smallest unit with filters, or else 0: h9 is every day at 09:00:00.
+type Schedule struct {
+	MonthDays []Filter
+	WeekDays  []Filter
+	Hours     []Filter
+	Minutes   []Filter
+	Seconds   []Filter
+
+	// Macro is the macro of the Schedule, such as {$SCHEDULE}, if not empty.
+	// The other fields are not set then.
+	Macro string
+}
+
+// units are the prefixes of the units of schedules with their bounds.
+var units = []struct {
+	prefix   string
+	min, max int
+}{
+	{"md", 1, 31},
+	{"wd", 1, 7},
+	{"h", 0, 23},
+	{"m", 0, 59},
+	{"s", 0, 59},
+}
+
+func (s *Schedule) filters() []*[]Filter {
+	return []*[]Filter{&s.MonthDays, &s.WeekDays, &s.Hours, &s.Minutes, &s.Seconds}
+}
+
+// ParseSchedule parses a scheduling interval.
+func ParseSchedule(s string) (*Schedule, error) {
+	s = strings.TrimSpace(s)
+	if isMacro(s) {
+		return &Schedule{Macro: s}, nil
+	}
+
+	sched := &Schedule{}
+	filters := sched.filters()
+
+	rest, next := s, 0
+	for rest != "" {
+		i := next
+		for i < len(units) && !strings.HasPrefix(rest, units[i].prefix) {
+			i++
+		}
+		if i == len(units) {
+			return nil, &Error{Value: s, Reason: fmt.Sprintf("unexpected %q in scheduling interval", rest)}
+		}
+
+		rest = rest[len(units[i].prefix):]
+		end := strings.IndexFunc(rest, func(r rune) bool {
+			return !(r >= '0' && r <= '9' || r == '-' || r == '/' || r == ',')
+		})
+		if end < 0 {
+			end = len(rest)
+		}
+
+		for _, text := range strings.Split(rest[:end], ",") {
+			f, err := parseFilter(text, units[i].min, units[i].max)
+			if err != nil {
+				return nil, &Error{Value: s, Reason: fmt.Sprintf("invalid %s filter %q: %v", units[i].prefix, text, err)}
+			}
+			*filters[i] = append(*filters[i], f)
+		}
+
+		rest, next = rest[end:], i+1
+	}
+
+	if next == 0 {
+		return nil, &Error{Value: s, Reason: "empty scheduling interval"}
+	}
+
+	return sched, nil
+}
+
+// parseFilter parses a filter such as 5, 1-5, 0-59/5 or /10.
+func parseFilter(s string, min, max int) (Filter, error) {
+	f := Filter{From: min, To: max, Step: 1}
+
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		step, err := strconv.Atoi(s[i+1:])
+		if err != nil || step < 1 || step > max-min+1 {
+			return f, fmt.Errorf("invalid step")
+		}
+		f.Step, s = step, s[:i]
+		if s == "" {
+			return f, nil
+		}
+	}
+
+	from, to := s, s
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		from, to = s[:i], s[i+1:]
+	} else if f.Step > 1 {
+		to = strconv.Itoa(max)
+	}
+
+	var err1, err2 error
+	f.From, err1 = strconv.Atoi(from)
+	f.To, err2 = strconv.Atoi(to)
+	if err1 != nil || err2 != nil || f.From < min || f.To > max || f.From > f.To {
+		return f, fmt.Errorf("value out of range %d-%d", min, max)
+	}
+
+	return f, nil
+}
+
+// String returns the Schedule in the Zabbix format.
+func (s *Schedule) String() string {
+	if s.Macro != "" {
+		return s.Macro
+	}
+
+	var b strings.Builder
+	for i, filters := range s.filters() {
+		if len(*filters) == 0 {
+			continue
+		}
+		b.WriteString(units[i].prefix)
+		for j, f := range *filters {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.String())
+		}
+	}
+	return b.String()
+}
+
+// matcher returns a function matching values of each unit, with the
+// defaults of units without filters.
+func (s *Schedule) matcher() []func(int) bool {
+	filters := s.filters()
+
+	smallest := 0
+	for i, f := range filters {
+		if len(*f) > 0 {
+			smallest = i
+		}
+	}
+
+	match := make([]func(int) bool, len(filters))
+	for i, f := range filters {
+		i, f := i, *f
+		switch {
+		case len(f) > 0:
+			match[i] = func(n int) bool {
+				for _, filter := range f {
+					if filter.match(n) {
+						return true
+					}
+				}
+				return false
+			}
+		case i < smallest:
+			match[i] = func(int) bool { return true }
+		default:
+			match[i] = func(n int) bool { return n == units[i].min }
+		}
+	}
+
+	// week days are not a smaller unit than month days
+	if len(s.WeekDays) == 0 && len(s.MonthDays) > 0 {
+		match[1] = func(int) bool { return true }
+	}
+
+	return match
+}
+
+// IsActive returns true if the item is scheduled to be checked at the second
+// of t, in the location of t. A MacroError is returned if the Schedule is a
+// macro.
+func (s *Schedule) IsActive(t time.Time) (bool, error) {
+	if s.Macro != "" {
+		return false, &MacroError{Macro: s.Macro}
+	}
+
+	match := s.matcher()
+	return match[0](t.Day()) && match[1](isoWeekday(t)) && match[2](t.Hour()) && match[3](t.Minute()) && match[4](t.Second()), nil
+}
+
+// Next returns the first time after t the item is scheduled to be checked at,
+// in the location of t. A zero time is returned if there is no such time
+// within 8 years, as for md31wd1 filters never matching.
+func (s *Schedule) Next(t time.Time) (time.Time, error) {
+	if s.Macro != "" {
+		return time.Time{}, &MacroError{Macro: s.Macro}
+	}
+
+	match := s.matcher()
+	start := t.Truncate(time.Second).Add(time.Second)
+	y, m, d := start.Date()
+
+	for i := 0; i < 8*366; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, t.Location())
+		if !match[0](day.Day()) || !match[1](isoWeekday(day)) {
+			continue
+		}
+
+		for h := 0; h < 24; h++ {
+			if !match[2](h) {
+				continue
+			}
+			for min := 0; min < 60; min++ {
+				if !match[3](min) {
+					continue
+				}
+				for sec := 0; sec < 60; sec++ {
+					if !match[4](sec) {
+						continue
+					}
+					c := time.Date(day.Year(), day.Month(), day.Day(), h, min, sec, 0, t.Location())
+					if !c.Before(start) {
+						return c, nil
+					}
+				}
+			}
+		}
+	}
+
+	return time.Time{}, nil
+}
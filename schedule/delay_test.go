@@ -0,0 +1,125 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix/schedule"
+)
+
+func TestParseDelay(t *testing.T) {
+	tests := map[string]string{
+		"30s":      "30s",
+		"{$DELAY}": "{$DELAY}",
+		"1m;10s/1-5,09:00-18:00;0/6-7,00:00-24:00": "1m;10s/1-5,09:00-18:00;0/6-7,00:00-24:00",
+		"0;wd1-5h9-18/3m0,30":                      "0;wd1-5h9-18/3m0,30",
+		"1h;md1,15h2;m/10":                         "1h;md1,15h2;m0-59/10",
+		"5m;{$FLEX}/{$WORKTIME};{$SCHEDULE}":       "5m;{$FLEX}/{$WORKTIME};{$SCHEDULE}",
+		"{#DELAY};h9m30s15/5":                      "{#DELAY};h9m30s15-59/5",
+	}
+
+	for s, expected := range tests {
+		d, err := schedule.ParseDelay(s)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", s, err)
+			continue
+		}
+		if d.String() != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, s, d)
+		}
+	}
+
+	for _, s := range []string{
+		"1x",
+		"1M",
+		"1m;10x/1-5,09:00-18:00",
+		"1m;10s/1-5",
+		"0;h24",
+		"0;h9wd1",
+		"0;wd0",
+		"0;m0-59/0",
+		"0;h",
+		"0;x1",
+	} {
+		if _, err := schedule.ParseDelay(s); err == nil {
+			t.Errorf("Expected error parsing %q", s)
+		}
+	}
+}
+
+func TestDelayIntervalAt(t *testing.T) {
+	d, err := schedule.ParseDelay("5m;1m/1-5,09:00-18:00;30s/1,12:00-13:00;0/7,00:00-24:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[time.Time]time.Duration{
+		at(1, 8, 0):  5 * time.Minute,
+		at(1, 10, 0): time.Minute,
+		at(1, 12, 0): 30 * time.Second,
+		at(7, 12, 0): 0,
+	}
+	for tm, expected := range tests {
+		if i, err := d.IntervalAt(tm); err != nil || i != expected {
+			t.Errorf("Expected %s at %s, got %s (%v)", expected, tm, i, err)
+		}
+	}
+
+	d, err = schedule.ParseDelay("{$DELAY};1m/1-5,09:00-18:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.IntervalAt(at(1, 10, 0)); err == nil {
+		t.Errorf("Expected error evaluating macro interval")
+	} else if _, ok := err.(*schedule.MacroError); !ok {
+		t.Errorf("Expected MacroError, got %v", err)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	tests := map[string]map[time.Time]time.Time{
+		// every day at 09:00:00
+		"h9": {
+			at(1, 8, 0): at(1, 9, 0),
+			at(1, 9, 0): at(2, 9, 0),
+		},
+		// week days every 15 minutes from 09:00 to 17:45
+		"wd1-5h9-17m/15": {
+			at(1, 9, 0):   at(1, 9, 15),
+			at(1, 17, 50): at(2, 9, 0),
+			at(5, 18, 0):  at(8, 9, 0),
+		},
+		// the 15th at midnight
+		"md15": {
+			at(1, 0, 0): at(5, 0, 0),
+		},
+		"md1,13wd3h12": {
+			at(1, 0, 0):  at(3, 12, 0),
+			at(3, 13, 0): time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for s, times := range tests {
+		sched, err := schedule.ParseSchedule(s)
+		if err != nil {
+			t.Fatalf("Error parsing %s: %v", s, err)
+		}
+		for from, expected := range times {
+			next, err := sched.Next(from)
+			if err != nil || !next.Equal(expected) {
+				t.Errorf("Expected %s after %s for %s, got %s (%v)", expected, from, s, next, err)
+			}
+			if active, _ := sched.IsActive(expected); !active {
+				t.Errorf("Expected %s to be active at %s", s, expected)
+			}
+			if active, _ := sched.IsActive(expected.Add(time.Second)); active {
+				t.Errorf("Expected %s to be inactive at %s", s, expected.Add(time.Second))
+			}
+		}
+	}
+
+	sched, _ := schedule.ParseSchedule("{$SCHEDULE}")
+	if _, err := sched.Next(at(1, 0, 0)); err == nil {
+		t.Errorf("Expected error for macro schedule")
+	}
+}
@@ -0,0 +1,297 @@
+// Package schedule parses Zabbix time periods and item update intervals.
+//
+// Time periods are used by user media, actions and flexible intervals:
+//
+//	1-5,09:00-18:00;6-7,10:00-16:00
+//
+// Update intervals combine a default interval with flexible and scheduling
+// intervals:
+//
+//	1m;10s/1-5,09:00-18:00;wd1-5h9m0-59/5
+//
+// User macros are kept as is and reported by a MacroError when a value cannot
+// be evaluated without expanding them first.
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/appendix/time_period
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Error is returned when parsing an invalid time period or update interval.
+type Error struct {
+	Value  string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Invalid value %q: %s", e.Value, e.Reason)
+}
+
+// MacroError is returned when evaluating a value holding a macro which is not
+// expanded.
+type MacroError struct {
+	Macro string
+}
+
+func (e *MacroError) Error() string {
+	return fmt.Sprintf("Cannot evaluate unexpanded macro %s", e.Macro)
+}
+
+var macroRegexp = regexp.MustCompile(`^\{(\$[A-Z0-9_.]+(:.*)?|#[A-Z0-9_.]+)\}$`)
+
+// isMacro returns true if s is a user or LLD macro, such as {$WORKTIME}.
+func isMacro(s string) bool {
+	return macroRegexp.MatchString(s)
+}
+
+// Range is a range of week days and a range of time within each of them, such
+// as 1-5,09:00-18:00, or a user macro.
+type Range struct {
+	// FromDay and ToDay are the first and last days of the range, from 1 for
+	// Monday to 7 for Sunday.
+	FromDay int
+	ToDay   int
+
+	// From and To are the start and end of the range from midnight. The end
+	// is excluded, and is at most 24 hours.
+	From time.Duration
+	To   time.Duration
+
+	// Macro is the macro of the range, such as {$WORKTIME}, if not empty.
+	// The other fields are not set then.
+	Macro string
+}
+
+// String returns the Range in the Zabbix format.
+func (r Range) String() string {
+	if r.Macro != "" {
+		return r.Macro
+	}
+
+	days := strconv.Itoa(r.FromDay)
+	if r.ToDay != r.FromDay {
+		days += "-" + strconv.Itoa(r.ToDay)
+	}
+	return fmt.Sprintf("%s,%s-%s", days, clock(r.From), clock(r.To))
+}
+
+// clock formats a time of the day as hh:mm.
+func clock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// IsActive returns true if t is within the Range, in the location of t.
+func (r Range) IsActive(t time.Time) (bool, error) {
+	if r.Macro != "" {
+		return false, &MacroError{Macro: r.Macro}
+	}
+
+	wd := isoWeekday(t)
+	if wd < r.FromDay || wd > r.ToDay {
+		return false, nil
+	}
+
+	since := sinceMidnight(t)
+	return since >= r.From && since < r.To, nil
+}
+
+// isoWeekday returns the day of the week of t, from 1 for Monday to 7 for
+// Sunday.
+func isoWeekday(t time.Time) int {
+	if wd := int(t.Weekday()); wd != 0 {
+		return wd
+	}
+	return 7
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(t.Nanosecond())
+}
+
+var rangeRegexp = regexp.MustCompile(`^([1-7])(?:-([1-7]))?,([0-9]{1,2}):([0-9]{2})-([0-9]{1,2}):([0-9]{2})$`)
+
+// ParseRange parses a single Range, such as 1-5,09:00-18:00 or {$WORKTIME}.
+func ParseRange(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+	if isMacro(s) {
+		return Range{Macro: s}, nil
+	}
+
+	match := rangeRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return Range{}, &Error{Value: s, Reason: "time period must be d-d,hh:mm-hh:mm"}
+	}
+
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	r := Range{FromDay: atoi(match[1]), ToDay: atoi(match[1])}
+	if match[2] != "" {
+		r.ToDay = atoi(match[2])
+	}
+	if r.ToDay < r.FromDay {
+		return Range{}, &Error{Value: s, Reason: "first day is after last day"}
+	}
+
+	fromH, fromM, toH, toM := atoi(match[3]), atoi(match[4]), atoi(match[5]), atoi(match[6])
+	if fromH > 23 || fromM > 59 || toH > 24 || toM > 59 || toH == 24 && toM != 0 {
+		return Range{}, &Error{Value: s, Reason: "invalid time"}
+	}
+
+	r.From = time.Duration(fromH)*time.Hour + time.Duration(fromM)*time.Minute
+	r.To = time.Duration(toH)*time.Hour + time.Duration(toM)*time.Minute
+	if r.To <= r.From {
+		return Range{}, &Error{Value: s, Reason: "start time is not before end time"}
+	}
+
+	return r, nil
+}
+
+// Period is a time period made of ranges separated by semicolons, such as
+// 1-5,09:00-18:00;6-7,10:00-16:00. A time is within the Period if it is within
+// any of its ranges.
+type Period []Range
+
+// ParsePeriod parses a time period.
+func ParsePeriod(s string) (Period, error) {
+	var p Period
+	for _, part := range strings.Split(s, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		r, err := ParseRange(part)
+		if err != nil {
+			return nil, err
+		}
+		p = append(p, r)
+	}
+
+	if len(p) == 0 {
+		return nil, &Error{Value: s, Reason: "empty time period"}
+	}
+
+	return p, nil
+}
+
+// String returns the Period in the Zabbix format.
+func (p Period) String() string {
+	ranges := make([]string, len(p))
+	for i, r := range p {
+		ranges[i] = r.String()
+	}
+	return strings.Join(ranges, ";")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Period) UnmarshalText(text []byte) error {
+	period, err := ParsePeriod(string(text))
+	if err != nil {
+		return err
+	}
+	*p = period
+	return nil
+}
+
+// Macros returns the macros of the Period.
+func (p Period) Macros() []string {
+	var macros []string
+	for _, r := range p {
+		if r.Macro != "" {
+			macros = append(macros, r.Macro)
+		}
+	}
+	return macros
+}
+
+// Expand returns the Period with its macros replaced by the time periods
+// returned by expand, such as macro.Expand.
+func (p Period) Expand(expand func(text string) (string, error)) (Period, error) {
+	var expanded Period
+	for _, r := range p {
+		if r.Macro == "" {
+			expanded = append(expanded, r)
+			continue
+		}
+
+		value, err := expand(r.Macro)
+		if err != nil {
+			return nil, err
+		}
+		if value == r.Macro {
+			return nil, &MacroError{Macro: r.Macro}
+		}
+
+		ranges, err := ParsePeriod(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value of macro %s: %v", r.Macro, err)
+		}
+		expanded = append(expanded, ranges...)
+	}
+	return expanded, nil
+}
+
+// IsActive returns true if t is within the Period, in the location of t. A
+// MacroError is returned if the Period holds macros.
+func (p Period) IsActive(t time.Time) (bool, error) {
+	if macros := p.Macros(); len(macros) > 0 {
+		return false, &MacroError{Macro: macros[0]}
+	}
+
+	for _, r := range p {
+		if active, _ := r.IsActive(t); active {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ErrNoTransition is returned by NextTransition for periods which are always
+// or never active.
+var ErrNoTransition = errors.New("Time period is always or never active")
+
+// NextTransition returns the first time after t when the Period becomes
+// active or inactive, in the location of t.
+func (p Period) NextTransition(t time.Time) (time.Time, error) {
+	active, err := p.IsActive(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// the ranges start and end within the next week
+	y, m, d := t.Date()
+	for i := 0; i <= 7; i++ {
+		var next time.Time
+		for _, r := range p {
+			for _, offset := range []time.Duration{r.From, r.To} {
+				c := time.Date(y, m, d+i, int(offset/time.Hour), int(offset%time.Hour/time.Minute), 0, 0, t.Location())
+				if !c.After(t) || !next.IsZero() && !c.Before(next) {
+					continue
+				}
+				if a, _ := p.IsActive(c); a != active {
+					next = c
+				}
+			}
+		}
+		if !next.IsZero() {
+			return next, nil
+		}
+	}
+
+	return time.Time{}, ErrNoTransition
+}
@@ -0,0 +1,171 @@
+package schedule_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix/schedule"
+)
+
+// 2024-03-11 is a Monday.
+func at(day, hour, min int) time.Time {
+	return time.Date(2024, 3, 10+day, hour, min, 0, 0, time.UTC)
+}
+
+func TestParsePeriod(t *testing.T) {
+	tests := map[string]string{
+		"1-5,09:00-18:00;6-7,10:00-16:00": "1-5,09:00-18:00;6-7,10:00-16:00",
+		"1-7,00:00-24:00":                 "1-7,00:00-24:00",
+		"3,9:30-17:45;":                   "3,09:30-17:45",
+		" 1-5,09:00-18:00 ;{$WEEKEND}":    "1-5,09:00-18:00;{$WEEKEND}",
+		`{$ONCALL:"team a"}`:              `{$ONCALL:"team a"}`,
+	}
+
+	for s, expected := range tests {
+		p, err := schedule.ParsePeriod(s)
+		if err != nil {
+			t.Errorf("Error parsing %s: %v", s, err)
+			continue
+		}
+		if p.String() != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, s, p)
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		";",
+		"1-5",
+		"0,09:00-18:00",
+		"5-1,09:00-18:00",
+		"1-8,09:00-18:00",
+		"1,18:00-09:00",
+		"1,09:00-09:00",
+		"1,24:00-24:30",
+		"1,00:00-24:01",
+		"1,09:60-10:00",
+		"1-5,09:00-18:00;bad",
+		"{WORKTIME}",
+	} {
+		if _, err := schedule.ParsePeriod(s); err == nil {
+			t.Errorf("Expected error parsing %q", s)
+		} else if _, ok := err.(*schedule.Error); !ok {
+			t.Errorf("Expected Error parsing %q, got %v", s, err)
+		}
+	}
+}
+
+func TestPeriodIsActive(t *testing.T) {
+	p, err := schedule.ParsePeriod("1-5,09:00-18:00;6-7,10:00-16:00;3,00:00-24:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[time.Time]bool{
+		at(1, 9, 0):   true,
+		at(1, 8, 59):  false,
+		at(1, 17, 59): true,
+		at(1, 18, 0):  false,
+		at(3, 2, 0):   true,
+		at(3, 23, 59): true,
+		at(6, 9, 30):  false,
+		at(6, 10, 0):  true,
+		at(7, 15, 59): true,
+		at(7, 16, 0):  false,
+	}
+
+	for tm, expected := range tests {
+		if active, err := p.IsActive(tm); err != nil || active != expected {
+			t.Errorf("Expected %v at %s, got %v (%v)", expected, tm.Format(time.RFC1123), active, err)
+		}
+	}
+
+	// the location of the time is used
+	tokyo := time.FixedZone("JST", 9*3600)
+	if active, _ := p.IsActive(at(1, 1, 0).In(tokyo)); !active {
+		t.Errorf("Expected period to be active at 10:00 JST")
+	}
+}
+
+func TestPeriodNextTransition(t *testing.T) {
+	p, err := schedule.ParsePeriod("1-5,09:00-18:00;5-6,17:00-24:00;7,00:00-02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[time.Time]time.Time{
+		at(1, 8, 0):  at(1, 9, 0),
+		at(1, 9, 0):  at(1, 18, 0),
+		at(1, 20, 0): at(2, 9, 0),
+		// overlapping and adjacent ranges
+		at(5, 12, 0): at(6, 0, 0),
+		at(6, 18, 0): at(7, 2, 0),
+		at(7, 1, 0):  at(7, 2, 0),
+		at(7, 3, 0):  at(8, 9, 0),
+	}
+
+	for from, expected := range tests {
+		next, err := p.NextTransition(from)
+		if err != nil || !next.Equal(expected) {
+			t.Errorf("Expected transition at %s after %s, got %s (%v)", expected, from, next, err)
+		}
+	}
+
+	always, _ := schedule.ParsePeriod("1-7,00:00-24:00")
+	if _, err := always.NextTransition(at(1, 0, 0)); err != schedule.ErrNoTransition {
+		t.Errorf("Expected ErrNoTransition, got %v", err)
+	}
+}
+
+func TestPeriodMacros(t *testing.T) {
+	p, err := schedule.ParsePeriod("1-5,09:00-18:00;{$WEEKEND}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.IsActive(at(1, 12, 0)); err == nil {
+		t.Errorf("Expected error evaluating a period with macros")
+	} else if me, ok := err.(*schedule.MacroError); !ok || me.Macro != "{$WEEKEND}" {
+		t.Errorf("Expected MacroError, got %v", err)
+	}
+
+	expanded, err := p.Expand(func(text string) (string, error) {
+		return "6,10:00-12:00;7,10:00-12:00", nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expanded.String() != "1-5,09:00-18:00;6,10:00-12:00;7,10:00-12:00" {
+		t.Errorf("Unexpected expanded period %s", expanded)
+	}
+	if active, err := expanded.IsActive(at(7, 11, 0)); err != nil || !active {
+		t.Errorf("Expected expanded period to be active on Sunday: %v", err)
+	}
+
+	// undefined macros are left as is
+	if _, err := p.Expand(func(text string) (string, error) { return text, nil }); err == nil {
+		t.Errorf("Expected error expanding undefined macro")
+	}
+}
+
+func TestPeriodJSON(t *testing.T) {
+	var media struct {
+		Period schedule.Period `json:"period"`
+	}
+	if err := json.Unmarshal([]byte(`{"period":"1-5,9:00-18:00"}`), &media); err != nil {
+		t.Fatal(err)
+	}
+	if len(media.Period) != 1 || media.Period[0].From != 9*time.Hour {
+		t.Errorf("Unexpected period %#v", media.Period)
+	}
+
+	b, err := json.Marshal(media)
+	if err != nil || string(b) != `{"period":"1-5,09:00-18:00"}` {
+		t.Errorf("Unexpected JSON %s (%v)", b, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"period":"1-5"}`), &media); err == nil {
+		t.Errorf("Expected error decoding invalid period")
+	}
+}
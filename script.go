@@ -1,32 +1,151 @@
 package zabbix
 
+// ScriptType is the type of a Script.
+type ScriptType int
+
+// ScriptScope is where a Script can be used.
+type ScriptScope int
+
+// ScriptExecuteOn is where a Script runs.
+type ScriptExecuteOn int
+
 const (
-	ScriptTypeScript  = 0
-	ScriptTypeIPMI    = 1
-	ScriptTypeSSH     = 2
-	ScriptTypeTelnet  = 3
-	ScriptTypeWebhook = 5
+	ScriptTypeScript  ScriptType = 0
+	ScriptTypeIPMI    ScriptType = 1
+	ScriptTypeSSH     ScriptType = 2
+	ScriptTypeTelnet  ScriptType = 3
+	ScriptTypeWebhook ScriptType = 5
+	ScriptTypeURL     ScriptType = 6
 
-	ScriptScopeActionOperation     = 1
-	ScriptScopeManualHostOperation = 2
-	ScriptScopeManualEventAction   = 4
+	ScriptScopeActionOperation     ScriptScope = 1
+	ScriptScopeManualHostOperation ScriptScope = 2
+	ScriptScopeManualEventAction   ScriptScope = 4
 
-	ScriptExecuteOnAgent  = 0
-	ScriptExecuteOnServer = 1
-	ScriptExecuteOnProxy  = 2
+	ScriptExecuteOnAgent  ScriptExecuteOn = 0
+	ScriptExecuteOnServer ScriptExecuteOn = 1
+	ScriptExecuteOnProxy  ScriptExecuteOn = 2
+)
 
+const (
 	ScriptResponseSuccess = "success"
 	ScriptResponseFailed  = "failed"
 )
 
+var (
+	scriptTypes = enum{"ScriptType", map[int]string{
+		int(ScriptTypeScript):  "script",
+		int(ScriptTypeIPMI):    "ipmi",
+		int(ScriptTypeSSH):     "ssh",
+		int(ScriptTypeTelnet):  "telnet",
+		int(ScriptTypeWebhook): "webhook",
+		int(ScriptTypeURL):     "url",
+	}}
+
+	scriptScopes = enum{"ScriptScope", map[int]string{
+		int(ScriptScopeActionOperation):     "action_operation",
+		int(ScriptScopeManualHostOperation): "manual_host_operation",
+		int(ScriptScopeManualEventAction):   "manual_event_action",
+	}}
+
+	scriptExecuteOns = enum{"ScriptExecuteOn", map[int]string{
+		int(ScriptExecuteOnAgent):  "agent",
+		int(ScriptExecuteOnServer): "server",
+		int(ScriptExecuteOnProxy):  "proxy",
+	}}
+)
+
+// String returns the name of the ScriptType.
+func (t ScriptType) String() string {
+	return scriptTypes.format(int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t ScriptType) MarshalText() ([]byte, error) {
+	return scriptTypes.marshalText(int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (t *ScriptType) UnmarshalText(text []byte) error {
+	return scriptTypes.unmarshalText(text, (*int)(t))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (t ScriptType) MarshalJSON() ([]byte, error) {
+	return scriptTypes.marshalJSON(int(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (t *ScriptType) UnmarshalJSON(data []byte) error {
+	return scriptTypes.unmarshalJSON(data, (*int)(t))
+}
+
+// String returns the name of the ScriptScope.
+func (s ScriptScope) String() string {
+	return scriptScopes.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s ScriptScope) MarshalText() ([]byte, error) {
+	return scriptScopes.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *ScriptScope) UnmarshalText(text []byte) error {
+	return scriptScopes.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s ScriptScope) MarshalJSON() ([]byte, error) {
+	return scriptScopes.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *ScriptScope) UnmarshalJSON(data []byte) error {
+	return scriptScopes.unmarshalJSON(data, (*int)(s))
+}
+
+// String returns the name of the ScriptExecuteOn.
+func (e ScriptExecuteOn) String() string {
+	return scriptExecuteOns.format(int(e))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (e ScriptExecuteOn) MarshalText() ([]byte, error) {
+	return scriptExecuteOns.marshalText(int(e))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (e *ScriptExecuteOn) UnmarshalText(text []byte) error {
+	return scriptExecuteOns.unmarshalText(text, (*int)(e))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (e ScriptExecuteOn) MarshalJSON() ([]byte, error) {
+	return scriptExecuteOns.marshalJSON(int(e))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (e *ScriptExecuteOn) UnmarshalJSON(data []byte) error {
+	return scriptExecuteOns.unmarshalJSON(data, (*int)(e))
+}
+
 type Script struct {
-	ScriptID    string `json:"scriptid"`
-	Name        string `json:"name"`
-	Type        int    `json:"type"`
-	Command     string `json:"command"`
-	Scope       int    `json:"scope"`
-	ExecuteOn   int    `json:"execute_on"`
-	Description string `json:"description"`
+	ScriptID    string          `json:"scriptid"`
+	Name        string          `json:"name"`
+	Type        ScriptType      `json:"type"`
+	Command     string          `json:"command"`
+	Scope       ScriptScope     `json:"scope"`
+	ExecuteOn   ScriptExecuteOn `json:"execute_on"`
+	Description string          `json:"description"`
 }
 
 type ScriptExecuteRequest struct {
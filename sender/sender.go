@@ -0,0 +1,209 @@
+// Package sender implements the Zabbix sender protocol used to send values to
+// trapper Items through a Zabbix server or proxy, like zabbix_sender.
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/appendix/protocols/zabbix_sender
+package sender
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/NexonSU/go-zabbix/itemkey"
+	"github.com/NexonSU/go-zabbix/zbxd"
+)
+
+const (
+	// DefaultPort is the default trapper port of the Zabbix server and proxy.
+	DefaultPort = "10051"
+
+	// DefaultTimeout is the default connection timeout.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Item is a value sent to a trapper Item.
+type Item struct {
+	// Host is the technical name of the Host the Item belongs to.
+	Host string `json:"host"`
+
+	// Key is the key of the Item.
+	Key string `json:"key"`
+
+	// Value is the value to send.
+	Value string `json:"value"`
+
+	// Clock is the Unix time of the value. The time of the server is used if
+	// not set.
+	Clock int64 `json:"clock,omitempty"`
+
+	// Nanoseconds is the nanoseconds part of the time of the value.
+	Nanoseconds int64 `json:"ns,omitempty"`
+}
+
+// NewItem returns an Item with the given value, timestamped with the current
+// time.
+func NewItem(host, key, value string) Item {
+	now := time.Now()
+
+	return Item{
+		Host:        host,
+		Key:         key,
+		Value:       value,
+		Clock:       now.Unix(),
+		Nanoseconds: int64(now.Nanosecond()),
+	}
+}
+
+// Validate returns an error if the Item has no Host or an invalid Key. The
+// server rejects such values without telling which ones.
+func (i Item) Validate() error {
+	if i.Host == "" {
+		return fmt.Errorf("Missing host of item %q", i.Key)
+	}
+	return itemkey.Validate(i.Key)
+}
+
+// Request is a "sender data" request.
+type Request struct {
+	Request     string `json:"request"`
+	Data        []Item `json:"data"`
+	Clock       int64  `json:"clock,omitempty"`
+	Nanoseconds int64  `json:"ns,omitempty"`
+}
+
+// Response is the response of the server to a "sender data" request.
+type Response struct {
+	// Response is "success" if the request was processed.
+	Response string `json:"response"`
+
+	// Info is the processing summary, such as
+	// "processed: 1; failed: 0; total: 1; seconds spent: 0.000055".
+	Info string `json:"info"`
+
+	// Processed is the number of accepted values, parsed from Info.
+	Processed int `json:"-"`
+
+	// Failed is the number of rejected values, parsed from Info.
+	Failed int `json:"-"`
+
+	// Total is the number of values received, parsed from Info.
+	Total int `json:"-"`
+
+	// SecondsSpent is the processing time on the server, parsed from Info.
+	SecondsSpent float64 `json:"-"`
+}
+
+var infoRegexp = regexp.MustCompile(`processed:\s*(\d+);\s*failed:\s*(\d+);\s*total:\s*(\d+);\s*seconds spent:\s*([0-9.]+)`)
+
+// ParseInfo fills the counters of the Response from its Info string.
+func (r *Response) ParseInfo() error {
+	match := infoRegexp.FindStringSubmatch(r.Info)
+	if match == nil {
+		return fmt.Errorf("Unexpected sender response info %q", r.Info)
+	}
+
+	r.Processed, _ = strconv.Atoi(match[1])
+	r.Failed, _ = strconv.Atoi(match[2])
+	r.Total, _ = strconv.Atoi(match[3])
+	r.SecondsSpent, _ = strconv.ParseFloat(match[4], 64)
+
+	return nil
+}
+
+// A Sender sends values to trapper Items through a Zabbix server or proxy.
+type Sender struct {
+	// Address is the host and port of the Zabbix server or proxy.
+	Address string
+
+	// Timeout is the timeout for connecting, sending and receiving.
+	Timeout time.Duration
+
+	// Compress enables compression of the sent data.
+	Compress bool
+}
+
+// New returns a Sender for the given server or proxy address. DefaultPort is
+// used if address has no port.
+func New(address string) *Sender {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, DefaultPort)
+	}
+
+	return &Sender{
+		Address: address,
+		Timeout: DefaultTimeout,
+	}
+}
+
+// Send sends a batch of values and returns the response of the server.
+//
+// An error is returned if there was a network or protocol error, or if the
+// server did not respond with "success". Rejected values are not reported as
+// an error: check Response.Failed.
+func (s *Sender) Send(items ...Item) (*Response, error) {
+	req := Request{
+		Request: "sender data",
+		Data:    items,
+	}
+
+	// the request time lets the server correct the clock skew of the values
+	for _, item := range items {
+		if item.Clock != 0 {
+			now := time.Now()
+			req.Clock = now.Unix()
+			req.Nanoseconds = int64(now.Nanosecond())
+			break
+		}
+	}
+
+	var resp Response
+	if err := s.Do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp.Response != "success" {
+		return &resp, fmt.Errorf("Sender request failed: %s %s", resp.Response, resp.Info)
+	}
+
+	if err := resp.ParseInfo(); err != nil {
+		return &resp, err
+	}
+
+	return &resp, nil
+}
+
+// Do sends the given request as JSON and decodes the JSON response into v.
+func (s *Sender) Do(req interface{}, v interface{}) error {
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.DialTimeout("tcp", s.Address, s.Timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if s.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.Timeout))
+	}
+
+	if err := zbxd.Write(conn, b, s.Compress); err != nil {
+		return fmt.Errorf("Error sending request: %v", err)
+	}
+
+	b, err = zbxd.Read(conn, 0)
+	if err != nil {
+		return fmt.Errorf("Error reading response: %v", err)
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("Error decoding JSON response body: %v", err)
+	}
+
+	return nil
+}
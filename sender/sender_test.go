@@ -0,0 +1,80 @@
+package sender
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestSend(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		var received Request
+		address := test.NewTCPServer(t, compress, func(req []byte) []byte {
+			if err := json.Unmarshal(req, &received); err != nil {
+				t.Errorf("Error decoding request: %v", err)
+			}
+
+			return []byte(`{"response":"success","info":"processed: 1; failed: 1; total: 2; seconds spent: 0.000055"}`)
+		})
+
+		s := New(address)
+		s.Compress = compress
+
+		resp, err := s.Send(
+			NewItem("web01", "trap.int", "42"),
+			Item{Host: "web01", Key: "missing", Value: "1"},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if received.Request != "sender data" || len(received.Data) != 2 || received.Data[0].Value != "42" {
+			t.Errorf("Unexpected request %+v", received)
+		}
+
+		if received.Clock == 0 {
+			t.Errorf("Expected request clock to be set for timestamped values")
+		}
+
+		if resp.Processed != 1 || resp.Failed != 1 || resp.Total != 2 || resp.SecondsSpent != 0.000055 {
+			t.Errorf("Unexpected response %+v", resp)
+		}
+	}
+}
+
+func TestSendFailed(t *testing.T) {
+	address := test.NewTCPServer(t, false, func(req []byte) []byte {
+		return []byte(`{"response":"failed","info":"cannot parse request"}`)
+	})
+
+	if _, err := New(address).Send(Item{Host: "web01", Key: "trap", Value: "1"}); err == nil {
+		t.Errorf("Expected an error for a failed response")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if s := New("zabbix.example.com"); s.Address != "zabbix.example.com:10051" {
+		t.Errorf("Expected default port, got %q", s.Address)
+	}
+
+	if s := New("[::1]:10052"); s.Address != "[::1]:10052" {
+		t.Errorf("Expected given port, got %q", s.Address)
+	}
+}
+
+func TestItemValidate(t *testing.T) {
+	if err := (Item{Host: "web01", Key: `net.if.in["eth0",bytes]`}).Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	for _, item := range []Item{
+		{Key: "trap"},
+		{Host: "web01", Key: "trap[a"},
+		{Host: "web01", Key: "trap value"},
+	} {
+		if err := item.Validate(); err == nil {
+			t.Errorf("Expected error validating %+v", item)
+		}
+	}
+}
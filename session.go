@@ -13,13 +13,19 @@ import (
 // ErrNotFound describes an empty result set for an API call.
 var (
 	ErrNotFound      = &NotFoundError{"No results were found matching the given search parameters"}
+	zabbixVersion540 *types.ZBXVersion
 	zabbixVersion600 *types.ZBXVersion
+	zabbixVersion620 *types.ZBXVersion
 	zabbixVersion640 *types.ZBXVersion
+	zabbixVersion700 *types.ZBXVersion
 )
 
 func init() {
+	zabbixVersion540, _ = types.NewZBXVersion("5.4.0")
 	zabbixVersion600, _ = types.NewZBXVersion("6.0.0")
+	zabbixVersion620, _ = types.NewZBXVersion("6.2.0")
 	zabbixVersion640, _ = types.NewZBXVersion("6.4.0")
+	zabbixVersion700, _ = types.NewZBXVersion("7.0.0")
 }
 
 // A Session is an authenticated Zabbix JSON-RPC API client. It must be
@@ -35,6 +41,24 @@ type Session struct {
 	// ApiVersion is the software version string of the connected Zabbix API.
 	APIVersion *types.ZBXVersion `json:"apiVersion"`
 
+	// Warn is called with the request parameters rewritten or ignored for the
+	// version of the connected Zabbix API, such as selectGroups, which is
+	// rewritten to selectHostGroups for Zabbix 6.2 and later. The warnings
+	// are printed if the ZBX_DEBUG environment variable is set and Warn is
+	// nil.
+	Warn func(w *Warning) `json:"-"`
+
+	// Strict is how fields of API responses which do not decode cleanly into
+	// the values given to Get are reported, such as fields renamed in newer
+	// Zabbix versions. Responses are decoded as json.Unmarshal does by
+	// default.
+	Strict StrictMode `json:"-"`
+
+	// DecodeWarn is called with the decoding issues of API responses in
+	// StrictWarn mode. The issues are printed if the ZBX_DEBUG environment
+	// variable is set and DecodeWarn is nil.
+	DecodeWarn func(method string, issue *DecodeIssue) `json:"-"`
+
 	client *http.Client
 }
 
@@ -134,13 +158,30 @@ func (c *Session) Do(req *Request, noAuthRequired bool) (resp *Response, err err
 		}
 	}
 
+	// rewrite deprecated and removed parameters for the API version
+	var tr *translator
+	if c.APIVersion != nil {
+		if tr, err = translateRequest(req, c.APIVersion); err != nil {
+			return nil, fmt.Errorf("Error encoding request parameters: %v", err)
+		}
+		for _, w := range tr.warnings {
+			if c.Warn != nil {
+				c.Warn(w)
+			} else {
+				dprintf("Warning  [%s:%d]: %s\n", req.Method, req.RequestID, w)
+			}
+		}
+	}
+
 	// encode request as json
 	b, err := json.Marshal(req)
 	if err != nil {
 		return
 	}
 
-	dprintf("Call     [%s:%d]: %s\n", req.Method, req.RequestID, b)
+	if debug {
+		dprintf("Call     [%s:%d]: %s\n", req.Method, req.RequestID, redact(b))
+	}
 
 	// create HTTP request
 	r, err := http.NewRequest("POST", c.URL, bytes.NewReader(b))
@@ -171,7 +212,9 @@ func (c *Session) Do(req *Request, noAuthRequired bool) (resp *Response, err err
 		return nil, fmt.Errorf("Error reading response: %v", err)
 	}
 
-	dprintf("Response [%s:%d]: %s\n", req.Method, req.RequestID, b)
+	if debug {
+		dprintf("Response [%s:%d]: %s\n", req.Method, req.RequestID, redact(b))
+	}
 
 	// map HTTP response to Response struct
 	resp = &Response{
@@ -189,6 +232,10 @@ func (c *Session) Do(req *Request, noAuthRequired bool) (resp *Response, err err
 		return
 	}
 
+	if tr != nil {
+		tr.translateResponse(resp)
+	}
+
 	return
 }
 
@@ -203,6 +250,24 @@ func (c *Session) Get(method string, params interface{}, v interface{}) error {
 		return err
 	}
 
+	if c.Strict != StrictOff {
+		issues, err := resp.Check(v)
+		if err != nil {
+			return err
+		}
+
+		if c.Strict == StrictError && len(issues) > 0 {
+			return &DecodeError{Method: method, Issues: issues}
+		}
+		for _, issue := range issues {
+			if c.DecodeWarn != nil {
+				c.DecodeWarn(method, issue)
+			} else {
+				dprintf("Decoding [%s:%d]: %s\n", req.Method, req.RequestID, issue)
+			}
+		}
+	}
+
 	err = resp.Bind(v)
 	if err != nil {
 		return err
@@ -210,3 +275,20 @@ func (c *Session) Get(method string, params interface{}, v interface{}) error {
 
 	return nil
 }
+
+// GetIDs calls the given Zabbix API method, which returns an object holding an
+// array of IDs under the given key, as create, update and delete methods do.
+func (c *Session) GetIDs(method string, params interface{}, key string) ([]string, error) {
+	body := make(map[string][]json.Number)
+	if err := c.Get(method, params, &body); err != nil {
+		return nil, err
+	}
+
+	// IDs are returned as numbers by some methods and versions
+	ids := make([]string, 0, len(body[key]))
+	for _, id := range body[key] {
+		ids = append(ids, id.String())
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,397 @@
+package zabbix
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StrictMode is how a Session reports the fields of API responses which do
+// not decode cleanly into the given Go values.
+type StrictMode int
+
+const (
+	// StrictOff decodes responses as json.Unmarshal does, ignoring unknown
+	// fields.
+	StrictOff StrictMode = iota
+
+	// StrictWarn reports decoding issues with Session.DecodeWarn and decodes
+	// responses as StrictOff does.
+	StrictWarn
+
+	// StrictError returns a DecodeError holding the decoding issues of a
+	// response, if any.
+	StrictError
+)
+
+// DecodeIssueKind is the kind of a DecodeIssue.
+type DecodeIssueKind int
+
+const (
+	// DecodeUnknownField is a field of a JSON object without matching Go
+	// struct field, as for fields renamed in newer Zabbix versions.
+	DecodeUnknownField DecodeIssueKind = iota
+
+	// DecodeTypeMismatch is a JSON value which cannot be decoded into the
+	// type of its Go value, as a number for a string.
+	DecodeTypeMismatch
+
+	// DecodeEmptyArray is an empty JSON array returned by PHP in place of an
+	// empty object.
+	DecodeEmptyArray
+)
+
+var decodeIssueKinds = enum{"DecodeIssueKind", map[int]string{
+	int(DecodeUnknownField): "unknown_field",
+	int(DecodeTypeMismatch): "type_mismatch",
+	int(DecodeEmptyArray):   "empty_array",
+}}
+
+// String returns the name of the DecodeIssueKind.
+func (k DecodeIssueKind) String() string {
+	return decodeIssueKinds.format(int(k))
+}
+
+// DecodeIssue is a field of an API response which does not decode cleanly
+// into its Go value.
+type DecodeIssue struct {
+	Kind DecodeIssueKind
+
+	// Path is the path of the field in the response, such as
+	// [0].interfaces[1].port.
+	Path string
+
+	// Type is the Go type the field is decoded into, empty for unknown
+	// fields.
+	Type string
+
+	// Value is the JSON value of the field, shortened if long.
+	Value string
+}
+
+func (i *DecodeIssue) String() string {
+	switch i.Kind {
+	case DecodeUnknownField:
+		return fmt.Sprintf("%s: unknown field with value %s", i.Path, i.Value)
+	case DecodeEmptyArray:
+		return fmt.Sprintf("%s: empty array instead of %s", i.Path, i.Type)
+	}
+	return fmt.Sprintf("%s: cannot decode %s into %s", i.Path, i.Value, i.Type)
+}
+
+// DecodeError is returned by a Session in StrictError mode for responses with
+// decoding issues.
+type DecodeError struct {
+	// Method is the name of the API method called.
+	Method string
+
+	Issues []*DecodeIssue
+}
+
+func (e *DecodeError) Error() string {
+	issues := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		issues[i] = issue.String()
+	}
+	return fmt.Sprintf("Strict decoding of %s response failed: %s", e.Method, strings.Join(issues, "; "))
+}
+
+// Check returns the issues of decoding the JSON body of the Response into the
+// given value: unknown fields, type mismatches and empty arrays in place of
+// objects. The value is not modified.
+func (c *Response) Check(v interface{}) ([]*DecodeIssue, error) {
+	body, err := decodeJSON(c.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding JSON response body: %v", err)
+	}
+
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("Cannot check decoding into non-pointer %v", t)
+	}
+
+	var chk checker
+	chk.value("", body, t.Elem(), false)
+	return chk.issues, nil
+}
+
+// checker collects the decoding issues of a JSON value.
+type checker struct {
+	issues []*DecodeIssue
+}
+
+func (chk *checker) report(kind DecodeIssueKind, path string, v interface{}, t reflect.Type) {
+	issue := &DecodeIssue{Kind: kind, Path: path, Value: shorten(v)}
+	if t != nil {
+		issue.Type = t.String()
+	}
+	if issue.Path == "" {
+		issue.Path = "result"
+	}
+	chk.issues = append(chk.issues, issue)
+}
+
+// shorten returns the JSON encoding of v, shortened to 40 characters.
+func shorten(v interface{}) string {
+	b, _ := json.Marshal(v)
+	if len(b) > 40 {
+		return string(b[:37]) + "..."
+	}
+	return string(b)
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// value checks the decoding of v into a value of type t. quoted is set for
+// fields with the string option, holding their value in a JSON string.
+func (chk *checker) value(path string, v interface{}, t reflect.Type, quoted bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if v == nil || t.Kind() == reflect.Interface {
+		return
+	}
+
+	if quoted {
+		s, ok := v.(string)
+		if !ok {
+			chk.report(DecodeTypeMismatch, path, v, t)
+			return
+		}
+		if t.Kind() == reflect.String {
+			// the string holds a JSON string
+			if _, ok := decodeString(s); !ok {
+				chk.report(DecodeTypeMismatch, path, v, t)
+			}
+			return
+		}
+		if reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+			if reflect.New(t).Interface().(json.Unmarshaler).UnmarshalJSON([]byte(s)) != nil {
+				chk.report(DecodeTypeMismatch, path, v, t)
+			}
+			return
+		}
+		inner, err := decodeJSON([]byte(s))
+		if err != nil {
+			chk.report(DecodeTypeMismatch, path, v, t)
+			return
+		}
+		v = inner
+	} else if reflect.PtrTo(t).Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		b, _ := json.Marshal(v)
+		if json.Unmarshal(b, reflect.New(t).Interface()) != nil {
+			chk.report(DecodeTypeMismatch, path, v, t)
+			return
+		}
+
+		switch v := v.(type) {
+		case map[string]interface{}:
+			// objects decoded by custom methods may still hold unknown fields
+			if t.Kind() == reflect.Struct {
+				chk.fields(path, v, t)
+			}
+		case []interface{}:
+			// custom methods may accept empty arrays for objects
+			if len(v) == 0 && (t.Kind() == reflect.Struct || t.Kind() == reflect.Map) {
+				chk.report(DecodeEmptyArray, path, v, t)
+			}
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			chk.report(DecodeTypeMismatch, path, v, t)
+		}
+
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			chk.report(DecodeTypeMismatch, path, v, t)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := v.(json.Number)
+		if ok {
+			_, err := strconv.ParseInt(string(n), 10, t.Bits())
+			ok = err == nil
+		}
+		if !ok {
+			chk.report(DecodeTypeMismatch, path, v, t)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := v.(json.Number)
+		if ok {
+			_, err := strconv.ParseUint(string(n), 10, t.Bits())
+			ok = err == nil
+		}
+		if !ok {
+			chk.report(DecodeTypeMismatch, path, v, t)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(json.Number); !ok {
+			chk.report(DecodeTypeMismatch, path, v, t)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if _, ok := v.(string); ok && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// []byte is decoded from base64 strings
+			return
+		}
+		a, ok := v.([]interface{})
+		if !ok {
+			chk.report(DecodeTypeMismatch, path, v, t)
+			return
+		}
+		for i, e := range a {
+			chk.value(fmt.Sprintf("%s[%d]", path, i), e, t.Elem(), false)
+		}
+
+	case reflect.Map:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			chk.mismatchObject(path, v, t)
+			return
+		}
+		for _, key := range sortedKeys(obj) {
+			chk.value(joinPath(path, key), obj[key], t.Elem(), false)
+		}
+
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			chk.mismatchObject(path, v, t)
+			return
+		}
+		chk.fields(path, obj, t)
+
+	default:
+		chk.report(DecodeTypeMismatch, path, v, t)
+	}
+}
+
+// mismatchObject reports a value which is not an object, distinguishing the
+// empty arrays returned by PHP for empty objects.
+func (chk *checker) mismatchObject(path string, v interface{}, t reflect.Type) {
+	if a, ok := v.([]interface{}); ok && len(a) == 0 {
+		chk.report(DecodeEmptyArray, path, v, t)
+		return
+	}
+	chk.report(DecodeTypeMismatch, path, v, t)
+}
+
+// fields checks the fields of a JSON object decoded into a struct.
+func (chk *checker) fields(path string, obj map[string]interface{}, t reflect.Type) {
+	fields := structFields(t)
+	for _, key := range sortedKeys(obj) {
+		f, ok := fields[key]
+		if !ok {
+			// encoding/json falls back to case-insensitive matches
+			for name, field := range fields {
+				if strings.EqualFold(name, key) {
+					f, ok = field, true
+					break
+				}
+			}
+		}
+
+		if !ok {
+			chk.report(DecodeUnknownField, joinPath(path, key), obj[key], nil)
+			continue
+		}
+		chk.value(joinPath(path, key), obj[key], f.typ, f.quoted)
+	}
+}
+
+// sortedKeys returns the keys of a JSON object sorted, to report issues in a
+// stable order.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// decodeString decodes a JSON string literal.
+func decodeString(s string) (string, bool) {
+	var v string
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return "", false
+	}
+	return v, true
+}
+
+// structField is a field of a struct as seen by encoding/json.
+type structField struct {
+	typ    reflect.Type
+	quoted bool
+}
+
+// structFields returns the fields of a struct by JSON name, including the
+// fields of embedded structs.
+func structFields(t reflect.Type) map[string]structField {
+	fields := make(map[string]structField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for embeddedName, embedded := range structFields(ft) {
+					// fields of the outer struct take precedence
+					if _, ok := fields[embeddedName]; !ok {
+						fields[embeddedName] = embedded
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		quoted := false
+		for _, opt := range strings.Split(opts, ",") {
+			if opt == "string" {
+				switch f.Type.Kind() {
+				case reflect.Bool, reflect.String,
+					reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+					reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+					reflect.Float32, reflect.Float64:
+					quoted = true
+				}
+			}
+		}
+		fields[name] = structField{typ: f.Type, quoted: quoted}
+	}
+	return fields
+}
@@ -0,0 +1,110 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+type strictObject struct {
+	ID      string            `json:"id"`
+	Count   int               `json:"count,string"`
+	Enabled bool              `json:"enabled"`
+	Tags    map[string]string `json:"tags"`
+	Nested  struct {
+		Value float64 `json:"value"`
+	} `json:"nested"`
+	Inventory zabbix.HostInventory `json:"inventory"`
+}
+
+func TestResponseCheck(t *testing.T) {
+	tests := map[string][]string{
+		`[{"id":"1","count":"2","enabled":true,"tags":{"a":"b"},"nested":{"value":1.5},"inventory":{"os":"linux"}}]`: nil,
+		`[{"id":"1","renamed":"x"}]`:                  {"unknown_field [0].renamed"},
+		`[{"id":1,"count":2}]`:                        {"type_mismatch [0].count int", "type_mismatch [0].id string"},
+		`[{"count":"two","enabled":"1"}]`:             {"type_mismatch [0].count int", "type_mismatch [0].enabled bool"},
+		`[{"tags":[],"nested":[],"inventory":[]}]`:    {"empty_array [0].inventory zabbix.HostInventory", "empty_array [0].nested struct { Value float64 \"json:\\\"value\\\"\" }", "empty_array [0].tags map[string]string"},
+		`[{"nested":{"value":"1","extra":null}}]`:     {"unknown_field [0].nested.extra", "type_mismatch [0].nested.value float64"},
+		`[{"tags":{"a":1}},null]`:                     {"type_mismatch [0].tags.a string"},
+		`{"id":"1"}`:                                  {"type_mismatch result []zabbix_test.strictObject"},
+		`[{"ID":"1","Count":"3"}]`:                    nil,
+		`[{"inventory":{"os":"linux","bogus":"x"}}]`:  nil,
+		`[{"id":null,"count":null,"inventory":null}]`: nil,
+	}
+
+	for body, expected := range tests {
+		resp := &zabbix.Response{Body: json.RawMessage(body)}
+		issues, err := resp.Check(&[]strictObject{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, issue := range issues {
+			s := issue.Kind.String() + " " + issue.Path
+			if issue.Type != "" {
+				s += " " + issue.Type
+			}
+			got = append(got, s)
+		}
+
+		if len(got) != len(expected) {
+			t.Errorf("Expected issues %q for %s but got %q", expected, body, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != expected[i] {
+				t.Errorf("Expected issues %q for %s but got %q", expected, body, got)
+				break
+			}
+		}
+	}
+}
+
+func TestSessionStrict(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{"hostid":"10084","host":"web01","unknown_field":"1"}]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	// off by default
+	if _, err := session.GetHosts(zabbix.HostGetParams{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var warnings []*zabbix.DecodeIssue
+	session.Strict = zabbix.StrictWarn
+	session.DecodeWarn = func(method string, issue *zabbix.DecodeIssue) {
+		if method != "host.get" {
+			t.Errorf("Unexpected method %s", method)
+		}
+		warnings = append(warnings, issue)
+	}
+	hosts, err := session.GetHosts(zabbix.HostGetParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hosts[0].Hostname != "web01" {
+		t.Errorf("Expected hosts to be decoded in StrictWarn mode, got %+v", hosts)
+	}
+	if len(warnings) != 1 || warnings[0].Path != "[0].unknown_field" || warnings[0].Kind != zabbix.DecodeUnknownField {
+		t.Errorf("Unexpected warnings %v", warnings)
+	}
+
+	session.Strict = zabbix.StrictError
+	_, err = session.GetHosts(zabbix.HostGetParams{})
+	decodeErr, ok := err.(*zabbix.DecodeError)
+	if !ok {
+		t.Fatalf("Expected a DecodeError but got %v", err)
+	}
+	if decodeErr.Method != "host.get" || len(decodeErr.Issues) != 1 {
+		t.Errorf("Unexpected error %v", decodeErr)
+	}
+	expected := `Strict decoding of host.get response failed: [0].unknown_field: unknown field with value "1"`
+	if decodeErr.Error() != expected {
+		t.Errorf("Expected error %q but got %q", expected, decodeErr.Error())
+	}
+}
@@ -0,0 +1,103 @@
+package zabbix
+
+// Template represents a Zabbix Template returned from the Zabbix API.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/template/object
+type Template struct {
+	// TemplateID is the unique ID of the Template.
+	TemplateID string `json:"templateid"`
+
+	// Hostname is the technical name of the Template.
+	Hostname string `json:"host"`
+
+	// DisplayName is the visible name of the Template.
+	DisplayName string `json:"name,omitempty"`
+
+	// Description is the description of the Template.
+	Description string `json:"description,omitempty"`
+
+	// UUID is the universal unique identifier of the Template.
+	UUID string `json:"uuid,omitempty"`
+
+	// Groups is only populated if TemplateGetParams.SelectGroups is given in
+	// the query parameters that returned this Template.
+	//
+	// Deprecated: Depcreated since Zabbix 6.2, use TemplateGroups instead.
+	Groups []Hostgroup `json:"groups,omitempty"`
+
+	// TemplateGroups is only populated if TemplateGetParams.
+	// SelectTemplateGroups is given in the query parameters that returned
+	// this Template.
+	TemplateGroups []TemplateGroup `json:"templategroups,omitempty"`
+
+	// Macros is only populated if TemplateGetParams.SelectMacros is given in
+	// the query parameters that returned this Template.
+	Macros []HostMacro `json:"macros,omitempty"`
+
+	// ParentTemplates are the templates linked to the Template. It is only
+	// populated if TemplateGetParams.SelectParentTemplates is given in the
+	// query parameters that returned this Template.
+	ParentTemplates []Template `json:"parentTemplates,omitempty"`
+
+	// Hosts is only populated if TemplateGetParams.SelectHosts is given in
+	// the query parameters that returned this Template.
+	Hosts []Host `json:"hosts,omitempty"`
+}
+
+// TemplateGetParams is query params for template.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/template/get
+type TemplateGetParams struct {
+	GetParameters
+
+	// TemplateIDs filters search results to Templates with the given IDs.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// GroupIDs filters search results to Templates in the given groups.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// HostIDs filters search results to Templates linked to the given hosts
+	// or templates.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// ParentTemplateIDs filters search results to Templates linked to the
+	// given templates.
+	ParentTemplateIDs []string `json:"parentTemplateids,omitempty"`
+
+	// SelectGroups returns the groups of each Template.
+	//
+	// Deprecated: Depcreated since Zabbix 6.2, use SelectTemplateGroups
+	// instead.
+	SelectGroups SelectQuery `json:"selectGroups,omitempty"`
+
+	// SelectTemplateGroups returns the template groups of each Template.
+	SelectTemplateGroups SelectQuery `json:"selectTemplateGroups,omitempty"`
+
+	// SelectMacros returns the user macros of each Template.
+	SelectMacros SelectQuery `json:"selectMacros,omitempty"`
+
+	// SelectParentTemplates returns the templates linked to each Template.
+	SelectParentTemplates SelectQuery `json:"selectParentTemplates,omitempty"`
+
+	// SelectHosts returns the hosts linked to each Template.
+	SelectHosts SelectQuery `json:"selectHosts,omitempty"`
+}
+
+// GetTemplates queries the Zabbix API for Templates matching the given search
+// parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetTemplates(params TemplateGetParams) ([]Template, error) {
+	templates := make([]Template, 0)
+	err := c.Get("template.get", params, &templates)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templates) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return templates, nil
+}
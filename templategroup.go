@@ -0,0 +1,60 @@
+package zabbix
+
+// TemplateGroup represents a Zabbix Template Group returned from the Zabbix
+// API. Template groups were split from host groups in Zabbix 6.2.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/templategroup/object
+type TemplateGroup struct {
+	// GroupID is the unique ID of the Template Group.
+	GroupID string `json:"groupid"`
+
+	// Name is the name of the Template Group.
+	Name string `json:"name"`
+
+	// UUID is the universal unique identifier of the Template Group.
+	UUID string `json:"uuid,omitempty"`
+
+	// Templates is only populated if TemplateGroupGetParams.SelectTemplates
+	// is given in the query parameters that returned this Template Group.
+	Templates []Template `json:"templates,omitempty"`
+}
+
+// TemplateGroupGetParams is query params for templategroup.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/templategroup/get
+type TemplateGroupGetParams struct {
+	GetParameters
+
+	// GroupIDs filters search results to Template Groups with the given IDs.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// TemplateIDs filters search results to Template Groups containing the
+	// given templates.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// WithTemplates filters search results to Template Groups containing
+	// templates.
+	WithTemplates bool `json:"with_templates,omitempty"`
+
+	// SelectTemplates returns the templates in each Template Group.
+	SelectTemplates SelectQuery `json:"selectTemplates,omitempty"`
+}
+
+// GetTemplateGroups queries the Zabbix API for Template Groups matching the
+// given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetTemplateGroups(params TemplateGroupGetParams) ([]TemplateGroup, error) {
+	groups := make([]TemplateGroup, 0)
+	err := c.Get("templategroup.get", params, &groups)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return groups, nil
+}
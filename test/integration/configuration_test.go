@@ -0,0 +1,59 @@
+package integration
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestConfigurationIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	session := test.GetTestSession(t)
+
+	hostgroups, err := session.GetHostgroups(zabbix.HostgroupGetParams{})
+	if err != nil {
+		if _, ok := err.(*zabbix.NotFoundError); !ok {
+			t.Fatalf("Error getting Hostgroups: %v", err)
+		}
+	}
+
+	if len(hostgroups) == 0 {
+		t.Skip("No Hostgroups found")
+	}
+
+	data, err := session.ExportConfiguration(zabbix.ConfigurationExportParams{
+		Format: zabbix.ConfigurationFormatJSON,
+		Options: zabbix.ConfigurationExportOptions{
+			HostGroupIDs: []string{hostgroups[0].GroupID},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error exporting configuration: %v", err)
+	}
+
+	if !json.Valid([]byte(data)) {
+		t.Fatalf("Exported configuration is not valid JSON: %s", data)
+	}
+
+	// importing an unchanged export must not change anything
+	comparison, err := session.CompareImportConfiguration(zabbix.ConfigurationImportParams{
+		Format: zabbix.ConfigurationFormatJSON,
+		Source: data,
+		Rules:  zabbix.ImportRules{HostGroups: &zabbix.ImportRule{CreateMissing: true}},
+	})
+	if _, ok := err.(*zabbix.UnsupportedVersionError); ok {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("Error comparing configuration: %v", err)
+	}
+
+	if len(comparison) != 0 {
+		t.Errorf("Expected no changes but got %+v", comparison)
+	}
+}
@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestDiscoveryRulesIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	session := test.GetTestSession(t)
+
+	params := zabbix.DiscoveryRuleGetParams{
+		SelectFilter:        zabbix.SelectExtendedOutput,
+		SelectLLDMacroPaths: zabbix.SelectExtendedOutput,
+	}
+
+	rules, err := session.GetDiscoveryRules(params)
+	if err != nil {
+		if _, ok := err.(*zabbix.NotFoundError); !ok {
+			t.Fatalf("Error getting discovery rules: %v", err)
+		}
+	}
+
+	if len(rules) == 0 {
+		t.Skip("No discovery rules found")
+	}
+
+	for i, rule := range rules {
+		if rule.ItemID == "" {
+			t.Fatalf("Discovery rule %d has no item ID", i)
+		}
+
+		if rule.Key == "" {
+			t.Fatalf("Discovery rule %d has no key", i)
+		}
+	}
+
+	t.Logf("Validated %d discovery rules", len(rules))
+
+	prototypes, err := session.GetItemPrototypes(zabbix.ItemPrototypeGetParams{
+		DiscoveryIDs: []string{rules[0].ItemID},
+	})
+	if err != nil {
+		if _, ok := err.(*zabbix.NotFoundError); !ok {
+			t.Fatalf("Error getting item prototypes: %v", err)
+		}
+	}
+
+	for i, prototype := range prototypes {
+		if prototype.ItemID == "" {
+			t.Fatalf("Item prototype %d has no item ID", i)
+		}
+	}
+
+	t.Logf("Validated %d item prototypes", len(prototypes))
+}
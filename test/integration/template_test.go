@@ -0,0 +1,37 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestTemplatesIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	session := test.GetTestSession(t)
+
+	params := zabbix.TemplateGetParams{}
+
+	templates, err := session.GetTemplates(params)
+	if err != nil {
+		if _, ok := err.(*zabbix.NotFoundError); !ok {
+			t.Fatalf("Error getting templates: %v", err)
+		}
+	}
+
+	if len(templates) == 0 {
+		t.Skip("No templates found")
+	}
+
+	for i, template := range templates {
+		if template.TemplateID == "" {
+			t.Fatalf("Template %d has no template ID", i)
+		}
+	}
+
+	t.Logf("Validated %d templates", len(templates))
+}
@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestTrendsIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	session := test.GetTestSession(t)
+
+	params := zabbix.TrendGetParams{
+		TimeFrom: time.Now().Add(-24 * time.Hour).Unix(),
+	}
+
+	trends, err := session.GetTrends(params)
+	if err != nil {
+		if _, ok := err.(*zabbix.NotFoundError); !ok {
+			t.Fatalf("Error getting trends: %v", err)
+		}
+	}
+
+	if len(trends) == 0 {
+		t.Skip("No trends found")
+	}
+
+	for i, trend := range trends {
+		if trend.ItemID == "" {
+			t.Fatalf("Trend %d has no item ID", i)
+		}
+	}
+
+	t.Logf("Validated %d trends", len(trends))
+}
+
+func TestTrendIteratorIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	session := test.GetTestSession(t)
+
+	params := zabbix.TrendGetParams{
+		TimeFrom: time.Now().Add(-7 * 24 * time.Hour).Unix(),
+	}
+	params.ResultLimit = 100
+
+	count := 0
+	it := session.NewTrendIterator(params, 24*time.Hour)
+	for it.Next() {
+		var trends []zabbix.Trend
+		if err := it.Scan(&trends); err != nil {
+			t.Fatalf("Error decoding trends: %v", err)
+		}
+
+		count += len(trends)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("Error iterating trends: %v", err)
+	}
+
+	t.Logf("Iterated %d trends", count)
+}
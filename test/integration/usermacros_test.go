@@ -36,3 +36,26 @@ func TestUserMacrosIntegration(t *testing.T) {
 
 	t.Logf("Validated %d user macros", len(macros))
 }
+
+func TestGlobalMacrosIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	session := test.GetTestSession(t)
+
+	macros, err := session.GetGlobalMacros(zabbix.UserMacroGetParams{})
+	if err != nil {
+		if _, ok := err.(*zabbix.NotFoundError); !ok {
+			t.Fatalf("Error getting global macros: %v", err)
+		}
+	}
+
+	for i, macro := range macros {
+		if macro.GlobalMacroID == "" {
+			t.Fatalf("Global macro %d returned in response body has no Global Macro ID", i)
+		}
+	}
+
+	t.Logf("Validated %d global macros", len(macros))
+}
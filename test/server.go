@@ -0,0 +1,110 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// HandlerFunc answers a fake JSON-RPC API call with a result, or with an
+// APIError if the returned error is an *zabbix.APIError.
+type HandlerFunc func(params json.RawMessage) (interface{}, error)
+
+// Server is a fake Zabbix JSON-RPC API server for unit tests.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	calls    []*zabbix.Request
+}
+
+// NewServer starts a fake Zabbix API server which is closed at the end of the
+// test.
+func NewServer(t *testing.T) *Server {
+	s := &Server{handlers: make(map[string]HandlerFunc)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Handle registers a handler for the given API method.
+func (s *Server) Handle(method string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[method] = handler
+}
+
+// Calls returns the requests received by the server so far.
+func (s *Server) Calls() []*zabbix.Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*zabbix.Request{}, s.calls...)
+}
+
+// Session returns a Session connected to the fake server and reporting the
+// given API version.
+func (s *Server) Session(t *testing.T, version string) *zabbix.Session {
+	ver, err := types.NewZBXVersion(version)
+	if err != nil {
+		t.Fatalf("Error parsing version %q: %v", version, err)
+	}
+
+	return &zabbix.Session{
+		URL:        s.URL,
+		Token:      "0424bd59b807674191e7d77572075f33",
+		APIVersion: ver,
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		zabbix.Request
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(b, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Request.Params = req.Params
+
+	s.mu.Lock()
+	s.calls = append(s.calls, &req.Request)
+	handler, ok := s.handlers[req.Method]
+	s.mu.Unlock()
+
+	resp := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.RequestID,
+	}
+
+	if !ok {
+		resp["error"] = &zabbix.APIError{Code: -32601, Message: "Method not found.", Data: "Incorrect method \"" + req.Method + "\"."}
+	} else if result, err := handler(req.Params); err != nil {
+		apiErr, ok := err.(*zabbix.APIError)
+		if !ok {
+			apiErr = &zabbix.APIError{Code: -32500, Message: "Application error.", Data: err.Error()}
+		}
+		resp["error"] = apiErr
+	} else {
+		resp["result"] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
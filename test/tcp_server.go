@@ -0,0 +1,49 @@
+package test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix/zbxd"
+)
+
+// TCPHandlerFunc answers a ZBXD request packet with the data of the response
+// packet. No response is sent if it returns nil.
+type TCPHandlerFunc func(request []byte) []byte
+
+// NewTCPServer starts a local ZBXD TCP stand-in for a Zabbix server, proxy or
+// agent which is closed at the end of the test, and returns its address.
+func NewTCPServer(t *testing.T, compress bool, handler TCPHandlerFunc) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Error starting TCP server: %v", err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				req, err := zbxd.Read(conn, 0)
+				if err != nil {
+					t.Errorf("Error reading request: %v", err)
+					return
+				}
+
+				if resp := handler(req); resp != nil {
+					if err := zbxd.Write(conn, resp, compress); err != nil {
+						t.Errorf("Error writing response: %v", err)
+					}
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
@@ -0,0 +1,351 @@
+package zabbix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// Warning describes a request parameter rewritten or dropped by a Session for
+// the version of the connected Zabbix API.
+type Warning struct {
+	// Method is the name of the API method called.
+	Method string
+
+	// Param is the name of the request parameter or object field.
+	Param string
+
+	// Replacement is the name Param was rewritten to, or empty if Param was
+	// dropped as it is not supported by the connected Zabbix API.
+	Replacement string
+
+	// Version is the version of the connected Zabbix API.
+	Version string
+}
+
+func (w *Warning) String() string {
+	if w.Replacement == "" {
+		return fmt.Sprintf("%s: %s is not supported by Zabbix API v%s and was ignored", w.Method, w.Param, w.Version)
+	}
+	return fmt.Sprintf("%s: %s was rewritten to %s for Zabbix API v%s", w.Method, w.Param, w.Replacement, w.Version)
+}
+
+// translation is a request parameter or object field renamed or removed in a
+// Zabbix version.
+type translation struct {
+	// methods are the API methods the translation applies to, such as
+	// host.get, or host.* for all the methods of an object.
+	methods []string
+
+	// param is the parameter used up to since, or the field of the objects
+	// of methods if field is true.
+	param string
+
+	// replacement is the parameter replacing param from since on, or empty
+	// if param was removed without replacement.
+	replacement string
+
+	// property and replacementProperty are the properties of the returned
+	// objects filled by param and replacement, if they differ, as for
+	// selectGroups and selectHostGroups.
+	property            string
+	replacementProperty string
+
+	field bool
+	since *types.ZBXVersion
+
+	// convert converts the value of param to the value of replacement. The
+	// translation is not reversed for older versions if set.
+	convert func(v interface{}) interface{}
+}
+
+var translations []translation
+
+func init() {
+	selectGroups := func(replacement, property string, methods ...string) translation {
+		return translation{
+			methods:             methods,
+			param:               "selectGroups",
+			replacement:         replacement,
+			property:            "groups",
+			replacementProperty: property,
+			since:               zabbixVersion620,
+		}
+	}
+
+	translations = []translation{
+		// host groups were split from template groups in 6.2
+		selectGroups("selectHostGroups", "hostgroups", "host.get", "hostprototype.get", "trigger.get", "maintenance.get"),
+		selectGroups("selectTemplateGroups", "templategroups", "template.get"),
+		{methods: []string{"hostgroup.get"}, param: "monitored_hosts", replacement: "with_monitored_hosts", since: zabbixVersion620},
+		{methods: []string{"hostgroup.get"}, param: "real_hosts", replacement: "with_hosts", since: zabbixVersion620},
+
+		// applications were replaced by tags in 5.4
+		{methods: []string{"host.get", "item.get", "trigger.get"}, param: "applicationids", since: zabbixVersion540},
+		{methods: []string{"host.get", "item.get", "template.get"}, param: "selectApplications", since: zabbixVersion540},
+
+		// host and group IDs of maintenances were replaced by objects in 6.0
+		{methods: []string{"maintenance.create", "maintenance.update"}, param: "hostids", replacement: "hosts", since: zabbixVersion600, convert: idObjects("hostid")},
+		{methods: []string{"maintenance.create", "maintenance.update"}, param: "groupids", replacement: "groups", since: zabbixVersion600, convert: idObjects("groupid")},
+
+		// proxies became standalone objects in 7.0
+		{methods: []string{"host.*"}, param: "proxy_hostid", replacement: "proxyid", field: true, since: zabbixVersion700},
+		{methods: []string{"proxy.*"}, param: "host", replacement: "name", field: true, since: zabbixVersion700},
+		{methods: []string{"proxy.get"}, param: "selectInterface", since: zabbixVersion700},
+	}
+}
+
+// idObjects returns a function converting an array of IDs to an array of
+// objects holding the IDs under the given key, as {"hostid": "10084"}.
+func idObjects(key string) func(v interface{}) interface{} {
+	return func(v interface{}) interface{} {
+		ids, _ := v.([]interface{})
+		objects := make([]interface{}, 0, len(ids))
+		for _, id := range ids {
+			objects = append(objects, map[string]interface{}{key: id})
+		}
+		return objects
+	}
+}
+
+func (t *translation) matches(method string) bool {
+	for _, m := range t.methods {
+		if m == method || strings.HasSuffix(m, ".*") && strings.HasPrefix(method, strings.TrimSuffix(m, "*")) {
+			return true
+		}
+	}
+	return false
+}
+
+// alias is a property of returned objects copied to another one, so that
+// objects decode the same way whichever parameter was sent.
+type alias struct {
+	from, to string
+}
+
+// translator rewrites the parameters of a request for the version of the
+// connected Zabbix API.
+type translator struct {
+	method   string
+	ver      *types.ZBXVersion
+	warnings []*Warning
+	aliases  []alias
+	changed  bool
+}
+
+// rename returns the name the given parameter is rewritten to for the
+// version, and false if it is left as is.
+func (tr *translator) rename(t *translation, name string) (string, bool) {
+	removed := tr.ver.Compare(t.since) >= 0
+	switch {
+	case name == t.param && removed:
+		return t.replacement, true
+	case name == t.replacement && t.replacement != "" && !removed && t.convert == nil:
+		return t.param, true
+	}
+	return name, false
+}
+
+func (tr *translator) warn(param, replacement string) {
+	tr.warnings = append(tr.warnings, &Warning{
+		Method:      tr.method,
+		Param:       param,
+		Replacement: replacement,
+		Version:     tr.ver.String(),
+	})
+	tr.changed = true
+}
+
+// object translates the keys of a JSON object in place.
+func (tr *translator) object(obj map[string]interface{}, field bool) {
+	for i := range translations {
+		t := &translations[i]
+		if t.field != field || !t.matches(tr.method) {
+			continue
+		}
+
+		for _, name := range []string{t.param, t.replacement} {
+			v, ok := obj[name]
+			if name == "" || !ok {
+				continue
+			}
+			to, ok := tr.rename(t, name)
+			if !ok {
+				continue
+			}
+			if v == nil {
+				// null parameters are ignored by the API
+				delete(obj, name)
+				tr.changed = true
+				continue
+			}
+
+			delete(obj, name)
+			tr.warn(name, to)
+			if to == "" {
+				continue
+			}
+
+			if t.convert != nil {
+				v = t.convert(v)
+				if existing, ok := obj[to].([]interface{}); ok {
+					v = append(existing, v.([]interface{})...)
+				}
+			}
+			if _, ok := obj[to]; !ok || t.convert != nil {
+				obj[to] = v
+			}
+
+			if t.property != "" {
+				if to == t.replacement {
+					tr.aliases = append(tr.aliases, alias{t.replacementProperty, t.property})
+				} else {
+					tr.aliases = append(tr.aliases, alias{t.property, t.replacementProperty})
+				}
+			}
+		}
+	}
+}
+
+// names translates field names listed as strings, as in output.
+func (tr *translator) names(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return tr.fieldName(v)
+	case []interface{}:
+		for i, name := range v {
+			if s, ok := name.(string); ok {
+				v[i] = tr.fieldName(s)
+			}
+		}
+	}
+	return v
+}
+
+func (tr *translator) fieldName(name string) string {
+	for i := range translations {
+		t := &translations[i]
+		if !t.field || !t.matches(tr.method) {
+			continue
+		}
+		if to, ok := tr.rename(t, name); ok && to != "" {
+			tr.warn(name, to)
+			return to
+		}
+	}
+	return name
+}
+
+// params translates the parameters of the request.
+func (tr *translator) params(params interface{}) {
+	// field renames apply to returned objects in both directions
+	for _, t := range translations {
+		if t.field && t.replacement != "" && t.matches(tr.method) {
+			tr.aliases = append(tr.aliases, alias{t.replacement, t.param}, alias{t.param, t.replacement})
+		}
+	}
+
+	switch params := params.(type) {
+	case map[string]interface{}:
+		if !strings.HasSuffix(tr.method, ".get") {
+			tr.object(params, true)
+		}
+		tr.object(params, false)
+
+		for _, key := range []string{"filter", "search"} {
+			if obj, ok := params[key].(map[string]interface{}); ok {
+				tr.object(obj, true)
+			}
+		}
+		for _, key := range []string{"output", "sortfield"} {
+			if v, ok := params[key]; ok {
+				params[key] = tr.names(v)
+			}
+		}
+
+	case []interface{}:
+		for _, e := range params {
+			if obj, ok := e.(map[string]interface{}); ok {
+				tr.object(obj, true)
+				tr.object(obj, false)
+			}
+		}
+	}
+}
+
+// result copies the aliased properties of the returned objects.
+func (tr *translator) result(v interface{}) bool {
+	changed := false
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, a := range tr.aliases {
+			value, ok := v[a.from]
+			if _, exists := v[a.to]; ok && !exists {
+				v[a.to] = value
+				changed = true
+			}
+		}
+
+	case []interface{}:
+		for _, e := range v {
+			if tr.result(e) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// decodeJSON decodes a JSON value keeping numbers as is.
+func decodeJSON(b []byte) (interface{}, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// translateRequest rewrites the deprecated and removed parameters of req for
+// the given API version, and returns a translator to apply to the response.
+// The parameters are left untouched if nothing needs to be rewritten.
+func translateRequest(req *Request, ver *types.ZBXVersion) (*translator, error) {
+	tr := &translator{method: req.Method, ver: ver}
+
+	b, err := json.Marshal(req.Params)
+	if err != nil {
+		return nil, err
+	}
+	params, err := decodeJSON(b)
+	if err != nil {
+		return nil, err
+	}
+
+	tr.params(params)
+	if tr.changed {
+		req.Params = params
+	}
+
+	return tr, nil
+}
+
+// translateResponse copies the properties of the returned objects renamed
+// since the version the request was written for.
+func (tr *translator) translateResponse(resp *Response) {
+	if len(tr.aliases) == 0 || len(resp.Body) == 0 {
+		return
+	}
+
+	v, err := decodeJSON(resp.Body)
+	if err != nil || !tr.result(v) {
+		return
+	}
+
+	if b, err := json.Marshal(v); err == nil {
+		resp.Body = b
+	}
+}
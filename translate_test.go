@@ -0,0 +1,172 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestTranslateSelectGroups(t *testing.T) {
+	tests := map[string]struct {
+		params   zabbix.HostGetParams
+		expected string
+		result   string
+	}{
+		"7.0.0": {
+			params:   zabbix.HostGetParams{SelectGroups: zabbix.SelectExtendedOutput},
+			expected: `{"output":"extend","selectHostGroups":"extend"}`,
+			result:   `[{"hostid":"10084","proxyid":"3","hostgroups":[{"groupid":"2"}]}]`,
+		},
+		"6.0.0": {
+			params:   zabbix.HostGetParams{SelectHostGroups: zabbix.SelectExtendedOutput},
+			expected: `{"output":"extend","selectGroups":"extend"}`,
+			result:   `[{"hostid":"10084","proxy_hostid":"3","groups":[{"groupid":"2"}]}]`,
+		},
+	}
+
+	for version, tc := range tests {
+		server := test.NewServer(t)
+		server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+			return json.RawMessage(tc.result), nil
+		})
+		session := server.Session(t, version)
+
+		var warnings []*zabbix.Warning
+		session.Warn = func(w *zabbix.Warning) { warnings = append(warnings, w) }
+
+		tc.params.OutputFields = zabbix.SelectExtendedOutput
+		hosts, err := session.GetHosts(tc.params)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if params := string(server.Calls()[0].Params.(json.RawMessage)); params != tc.expected {
+			t.Errorf("Expected params %s for v%s but got %s", tc.expected, version, params)
+		}
+		if len(warnings) != 1 || warnings[0].Version != version {
+			t.Errorf("Expected a warning for v%s but got %v", version, warnings)
+		}
+
+		// returned objects decode the same way for all versions
+		host := hosts[0]
+		if len(host.Groups) != 1 || len(host.HostGroups) != 1 || host.ProxyHostID != "3" {
+			t.Errorf("Unexpected host for v%s: %+v", version, host)
+		}
+	}
+}
+
+func TestTranslateProxyFields(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("proxy.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{"proxyid":"3","name":"proxy01"}]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	var warnings []*zabbix.Warning
+	session.Warn = func(w *zabbix.Warning) { warnings = append(warnings, w) }
+
+	proxies, err := session.GetProxies(zabbix.ProxyGetParams{
+		GetParameters: zabbix.GetParameters{
+			OutputFields: zabbix.SelectFields{"proxyid", "host"},
+			Filter:       map[string]interface{}{"host": "proxy01"},
+		},
+		SelectInterface: zabbix.SelectExtendedOutput,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"filter":{"name":"proxy01"},"output":["proxyid","name"]}`
+	if params := string(server.Calls()[0].Params.(json.RawMessage)); params != expected {
+		t.Errorf("Expected params %s but got %s", expected, params)
+	}
+
+	if proxies[0].Host != "proxy01" {
+		t.Errorf("Expected proxy name in Host but got %+v", proxies[0])
+	}
+
+	if len(warnings) != 3 {
+		t.Fatalf("Expected 3 warnings but got %v", warnings)
+	}
+	for _, w := range warnings {
+		if w.Param == "selectInterface" && w.Replacement != "" {
+			t.Errorf("Expected selectInterface to be ignored but got %s", w)
+		}
+	}
+}
+
+func TestTranslateMaintenanceHostIDs(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("maintenance.create", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`{"maintenanceids":["7"]}`), nil
+	})
+
+	calls := []struct {
+		version string
+		hostIDs []string
+	}{
+		{"5.0.0", []string{"10084", "10085"}},
+		{"7.0.0", []string{"10084", "10085"}},
+		{"7.0.0", nil},
+	}
+	for _, call := range calls {
+		session := server.Session(t, call.version)
+		params := &zabbix.MaintenanceCreateParams{
+			Maintenance: zabbix.Maintenance{Name: "Patching"},
+			HostIDs:     call.hostIDs,
+		}
+		if err := session.Get("maintenance.create", params, &zabbix.MaintenanceCreateResponse{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var params []map[string]interface{}
+	for _, call := range server.Calls() {
+		var p map[string]interface{}
+		if err := json.Unmarshal(call.Params.(json.RawMessage), &p); err != nil {
+			t.Fatal(err)
+		}
+		params = append(params, p)
+	}
+
+	if _, ok := params[0]["hostids"]; !ok {
+		t.Errorf("Expected hostids for v5.0 but got %v", params[0])
+	}
+
+	hosts, _ := params[1]["hosts"].([]interface{})
+	if _, ok := params[1]["hostids"]; ok || len(hosts) != 2 {
+		t.Fatalf("Expected hosts for v7.0 but got %v", params[1])
+	}
+	if host, _ := hosts[1].(map[string]interface{}); host["hostid"] != "10085" {
+		t.Errorf("Unexpected host %v", hosts[1])
+	}
+
+	// null host IDs must not clear the hosts of the maintenance
+	if _, ok := params[2]["hostids"]; ok {
+		t.Errorf("Expected null hostids to be dropped but got %v", params[2])
+	}
+	if _, ok := params[2]["hosts"]; ok {
+		t.Errorf("Expected no hosts but got %v", params[2])
+	}
+}
+
+func TestTranslateUnchanged(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("host.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[{"hostid":"10084"}]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+	session.Warn = func(w *zabbix.Warning) { t.Errorf("Unexpected warning: %s", w) }
+
+	_, err := session.GetHosts(zabbix.HostGetParams{HostIDs: []string{"10084"}, SelectHostGroups: zabbix.SelectExtendedOutput})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"hostids":["10084"],"selectHostGroups":"extend"}`
+	if params := string(server.Calls()[0].Params.(json.RawMessage)); params != expected {
+		t.Errorf("Expected params %s but got %s", expected, params)
+	}
+}
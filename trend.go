@@ -0,0 +1,267 @@
+package zabbix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/NexonSU/go-zabbix/types"
+)
+
+// Trend represents a Zabbix Trend of an Item with the numeric float value
+// type, returned from the Zabbix API.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/trend/object
+type Trend struct {
+	// ItemID is the ID of the related Item.
+	ItemID string `json:"itemid"`
+
+	// Timestamp is the start of the hour the Trend values were aggregated for.
+	Timestamp types.ZBXUnixTimestamp `json:"clock"`
+
+	// Num is the number of values that were received during the hour.
+	Num types.ZBXInt `json:"num"`
+
+	// ValueMin is the hourly minimum value.
+	ValueMin float64 `json:"value_min,string"`
+
+	// ValueAvg is the hourly average value.
+	ValueAvg float64 `json:"value_avg,string"`
+
+	// ValueMax is the hourly maximum value.
+	ValueMax float64 `json:"value_max,string"`
+}
+
+// TrendUint represents a Zabbix Trend of an Item with the numeric unsigned
+// value type, returned from the Zabbix API.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/trend/object
+type TrendUint struct {
+	// ItemID is the ID of the related Item.
+	ItemID string `json:"itemid"`
+
+	// Timestamp is the start of the hour the Trend values were aggregated for.
+	Timestamp types.ZBXUnixTimestamp `json:"clock"`
+
+	// Num is the number of values that were received during the hour.
+	Num types.ZBXInt `json:"num"`
+
+	// ValueMin is the hourly minimum value.
+	ValueMin uint64 `json:"value_min,string"`
+
+	// ValueAvg is the hourly average value.
+	ValueAvg uint64 `json:"value_avg,string"`
+
+	// ValueMax is the hourly maximum value.
+	ValueMax uint64 `json:"value_max,string"`
+}
+
+// TrendGetParams is query params for trend.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/trend/get
+type TrendGetParams struct {
+	GetParameters
+
+	// ItemIDs filters search results to trends of the given Item IDs.
+	ItemIDs []string `json:"itemids,omitempty"`
+
+	// TimeFrom filters search results to trends that have been collected
+	// after or at the given timestamp.
+	TimeFrom int64 `json:"time_from,omitempty"`
+
+	// TimeTill filters search results to trends that have been collected
+	// before or at the given timestamp.
+	TimeTill int64 `json:"time_till,omitempty"`
+}
+
+// GetTrends queries the Zabbix API for Trends of numeric float Items matching
+// the given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetTrends(params TrendGetParams) ([]Trend, error) {
+	trends := make([]Trend, 0)
+	err := c.Get("trend.get", params, &trends)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(trends) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return trends, nil
+}
+
+// GetTrendsUint queries the Zabbix API for Trends of numeric unsigned Items
+// matching the given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetTrendsUint(params TrendGetParams) ([]TrendUint, error) {
+	trends := make([]TrendUint, 0)
+	err := c.Get("trend.get", params, &trends)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(trends) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return trends, nil
+}
+
+// ErrTrendIteratorNoTimeFrom is returned by TrendIterator.Err if
+// TrendGetParams.TimeFrom was not given.
+var ErrTrendIteratorNoTimeFrom = errors.New("No start time was given for the trend iterator")
+
+// TrendIterator walks the Trends of a long time period in consecutive time
+// windows, one `trend.get` call per window.
+//
+// If GetParameters.ResultLimit is set and a window returns as many results as
+// the limit, the window is split in half and queried again, so no Trends are
+// lost to the limit. Windows are never split below one hour, the resolution
+// of Trends: such windows hold at most one Trend per Item and are queried
+// again in batches of ItemIDs. Next fails if ItemIDs is not set.
+//
+//	it := session.NewTrendIterator(params, 24*time.Hour)
+//	for it.Next() {
+//		var trends []zabbix.Trend
+//		if err := it.Scan(&trends); err != nil {
+//			return err
+//		}
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type TrendIterator struct {
+	session *Session
+	params  TrendGetParams
+	window  time.Duration
+	from    int64
+	till    int64
+	page    []json.RawMessage
+	err     error
+}
+
+// NewTrendIterator returns a TrendIterator for Trends between
+// params.TimeFrom and params.TimeTill using windows of the given size. If
+// params.TimeTill is not set, the current time is used. params.TimeFrom is
+// required, as iterating from the Unix epoch would query decades of empty
+// windows: the iterator fails with ErrTrendIteratorNoTimeFrom otherwise.
+func (c *Session) NewTrendIterator(params TrendGetParams, window time.Duration) *TrendIterator {
+	if window < time.Hour {
+		window = time.Hour
+	}
+
+	till := params.TimeTill
+	if till == 0 {
+		till = time.Now().Unix()
+	}
+
+	it := &TrendIterator{
+		session: c,
+		params:  params,
+		window:  window,
+		from:    params.TimeFrom,
+		till:    till,
+	}
+	if params.TimeFrom == 0 {
+		it.err = ErrTrendIteratorNoTimeFrom
+	}
+
+	return it
+}
+
+// Next fetches the Trends of the next non-empty time window. It returns false
+// when the time period is exhausted or an error occurs.
+func (it *TrendIterator) Next() bool {
+	window := int64(it.window / time.Second)
+	limit := it.params.GetParameters.ResultLimit
+
+	for it.err == nil && it.from <= it.till {
+		till := it.from + window - 1
+		if till > it.till {
+			till = it.till
+		}
+
+		params := it.params
+		params.TimeFrom = it.from
+		params.TimeTill = till
+
+		page := make([]json.RawMessage, 0)
+		if it.err = it.session.Get("trend.get", params, &page); it.err != nil {
+			return false
+		}
+
+		// narrow the window if results may have been cut by the limit
+		if limit > 0 && len(page) >= limit {
+			if till-it.from >= 3600 {
+				window = (till - it.from + 1) / 2
+				continue
+			}
+
+			if page, it.err = it.getItems(params, page); it.err != nil {
+				return false
+			}
+		}
+
+		it.from = till + 1
+		if len(page) > 0 {
+			it.page = page
+			return true
+		}
+	}
+
+	return false
+}
+
+// getItems returns the Trends of a full window below one hour, which holds at
+// most one Trend per Item, by querying as many Items per call as the result
+// limit.
+func (it *TrendIterator) getItems(params TrendGetParams, page []json.RawMessage) ([]json.RawMessage, error) {
+	limit := params.GetParameters.ResultLimit
+	if len(params.ItemIDs) == 0 {
+		return nil, fmt.Errorf("Trends between %d and %d exceed the result limit of %d, set ItemIDs to query them by Item", params.TimeFrom, params.TimeTill, limit)
+	}
+	if len(params.ItemIDs) <= limit {
+		return page, nil
+	}
+
+	page = make([]json.RawMessage, 0, len(params.ItemIDs))
+	for i := 0; i < len(params.ItemIDs); i += limit {
+		batch := params
+		batch.ItemIDs = params.ItemIDs[i:]
+		if len(batch.ItemIDs) > limit {
+			batch.ItemIDs = batch.ItemIDs[:limit]
+		}
+
+		trends := make([]json.RawMessage, 0)
+		if err := it.session.Get("trend.get", batch, &trends); err != nil {
+			return nil, err
+		}
+		page = append(page, trends...)
+	}
+
+	return page, nil
+}
+
+// Scan decodes the Trends of the current window into v, which should be a
+// pointer to []Trend or []TrendUint depending on the value type of the
+// queried Items.
+func (it *TrendIterator) Scan(v interface{}) error {
+	b, err := json.Marshal(it.page)
+	if err != nil {
+		return err
+	}
+
+	return (&Response{Body: b}).Bind(v)
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *TrendIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,225 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestGetTrends(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("trend.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[
+			{"itemid":"23296","clock":"1683640800","num":"60","value_min":"0.0012","value_avg":"0.0150","value_max":"0.1200"},
+			{"itemid":"23296","clock":"1683644400","num":"59","value_min":"0.0010","value_avg":"0.0140","value_max":"0.1000"}
+		]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	trends, err := session.GetTrends(zabbix.TrendGetParams{ItemIDs: []string{"23296"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(trends) != 2 {
+		t.Fatalf("Expected 2 trends but got %d", len(trends))
+	}
+
+	if trends[0].Num != 60 || trends[0].ValueAvg != 0.015 || trends[1].ValueMax != 0.1 {
+		t.Errorf("Unexpected trend values: %+v", trends)
+	}
+
+	if unix := time.Time(trends[1].Timestamp).Unix(); unix != 1683644400 {
+		t.Errorf("Expected trend timestamp 1683644400 but got %d", unix)
+	}
+}
+
+func TestGetTrendsUint(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("trend.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[
+			{"itemid":"23297","clock":"1683640800","num":"60","value_min":"18446744073709551000","value_avg":"18446744073709551100","value_max":"18446744073709551615"}
+		]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	trends, err := session.GetTrendsUint(zabbix.TrendGetParams{ItemIDs: []string{"23297"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if trends[0].ValueMax != 18446744073709551615 {
+		t.Errorf("Expected max uint64 but got %d", trends[0].ValueMax)
+	}
+}
+
+func TestTrendIterator(t *testing.T) {
+	const (
+		from  = 1683590400 // 2023-05-09 00:00:00 UTC
+		hours = 72
+		limit = 20
+	)
+
+	// one trend per hour, limited like the real API
+	server := test.NewServer(t)
+	server.Handle("trend.get", func(params json.RawMessage) (interface{}, error) {
+		var p zabbix.TrendGetParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+
+		trends := make([]map[string]string, 0)
+		for clock := int64(from); clock < from+hours*3600; clock += 3600 {
+			if clock >= p.TimeFrom && clock <= p.TimeTill && len(trends) < p.ResultLimit {
+				trends = append(trends, map[string]string{
+					"itemid":    "1",
+					"clock":     fmt.Sprint(clock),
+					"num":       "60",
+					"value_min": "1",
+					"value_avg": "1",
+					"value_max": "1",
+				})
+			}
+		}
+
+		return trends, nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	params := zabbix.TrendGetParams{
+		TimeFrom: from,
+		TimeTill: from + hours*3600 - 1,
+	}
+	params.ResultLimit = limit
+
+	seen := make(map[int64]bool)
+	it := session.NewTrendIterator(params, 7*24*time.Hour)
+	for it.Next() {
+		var trends []zabbix.TrendUint
+		if err := it.Scan(&trends); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, trend := range trends {
+			unix := time.Time(trend.Timestamp).Unix()
+			if seen[unix] {
+				t.Errorf("Trend %d returned twice", unix)
+			}
+			seen[unix] = true
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(seen) != hours {
+		t.Errorf("Expected %d trends but got %d", hours, len(seen))
+	}
+}
+
+func TestTrendIteratorItems(t *testing.T) {
+	const (
+		from  = 1683590400 // 2023-05-09 00:00:00 UTC
+		hours = 4
+		limit = 2
+	)
+	items := []string{"1", "2", "3", "4", "5"}
+
+	// one trend per item and hour, more items than the limit
+	server := test.NewServer(t)
+	server.Handle("trend.get", func(params json.RawMessage) (interface{}, error) {
+		var p zabbix.TrendGetParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		if len(p.ItemIDs) == 0 {
+			p.ItemIDs = items
+		}
+
+		trends := make([]map[string]string, 0)
+		for clock := int64(from); clock < from+hours*3600; clock += 3600 {
+			for _, id := range p.ItemIDs {
+				if clock >= p.TimeFrom && clock <= p.TimeTill && len(trends) < p.ResultLimit {
+					trends = append(trends, map[string]string{
+						"itemid":    id,
+						"clock":     fmt.Sprint(clock),
+						"num":       "60",
+						"value_min": "1",
+						"value_avg": "1",
+						"value_max": "1",
+					})
+				}
+			}
+		}
+
+		return trends, nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	params := zabbix.TrendGetParams{
+		ItemIDs:  items,
+		TimeFrom: from,
+		TimeTill: from + hours*3600 - 1,
+	}
+	params.ResultLimit = limit
+
+	seen := make(map[string]bool)
+	it := session.NewTrendIterator(params, 24*time.Hour)
+	for it.Next() {
+		var trends []zabbix.TrendUint
+		if err := it.Scan(&trends); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, trend := range trends {
+			key := fmt.Sprintf("%s@%d", trend.ItemID, time.Time(trend.Timestamp).Unix())
+			if seen[key] {
+				t.Errorf("Trend %s returned twice", key)
+			}
+			seen[key] = true
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(seen) != hours*len(items) {
+		t.Errorf("Expected %d trends but got %d", hours*len(items), len(seen))
+	}
+
+	// without item IDs, the window cannot be split any further
+	params.ItemIDs = nil
+	it = session.NewTrendIterator(params, 24*time.Hour)
+	for it.Next() {
+	}
+
+	if err := it.Err(); err == nil || !strings.Contains(err.Error(), "exceed the result limit") {
+		t.Errorf("Expected a result limit error but got %v", err)
+	}
+}
+
+func TestTrendIteratorNoTimeFrom(t *testing.T) {
+	server := test.NewServer(t)
+	session := server.Session(t, "7.0.0")
+
+	it := session.NewTrendIterator(zabbix.TrendGetParams{ItemIDs: []string{"1"}}, time.Hour)
+	if it.Next() {
+		t.Errorf("Expected no trends without a start time")
+	}
+	if err := it.Err(); err != zabbix.ErrTrendIteratorNoTimeFrom {
+		t.Errorf("Expected ErrTrendIteratorNoTimeFrom but got %v", err)
+	}
+
+	for _, call := range server.Calls() {
+		if call.Method == "trend.get" {
+			t.Errorf("Expected no trend.get call")
+		}
+	}
+}
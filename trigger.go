@@ -1,28 +1,42 @@
 package zabbix
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/NexonSU/go-zabbix/types"
 )
 
+// TriggerAlarmState is the value of a Trigger, OK or problem.
+type TriggerAlarmState int
+
 const (
 	// TriggerAlarmStateOK means a normal trigger state. Called FALSE in older Zabbix versions.
-	TriggerAlarmStateOK = iota
+	TriggerAlarmStateOK TriggerAlarmState = iota
 
 	// TriggerAlarmStateProblem normally means that something happened.
 	TriggerAlarmStateProblem
 )
 
+// TriggerState is whether a Trigger is evaluated normally or is unknown.
+type TriggerState int
+
 const (
 	// TriggerStateNormal means normal trigger state
-	TriggerStateNormal = iota
+	TriggerStateNormal TriggerState = iota
 
 	// TriggerStateUnknown means unknown trigger state
 	TriggerStateUnknown
 )
 
+// Severity is the severity of a Trigger or an Event.
+type Severity int
+
 const (
 	// TriggerSeverityNotClassified is Not classified severity
-	TriggerSeverityNotClassified = iota
+	TriggerSeverityNotClassified Severity = iota
 
 	// TriggerSeverityInformation is Information severity
 	TriggerSeverityInformation
@@ -40,6 +54,210 @@ const (
 	TriggerSeverityDisaster
 )
 
+var (
+	triggerAlarmStates = enum{"TriggerAlarmState", map[int]string{
+		int(TriggerAlarmStateOK):      "ok",
+		int(TriggerAlarmStateProblem): "problem",
+	}}
+
+	triggerStates = enum{"TriggerState", map[int]string{
+		int(TriggerStateNormal):  "normal",
+		int(TriggerStateUnknown): "unknown",
+	}}
+
+	severities = enum{"Severity", map[int]string{
+		int(TriggerSeverityNotClassified): "not_classified",
+		int(TriggerSeverityInformation):   "information",
+		int(TriggerSeverityWarning):       "warning",
+		int(TriggerSeverityAverage):       "average",
+		int(TriggerSeverityHigh):          "high",
+		int(TriggerSeverityDisaster):      "disaster",
+	}}
+)
+
+// String returns the name of the TriggerAlarmState.
+func (s TriggerAlarmState) String() string {
+	return triggerAlarmStates.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TriggerAlarmState) MarshalText() ([]byte, error) {
+	return triggerAlarmStates.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *TriggerAlarmState) UnmarshalText(text []byte) error {
+	return triggerAlarmStates.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s TriggerAlarmState) MarshalJSON() ([]byte, error) {
+	return triggerAlarmStates.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *TriggerAlarmState) UnmarshalJSON(data []byte) error {
+	return triggerAlarmStates.unmarshalJSON(data, (*int)(s))
+}
+
+// String returns the name of the TriggerState.
+func (s TriggerState) String() string {
+	return triggerStates.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s TriggerState) MarshalText() ([]byte, error) {
+	return triggerStates.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *TriggerState) UnmarshalText(text []byte) error {
+	return triggerStates.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s TriggerState) MarshalJSON() ([]byte, error) {
+	return triggerStates.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *TriggerState) UnmarshalJSON(data []byte) error {
+	return triggerStates.unmarshalJSON(data, (*int)(s))
+}
+
+// String returns the name of the Severity, such as not_classified or high.
+func (s Severity) String() string {
+	return severities.format(int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return severities.marshalText(int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Names and numbers are
+// accepted.
+func (s *Severity) UnmarshalText(text []byte) error {
+	return severities.unmarshalText(text, (*int)(s))
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return severities.marshalJSON(int(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (s *Severity) UnmarshalJSON(data []byte) error {
+	return severities.unmarshalJSON(data, (*int)(s))
+}
+
+// SeverityMask is a set of severities, such as the severities a user Media
+// is notified about.
+type SeverityMask int
+
+// SeverityMaskAll holds all the severities.
+const SeverityMaskAll SeverityMask = 1<<(TriggerSeverityDisaster+1) - 1
+
+// NewSeverityMask returns a SeverityMask holding the given severities.
+// Values which are not TriggerSeverity constants are ignored.
+func NewSeverityMask(severities ...Severity) SeverityMask {
+	var m SeverityMask
+	for _, s := range severities {
+		if validSeverity(s) {
+			m |= 1 << s
+		}
+	}
+	return m
+}
+
+// Has returns true if the SeverityMask holds the given Severity.
+func (m SeverityMask) Has(s Severity) bool {
+	return validSeverity(s) && m&(1<<s) != 0
+}
+
+func validSeverity(s Severity) bool {
+	return s >= TriggerSeverityNotClassified && s <= TriggerSeverityDisaster
+}
+
+// Severities returns the severities of the SeverityMask, lowest first.
+func (m SeverityMask) Severities() []Severity {
+	var list []Severity
+	for s := TriggerSeverityNotClassified; s <= TriggerSeverityDisaster; s++ {
+		if m.Has(s) {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// String returns the names of the severities of the SeverityMask separated by
+// commas, such as average,high,disaster.
+func (m SeverityMask) String() string {
+	list := m.Severities()
+	names := make([]string, len(list))
+	for i, s := range list {
+		names[i] = s.String()
+	}
+	if m&^SeverityMaskAll != 0 {
+		names = append(names, fmt.Sprintf("SeverityMask(%d)", int(m&^SeverityMaskAll)))
+	}
+	return strings.Join(names, ",")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m SeverityMask) MarshalText() ([]byte, error) {
+	if m&^SeverityMaskAll != 0 {
+		return nil, fmt.Errorf("Invalid SeverityMask %d", int(m))
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Severity names separated
+// by commas and numeric masks are accepted.
+func (m *SeverityMask) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 0 || SeverityMask(n)&^SeverityMaskAll != 0 {
+			return fmt.Errorf("Invalid SeverityMask %d", n)
+		}
+		*m = SeverityMask(n)
+		return nil
+	}
+
+	var mask SeverityMask
+	if s != "" {
+		for _, name := range strings.Split(s, ",") {
+			var severity Severity
+			if err := severity.UnmarshalText([]byte(name)); err != nil {
+				return err
+			}
+			mask |= NewSeverityMask(severity)
+		}
+	}
+	*m = mask
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. The value is encoded as a
+// numeric string, as the API expects.
+func (m SeverityMask) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.Itoa(int(m)))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Numbers and numeric strings
+// are accepted.
+func (m *SeverityMask) UnmarshalJSON(data []byte) error {
+	return enum{typ: "SeverityMask"}.unmarshalJSON(data, (*int)(m))
+}
+
 // Trigger represents a Zabbix Trigger returned from the Zabbix API.
 //
 // See: https://www.zabbix.com/documentation/3.4/manual/config/triggers
@@ -50,13 +268,13 @@ type Trigger struct {
 	// AlarmState shows whether the trigger is in OK or problem state.
 	//
 	// AlarmState must be one of the TriggerAlarmState constants.
-	AlarmState int `json:"value,string"`
+	AlarmState TriggerAlarmState `json:"value"`
 
 	// Description is the name of the trigger.
 	Description string `json:"description"`
 
 	// Enabled shows whether the trigger is enabled or disabled.
-	Enabled types.ZBXBoolean `json:"status,string"`
+	Enabled types.ZBXBoolean `json:"status"`
 
 	// Expression is the trigger expression
 	Expression string `json:"expression"`
@@ -74,17 +292,17 @@ type Trigger struct {
 	Groups []Hostgroup `json:"groups"`
 
 	// LastChange is the time when the trigger last changed its state.
-	LastChange int `json:"lastchange,string"`
+	LastChange types.ZBXInt `json:"lastchange"`
 
 	// Severity of the trigger.
 	//
 	// Severity must be one of the TriggerSeverity constants.
-	Severity int `json:"priority,string"`
+	Severity Severity `json:"priority"`
 
 	// State of the trigger.
 	//
 	// State must be one of the TriggerState constants.
-	State int `json:"state,string"`
+	State TriggerState `json:"state"`
 
 	// Tags is an array of trigger tags
 	//
@@ -161,7 +379,7 @@ type TriggerGetParams struct {
 
 	RecentProblemOnly bool `json:"only_true,omitempty"`
 
-	MinSeverity int `json:"min_severity,omitempty"`
+	MinSeverity Severity `json:"min_severity,omitempty"`
 
 	ExpandComment bool `json:"expandComment,omitempty"`
 
@@ -0,0 +1,203 @@
+package zabbix
+
+import "github.com/NexonSU/go-zabbix/types"
+
+// TriggerPrototype represents a Zabbix Trigger Prototype returned from the
+// Zabbix API.
+//
+// Zero values of optional fields are omitted when creating or updating a
+// Trigger Prototype, so the server defaults apply, or the current values are
+// kept. Enumerated fields such as Status are pointers, as their zero value is
+// valid: nil fields are omitted.
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/triggerprototype/object
+type TriggerPrototype struct {
+	// TriggerID is the unique ID of the Trigger Prototype.
+	TriggerID string `json:"triggerid,omitempty"`
+
+	// Description is the name of the Trigger Prototype.
+	Description string `json:"description,omitempty"`
+
+	// Expression is the problem expression, referring to item prototypes.
+	Expression string `json:"expression,omitempty"`
+
+	// EventName is the event name generated by the discovered Triggers.
+	EventName string `json:"event_name,omitempty"`
+
+	// OpData is the operational data of the discovered Triggers.
+	OpData string `json:"opdata,omitempty"`
+
+	// Comments are the additional description of the Trigger Prototype.
+	Comments string `json:"comments,omitempty"`
+
+	// Severity must be one of the TriggerSeverity constants.
+	Severity *Severity `json:"priority,omitempty"`
+
+	// Status is 0 if the Trigger Prototype is enabled and 1 if disabled.
+	Status *types.ZBXInt `json:"status,omitempty"`
+
+	// Discover is 0 if Triggers are discovered from the prototype and 1 if
+	// not.
+	Discover *types.ZBXInt `json:"discover,omitempty"`
+
+	// TemplateID is the ID of the parent template Trigger Prototype.
+	TemplateID string `json:"templateid,omitempty"`
+
+	// Type is 1 if the discovered Triggers generate multiple problem events.
+	Type int `json:"type,string,omitempty"`
+
+	// URL is the URL associated with the Trigger Prototype.
+	URL string `json:"url,omitempty"`
+
+	// RecoveryMode is the OK event generation mode.
+	RecoveryMode int `json:"recovery_mode,string,omitempty"`
+
+	// RecoveryExpression is the recovery expression.
+	RecoveryExpression string `json:"recovery_expression,omitempty"`
+
+	// CorrelationMode is the OK event closing mode.
+	CorrelationMode int `json:"correlation_mode,string,omitempty"`
+
+	// CorrelationTag is the tag used to match OK events.
+	CorrelationTag string `json:"correlation_tag,omitempty"`
+
+	// ManualClose is 1 if problems may be closed manually.
+	ManualClose int `json:"manual_close,string,omitempty"`
+
+	// UUID is the universal unique identifier of a template Trigger
+	// Prototype.
+	UUID string `json:"uuid,omitempty"`
+
+	// Tags are the Trigger Prototype tags.
+	Tags []TriggerTag `json:"tags,omitempty"`
+
+	// Dependencies are the Triggers and Trigger Prototypes the Trigger
+	// Prototype depends on.
+	Dependencies []TriggerPrototypeDependency `json:"dependencies,omitempty"`
+
+	// DiscoveryRule is only populated if TriggerPrototypeGetParams.
+	// SelectDiscoveryRule is given in the query parameters that returned
+	// this Trigger Prototype.
+	DiscoveryRule *DiscoveryRule `json:"discoveryRule,omitempty"`
+
+	// Hosts is only populated if TriggerPrototypeGetParams.SelectHosts is
+	// given in the query parameters that returned this Trigger Prototype.
+	Hosts []Host `json:"hosts,omitempty"`
+}
+
+// TriggerPrototypeDependency is a dependency of a Trigger Prototype.
+type TriggerPrototypeDependency struct {
+	TriggerID string `json:"triggerid"`
+}
+
+// TriggerPrototypeGetParams is query params for triggerprototype.get call
+//
+// See: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/triggerprototype/get
+type TriggerPrototypeGetParams struct {
+	GetParameters
+
+	// TriggerIDs filters search results to Trigger Prototypes with the given
+	// IDs.
+	TriggerIDs []string `json:"triggerids,omitempty"`
+
+	// DiscoveryIDs filters search results to Trigger Prototypes of the given
+	// Discovery Rules.
+	DiscoveryIDs []string `json:"discoveryids,omitempty"`
+
+	// GroupIDs filters search results to Trigger Prototypes of hosts in the
+	// given groups.
+	GroupIDs []string `json:"groupids,omitempty"`
+
+	// HostIDs filters search results to Trigger Prototypes of the given
+	// hosts.
+	HostIDs []string `json:"hostids,omitempty"`
+
+	// TemplateIDs filters search results to Trigger Prototypes of the given
+	// templates.
+	TemplateIDs []string `json:"templateids,omitempty"`
+
+	// Functions filters search results to Trigger Prototypes using the given
+	// functions.
+	Functions []string `json:"functions,omitempty"`
+
+	// Inherited filters search results to Trigger Prototypes inherited from
+	// a template.
+	Inherited bool `json:"inherited,omitempty"`
+
+	// Templated filters search results to Trigger Prototypes of templates.
+	Templated bool `json:"templated,omitempty"`
+
+	// Monitored filters search results to enabled Trigger Prototypes of
+	// monitored hosts.
+	Monitored bool `json:"monitored,omitempty"`
+
+	// MinSeverity filters search results to Trigger Prototypes with the
+	// given severity or higher.
+	MinSeverity Severity `json:"min_severity,omitempty"`
+
+	// ExpandExpression expands functions and macros in the expressions.
+	ExpandExpression bool `json:"expandExpression,omitempty"`
+
+	// SelectDiscoveryRule returns the Discovery Rule of each Trigger
+	// Prototype.
+	SelectDiscoveryRule SelectQuery `json:"selectDiscoveryRule,omitempty"`
+
+	// SelectHosts returns the hosts each Trigger Prototype belongs to.
+	SelectHosts SelectQuery `json:"selectHosts,omitempty"`
+
+	// SelectItems returns the items and item prototypes used by each Trigger
+	// Prototype.
+	SelectItems SelectQuery `json:"selectItems,omitempty"`
+
+	// SelectFunctions returns the functions used by each Trigger Prototype.
+	SelectFunctions SelectQuery `json:"selectFunctions,omitempty"`
+
+	// SelectDependencies returns the dependencies of each Trigger Prototype.
+	SelectDependencies SelectQuery `json:"selectDependencies,omitempty"`
+
+	// SelectTags returns the tags of each Trigger Prototype.
+	SelectTags SelectQuery `json:"selectTags,omitempty"`
+}
+
+// GetTriggerPrototypes queries the Zabbix API for Trigger Prototypes matching
+// the given search parameters.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+func (c *Session) GetTriggerPrototypes(params TriggerPrototypeGetParams) ([]TriggerPrototype, error) {
+	prototypes := make([]TriggerPrototype, 0)
+	err := c.Get("triggerprototype.get", params, &prototypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prototypes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return prototypes, nil
+}
+
+// CreateTriggerPrototypes creates Trigger Prototypes.
+// Returns a list of IDs of the created Trigger Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/triggerprototype/create
+func (c *Session) CreateTriggerPrototypes(prototypes ...TriggerPrototype) ([]string, error) {
+	return c.GetIDs("triggerprototype.create", prototypes, "triggerids")
+}
+
+// UpdateTriggerPrototypes updates Trigger Prototypes.
+// Returns a list of IDs of the updated Trigger Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/triggerprototype/update
+func (c *Session) UpdateTriggerPrototypes(prototypes ...TriggerPrototype) ([]string, error) {
+	return c.GetIDs("triggerprototype.update", prototypes, "triggerids")
+}
+
+// DeleteTriggerPrototypes deletes Trigger Prototypes.
+// Returns a list of IDs of the deleted Trigger Prototypes.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/triggerprototype/delete
+func (c *Session) DeleteTriggerPrototypes(triggerIDs ...string) ([]string, error) {
+	return c.GetIDs("triggerprototype.delete", triggerIDs, "triggerids")
+}
@@ -4,12 +4,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 type ZBXBoolean bool
 
 func (bit *ZBXBoolean) UnmarshalJSON(data []byte) error {
-	asString := string(data)
+	// the value may still be quoted when decoded with the `string` option
+	if string(data) == "null" {
+		return nil
+	}
+
+	asString := strings.Trim(string(data), `"`)
 	if asString == "1" || asString == "true" {
 		*bit = true
 	} else if asString == "0" || asString == "false" {
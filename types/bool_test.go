@@ -8,6 +8,8 @@ func TestBool(t *testing.T) {
 		"true":  true,
 		"0":     false,
 		"false": false,
+		`"1"`:   true,
+		`"0"`:   false,
 	}
 
 	for input, expected := range tests {
@@ -1,57 +1,128 @@
 package types
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
-type ZBXDuration time.Duration
+// ZBXDuration is a Zabbix time period such as 30, 5m or 90d, or a user macro
+// such as {$TIMEOUT}. Zabbix only supports the s, m, h, d and w suffixes in
+// time periods.
+type ZBXDuration struct {
+	Duration time.Duration
 
-var durationRegexp = regexp.MustCompile(`^\"(?P<time>\d+)(?P<suffix>(s|m|h|d|w|M|y))?\"$`)
+	// Macro is the user or LLD macro of the period, such as {$TIMEOUT}, if
+	// not empty. Duration is zero then.
+	Macro string
+}
 
-//func (t ZBXDuration) MarshalJSON() ([]byte, error) {
-//
-//}
+var (
+	durationRegexp      = regexp.MustCompile(`^([0-9]+)([smhdw]?)$`)
+	durationMacroRegexp = regexp.MustCompile(`^\{(\$[A-Z0-9_.]+(:.*)?|#[A-Z0-9_.]+)\}$`)
+)
 
-func (t *ZBXDuration) UnmarshalJSON(data []byte) (err error) {
-	match := durationRegexp.FindSubmatch(data)
+// durationSuffixes are the time suffixes by decreasing length.
+var durationSuffixes = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"w", 7 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
 
-	if len(match) == 0 {
-		*t = 0
-		return nil
+// ParseDuration parses a Zabbix time period. An empty string is a zero
+// period.
+func ParseDuration(s string) (ZBXDuration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ZBXDuration{}, nil
+	}
+	if durationMacroRegexp.MatchString(s) {
+		return ZBXDuration{Macro: s}, nil
+	}
+
+	match := durationRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return ZBXDuration{}, fmt.Errorf("Invalid time period %q", s)
 	}
 
-	time, err := strconv.ParseInt(string(match[1]), 10, 32)
+	n, err := strconv.ParseInt(match[1], 10, 64)
 	if err != nil {
-		return err
+		return ZBXDuration{}, fmt.Errorf("Invalid time period %q: %v", s, err)
 	}
 
-	var suffix rune
-	if len(match[2]) > 0 {
-		suffix = rune(match[2][0])
+	unit := time.Second
+	for _, suffix := range durationSuffixes {
+		if suffix.suffix == match[2] {
+			unit = suffix.unit
+		}
 	}
+	if n > int64(1<<63-1)/int64(unit) {
+		return ZBXDuration{}, fmt.Errorf("Time period %q is out of range", s)
+	}
+
+	return ZBXDuration{Duration: time.Duration(n) * unit}, nil
+}
 
-	// to nano
-	time *= 1_000_000_000
+// String returns the time period with the largest suffix dividing it, such as
+// 90d for 7776000 seconds. Durations are truncated to whole seconds.
+func (t ZBXDuration) String() string {
+	if t.Macro != "" {
+		return t.Macro
+	}
 
-	switch suffix {
-	case 's': // already in seconds
-	case '\x00': // no suffix means seconds
-	case 'm':
-		time *= 60
-	case 'h':
-		time *= 60 * 60
-	case 'd':
-		time *= 60 * 60 * 24
-	case 'w':
-		time *= 60 * 60 * 24 * 7
-	case 'M':
-		time *= 60 * 60 * 24 * 30
-	case 'y':
-		time *= 60 * 60 * 24 * 365
+	d := t.Duration.Truncate(time.Second)
+	if d == 0 {
+		return "0"
 	}
 
-	*t = ZBXDuration(time)
+	for _, suffix := range durationSuffixes {
+		if d%suffix.unit == 0 {
+			return strconv.FormatInt(int64(d/suffix.unit), 10) + suffix.suffix
+		}
+	}
+	return strconv.FormatInt(int64(d/time.Second), 10) + "s"
+}
+
+// IsMacro returns true if the time period is a macro.
+func (t ZBXDuration) IsMacro() bool {
+	return t.Macro != ""
+}
+
+func (t ZBXDuration) MarshalJSON() ([]byte, error) {
+	if t.Duration < 0 {
+		return nil, fmt.Errorf("Negative time period %s", t.Duration)
+	}
+	if t.Duration%time.Second != 0 {
+		return nil, fmt.Errorf("Time period %s is not a whole number of seconds", t.Duration)
+	}
+	return json.Marshal(t.String())
+}
+
+func (t *ZBXDuration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	// the API may return numbers as well as strings
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+
+	d, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*t = d
 	return nil
 }
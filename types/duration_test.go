@@ -1,8 +1,10 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 )
 
 func TestDuration(t *testing.T) {
@@ -15,6 +17,10 @@ func TestDuration(t *testing.T) {
 		"2h":  60 * 60 * 2 * 1_000_000_000,
 		"1d":  60 * 60 * 24 * 1_000_000_000,
 		"1w":  60 * 60 * 24 * 7 * 1_000_000_000,
+		"0":   0,
+		"":    0,
+		// more than 32 bits of seconds
+		"5000000000": 5_000_000_000 * 1_000_000_000,
 	}
 
 	for durationString, expected := range durations {
@@ -24,11 +30,82 @@ func TestDuration(t *testing.T) {
 			t.Error(err)
 		}
 
-		if expected != int64(duration) {
+		if expected != int64(duration.Duration) {
 			t.Errorf("Expected nano seconds %d but got %d for duration %q",
 				expected,
-				int64(duration),
+				int64(duration.Duration),
 				durationString)
 		}
 	}
 }
+
+func TestDurationErrors(t *testing.T) {
+	for _, s := range []string{`"1M"`, `"1y"`, `"5 minutes"`, `"-1m"`, `"1.5h"`, `"{TIMEOUT}"`, `"99999999999999999999"`, `"9999999999999w"`, `true`} {
+		var duration ZBXDuration
+		if err := json.Unmarshal([]byte(s), &duration); err == nil {
+			t.Errorf("Expected error decoding %s, got %v", s, duration)
+		}
+	}
+}
+
+func TestDurationMarshal(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                   "0",
+		30 * time.Second:    "30s",
+		90 * time.Second:    "90s",
+		5 * time.Minute:     "5m",
+		90 * time.Minute:    "90m",
+		2 * time.Hour:       "2h",
+		36 * time.Hour:      "36h",
+		90 * 24 * time.Hour: "90d",
+		14 * 24 * time.Hour: "2w",
+	}
+
+	for d, expected := range tests {
+		b, err := json.Marshal(ZBXDuration{Duration: d})
+		if err != nil || string(b) != `"`+expected+`"` {
+			t.Errorf("Expected %q for %s, got %s (%v)", expected, d, b, err)
+			continue
+		}
+
+		// round-trip
+		var decoded ZBXDuration
+		if err := json.Unmarshal(b, &decoded); err != nil || decoded.Duration != d {
+			t.Errorf("Duration %s does not round-trip: %s (%v)", d, decoded, err)
+		}
+	}
+
+	for _, d := range []time.Duration{time.Millisecond, -time.Second} {
+		if _, err := json.Marshal(ZBXDuration{Duration: d}); err == nil {
+			t.Errorf("Expected error encoding %s", d)
+		}
+	}
+}
+
+func TestDurationMacro(t *testing.T) {
+	for _, s := range []string{"{$TIMEOUT}", `{$PERIOD:"db"}`, "{#DELAY}"} {
+		data, _ := json.Marshal(s)
+
+		var duration ZBXDuration
+		if err := json.Unmarshal(data, &duration); err != nil {
+			t.Fatal(err)
+		}
+		if !duration.IsMacro() || duration.Macro != s || duration.Duration != 0 {
+			t.Errorf("Expected macro %s, got %#v", s, duration)
+		}
+
+		b, err := json.Marshal(duration)
+		if err != nil || string(b) != string(data) {
+			t.Errorf("Macro %s does not round-trip: %s (%v)", s, b, err)
+		}
+	}
+}
+
+func TestDurationNumber(t *testing.T) {
+	var v struct {
+		Period ZBXDuration `json:"period"`
+	}
+	if err := json.Unmarshal([]byte(`{"period":3600}`), &v); err != nil || v.Period.Duration != time.Hour {
+		t.Errorf("Unexpected period %s (%v)", v.Period, err)
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ZBXInt is an integer returned by the API as a JSON number or as a numeric
+// string, depending on the method and the Zabbix version. Empty strings and
+// null decode to zero. ZBXInt is encoded as a numeric string, as the API
+// accepts for all the integer fields.
+type ZBXInt int64
+
+func (i ZBXInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(strconv.FormatInt(int64(i), 10))
+}
+
+func (i *ZBXInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	s, err := unquoteNumber(data)
+	if err != nil {
+		return fmt.Errorf("Integer unmarshal error: %v", err)
+	}
+	if s == "" {
+		*i = 0
+		return nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Integer unmarshal error: invalid input %s", data)
+	}
+	*i = ZBXInt(n)
+	return nil
+}
+
+// unquoteNumber returns the number held by a JSON number or string.
+func unquoteNumber(data []byte) (string, error) {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return "", fmt.Errorf("invalid input %s", data)
+	}
+	return n.String(), nil
+}
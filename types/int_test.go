@@ -0,0 +1,78 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInt(t *testing.T) {
+	tests := map[string]ZBXInt{
+		`"42"`:                   42,
+		`42`:                     42,
+		`"-1"`:                   -1,
+		`""`:                     0,
+		`"0"`:                    0,
+		`"1351090996"`:           1351090996,
+		`"9223372036854775807"`:  9223372036854775807,
+		`9223372036854775807`:    9223372036854775807,
+		`"-9223372036854775808"`: -9223372036854775808,
+	}
+
+	for input, expected := range tests {
+		var i ZBXInt
+		if err := json.Unmarshal([]byte(input), &i); err != nil {
+			t.Errorf("Error decoding %s: %v", input, err)
+			continue
+		}
+		if i != expected {
+			t.Errorf("Expected %d but got %d for %s", expected, i, input)
+		}
+	}
+
+	// null leaves the value unchanged
+	i := ZBXInt(7)
+	if err := json.Unmarshal([]byte("null"), &i); err != nil || i != 7 {
+		t.Errorf("Expected null to be ignored but got %d, %v", i, err)
+	}
+
+	b, err := json.Marshal(struct {
+		N ZBXInt `json:"n"`
+	}{-3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"n":"-3"}` {
+		t.Errorf("Unexpected encoding %s", b)
+	}
+}
+
+func TestIntErrors(t *testing.T) {
+	for _, s := range []string{`"1.5"`, `1.5`, `"1e3"`, `"abc"`, `" 1"`, `"9223372036854775808"`, `true`, `{}`, `[]`} {
+		var i ZBXInt
+		if err := json.Unmarshal([]byte(s), &i); err == nil {
+			t.Errorf("Expected error decoding %s, got %d", s, i)
+		}
+	}
+}
+
+func FuzzInt(f *testing.F) {
+	for _, s := range []string{`"42"`, `42`, `""`, `null`, `"-1"`, `1.5`, `"abc"`, `"9223372036854775808"`} {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var i ZBXInt
+		if err := json.Unmarshal(data, &i); err != nil {
+			return
+		}
+
+		b, err := json.Marshal(i)
+		if err != nil {
+			t.Fatalf("Error encoding %d: %v", i, err)
+		}
+		var decoded ZBXInt
+		if err := json.Unmarshal(b, &decoded); err != nil || decoded != i {
+			t.Fatalf("Expected %s to decode to %d but got %d, %v", b, i, decoded, err)
+		}
+	})
+}
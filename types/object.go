@@ -0,0 +1,41 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// isEmptyArray returns true if data is an empty JSON array.
+func isEmptyArray(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) >= 2 && data[0] == '[' && data[len(data)-1] == ']' &&
+		len(bytes.TrimSpace(data[1:len(data)-1])) == 0
+}
+
+// UnmarshalObject decodes a JSON object into v as json.Unmarshal does, but
+// leaves v unchanged for an empty array, which PHP returns for empty
+// associative arrays. v must not call UnmarshalObject for itself from its
+// UnmarshalJSON method; decode into a type without the method instead.
+func UnmarshalObject(data []byte, v interface{}) error {
+	if isEmptyArray(data) {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// ZBXStringMap is a JSON object of strings, such as the inventory of a host,
+// which may be returned as an empty array when it has no fields.
+type ZBXStringMap map[string]string
+
+func (m *ZBXStringMap) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	v := make(map[string]string)
+	if err := UnmarshalObject(data, &v); err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
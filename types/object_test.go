@@ -0,0 +1,79 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUnmarshalObject(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+	v.Name = "unchanged"
+
+	for _, s := range []string{`[]`, ` [ ] `} {
+		if err := UnmarshalObject([]byte(s), &v); err != nil || v.Name != "unchanged" {
+			t.Errorf("Expected %q to be ignored but got %+v, %v", s, v, err)
+		}
+	}
+
+	if err := UnmarshalObject([]byte(`{"name":"proxy01"}`), &v); err != nil || v.Name != "proxy01" {
+		t.Errorf("Expected object to be decoded but got %+v, %v", v, err)
+	}
+
+	for _, s := range []string{`[{"name":"x"}]`, `"x"`, `1`} {
+		if err := UnmarshalObject([]byte(s), &v); err == nil {
+			t.Errorf("Expected error decoding %s", s)
+		}
+	}
+}
+
+func TestStringMap(t *testing.T) {
+	tests := map[string]int{
+		`{"os":"Linux","type":"server"}`: 2,
+		`{}`:                             0,
+		`[]`:                             0,
+	}
+
+	for input, expected := range tests {
+		var m ZBXStringMap
+		if err := json.Unmarshal([]byte(input), &m); err != nil {
+			t.Errorf("Error decoding %s: %v", input, err)
+			continue
+		}
+		if m == nil || len(m) != expected {
+			t.Errorf("Expected %d fields but got %v for %s", expected, m, input)
+		}
+	}
+
+	m := ZBXStringMap{"os": "Linux"}
+	if err := json.Unmarshal([]byte("null"), &m); err != nil || m["os"] != "Linux" {
+		t.Errorf("Expected null to be ignored but got %v, %v", m, err)
+	}
+
+	if err := json.Unmarshal([]byte(`["Linux"]`), &m); err == nil {
+		t.Errorf("Expected error decoding non-empty array, got %v", m)
+	}
+}
+
+func FuzzStringMap(f *testing.F) {
+	for _, s := range []string{`{"os":"Linux"}`, `[]`, `{}`, `null`, `["x"]`, `{"os":1}`} {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var m ZBXStringMap
+		if err := json.Unmarshal(data, &m); err != nil {
+			return
+		}
+
+		b, err := json.Marshal(m)
+		if err != nil {
+			t.Fatalf("Error encoding %v: %v", m, err)
+		}
+		var decoded ZBXStringMap
+		if err := json.Unmarshal(b, &decoded); err != nil || len(decoded) != len(m) {
+			t.Fatalf("Expected %s to decode to %v but got %v, %v", b, m, decoded, err)
+		}
+	})
+}
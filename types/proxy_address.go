@@ -15,6 +15,12 @@ func (addr *ZBXProxyAddresses) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	// an empty string holds no addresses
+	if input == "" {
+		*addr = nil
+		return nil
+	}
+
 	*addr = strings.Split(input, ",")
 	return nil
 }
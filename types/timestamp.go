@@ -1,34 +1,53 @@
 package types
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
 )
 
+// ZBXUnixTimestamp is a time returned by the API as a Unix timestamp, in a
+// JSON number or string. Zero and empty strings decode to the zero time, for
+// timestamps which are not set, and null leaves the timestamp unchanged.
 type ZBXUnixTimestamp time.Time
 
-func (t *ZBXUnixTimestamp) MarshalJSON() ([]byte, error) {
-	stamp := fmt.Sprintf("\"%d\"", time.Time(*t).Unix())
-	return []byte(stamp), nil
+// IsZero returns true for timestamps which are not set.
+func (t ZBXUnixTimestamp) IsZero() bool {
+	return time.Time(t).IsZero()
+}
+
+// MarshalJSON encodes the timestamp as a numeric string, and the zero time as
+// "0".
+func (t ZBXUnixTimestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte(`"0"`), nil
+	}
+	return []byte(fmt.Sprintf("\"%d\"", time.Time(t).Unix())), nil
 }
 
 func (t *ZBXUnixTimestamp) UnmarshalJSON(data []byte) error {
-	var timestamp string
-	var unix int64
+	if string(data) == "null" {
+		return nil
+	}
 
-	err := json.Unmarshal(data, &timestamp)
+	s, err := unquoteNumber(data)
 	if err != nil {
-		return err
+		return fmt.Errorf("Timestamp unmarshal error: %v", err)
+	}
+	if s == "" {
+		*t = ZBXUnixTimestamp{}
+		return nil
 	}
 
-	unix, err = strconv.ParseInt(timestamp, 10, 64)
+	unix, err := strconv.ParseInt(s, 10, 64)
 	if err != nil {
-		return err
+		return fmt.Errorf("Timestamp unmarshal error: invalid input %s", data)
+	}
+	if unix == 0 {
+		*t = ZBXUnixTimestamp{}
+		return nil
 	}
 
-	// Fractional seconds are handled implicitly by Parse.
 	*t = ZBXUnixTimestamp(time.Unix(unix, 0).UTC())
 
 	return nil
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -12,7 +13,7 @@ func TestUnixTimestamp(t *testing.T) {
 	}
 
 	tests := map[string]time.Time{
-		"0":          time.Date(1970, 1, 1, 0, 0, 0, 0, loc),
+		"0":          {},
 		"1683642493": time.Date(2023, 5, 9, 14, 28, 13, 0, loc),
 	}
 
@@ -32,3 +33,74 @@ func TestUnixTimestamp(t *testing.T) {
 		}
 	}
 }
+
+func TestUnixTimestampLenient(t *testing.T) {
+	expected := time.Date(2023, 5, 9, 14, 28, 13, 0, time.UTC)
+	for _, s := range []string{`1683642493`, `"1683642493"`} {
+		var zbxtime ZBXUnixTimestamp
+		if err := json.Unmarshal([]byte(s), &zbxtime); err != nil {
+			t.Errorf("Error decoding %s: %v", s, err)
+		}
+		if !time.Time(zbxtime).Equal(expected) {
+			t.Errorf("Expected '%v' but got '%v' for %s", expected, time.Time(zbxtime), s)
+		}
+	}
+
+	zbxtime := ZBXUnixTimestamp(expected)
+	if err := json.Unmarshal([]byte(`""`), &zbxtime); err != nil || !zbxtime.IsZero() {
+		t.Errorf("Expected zero time for empty string but got '%v', %v", time.Time(zbxtime), err)
+	}
+
+	var unset *ZBXUnixTimestamp
+	if err := json.Unmarshal([]byte(`null`), &unset); err != nil || unset != nil {
+		t.Errorf("Expected null to leave the timestamp unset but got %v, %v", unset, err)
+	}
+
+	for _, s := range []string{`"yesterday"`, `1.5`, `true`, `{}`} {
+		var zbxtime ZBXUnixTimestamp
+		if err := json.Unmarshal([]byte(s), &zbxtime); err == nil {
+			t.Errorf("Expected error decoding %s, got '%v'", s, time.Time(zbxtime))
+		}
+	}
+}
+
+func TestUnixTimestampMarshal(t *testing.T) {
+	b, err := json.Marshal(struct {
+		Since ZBXUnixTimestamp  `json:"since"`
+		Till  *ZBXUnixTimestamp `json:"till"`
+		Unset ZBXUnixTimestamp  `json:"unset"`
+	}{
+		Since: ZBXUnixTimestamp(time.Unix(1683642493, 0)),
+		Till:  &ZBXUnixTimestamp{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"since":"1683642493","till":"0","unset":"0"}`
+	if string(b) != expected {
+		t.Errorf("Expected %s but got %s", expected, b)
+	}
+}
+
+func FuzzUnixTimestamp(f *testing.F) {
+	for _, s := range []string{`"1683642493"`, `1683642493`, `"0"`, `""`, `null`, `"-1"`, `"x"`} {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var zbxtime ZBXUnixTimestamp
+		if err := json.Unmarshal(data, &zbxtime); err != nil {
+			return
+		}
+
+		b, err := json.Marshal(zbxtime)
+		if err != nil {
+			t.Fatalf("Error encoding '%v': %v", time.Time(zbxtime), err)
+		}
+		var decoded ZBXUnixTimestamp
+		if err := json.Unmarshal(b, &decoded); err != nil || time.Time(decoded).Unix() != time.Time(zbxtime).Unix() && !zbxtime.IsZero() {
+			t.Fatalf("Expected %s to decode to '%v' but got '%v', %v", b, time.Time(zbxtime), time.Time(decoded), err)
+		}
+	})
+}
@@ -1,5 +1,9 @@
 package zabbix
 
+import (
+	"github.com/NexonSU/go-zabbix/types"
+)
+
 // User represents a Zabbix User returned from the Zabbix API.
 //
 // See: https://www.zabbix.com/documentation/7.4/en/manual/api/reference/user/object
@@ -22,10 +26,10 @@ type User struct {
 	RoleId string `json:"roleid"`
 
 	// Time of the last unsuccessful login attempt.
-	AttemptClock int `json:"attempt_clock,string"`
+	AttemptClock types.ZBXInt `json:"attempt_clock"`
 
 	// Recent failed login attempt count.
-	AttemptFailed int `json:"attempt_failed,string"`
+	AttemptFailed types.ZBXInt `json:"attempt_failed"`
 
 	// IP address from where the last unsuccessful login attempt came from.
 	AttemptIp string `json:"attempt_ip"`
@@ -35,13 +39,13 @@ type User struct {
 	// Possible values:
 	// 0 - (default) auto-login disabled;
 	// 1 - auto-login enabled.
-	AutoLogin int `json:"autologin,string"`
+	AutoLogin types.ZBXInt `json:"autologin"`
 
 	// User session life time. Accepts seconds and time unit with suffix.
 	// If set to 0s, the session will never expire.
 	//
 	//Default: 15m.
-	AutoLogout string `json:"autologout"`
+	AutoLogout types.ZBXDuration `json:"autologout"`
 
 	// Language code of the user's language, for example, en_US.
 	//
@@ -56,7 +60,7 @@ type User struct {
 	// Possible values:
 	// 0 - not provisioned;
 	// 1 - provisioned.
-	Provisioned int `json:"provisioned,string"`
+	Provisioned types.ZBXInt `json:"provisioned"`
 
 	// Automatic refresh period. Accepts seconds or time unit with suffix
 	// (e.g., 30s, 90s, 1m, 1h).
@@ -67,7 +71,7 @@ type User struct {
 	// Amount of object rows to show per page.
 	//
 	// Default: 50.
-	RowsPerPage int `json:"rows_per_page,string"`
+	RowsPerPage types.ZBXInt `json:"rows_per_page"`
 
 	// Surname of the user.
 	Surname string `json:"surname"`
@@ -81,7 +85,7 @@ type User struct {
 	Theme string `json:"theme"`
 
 	// Time when the latest provisioning operation was made.
-	TsProvisioned int `json:"ts_provisioned,string"`
+	TsProvisioned types.ZBXInt `json:"ts_provisioned"`
 
 	// URL of the page to redirect the user to after logging in.
 	Url string `json:"url"`
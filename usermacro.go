@@ -74,7 +74,7 @@ func (c *Session) CreateUserMacros(macros ...HostMacro) (hostMacroIds []string,
 func (c *Session) DeleteUserMacros(hostMacroIDs ...string) (hostMacroIds []string, err error) {
 	var body UserMacroResponse
 
-	if err := c.Get("usermacro.delete", hostMacroIds, &body); err != nil {
+	if err := c.Get("usermacro.delete", hostMacroIDs, &body); err != nil {
 		return nil, err
 	}
 
@@ -92,7 +92,7 @@ func (c *Session) DeleteUserMacros(hostMacroIDs ...string) (hostMacroIds []strin
 func (c *Session) UpdateUserMacros(macros ...HostMacro) (hostMacroIds []string, err error) {
 	var body UserMacroResponse
 
-	if err := c.Get("usermacro.update", hostMacroIds, &body); err != nil {
+	if err := c.Get("usermacro.update", macros, &body); err != nil {
 		return nil, err
 	}
 
@@ -102,3 +102,49 @@ func (c *Session) UpdateUserMacros(macros ...HostMacro) (hostMacroIds []string,
 
 	return body.HostMacroIDs, nil
 }
+
+// GetGlobalMacros queries the Zabbix API for global macros matching the given
+// search parameters. The GlobalMacro parameter is always set.
+//
+// ErrNotFound is returned if the search result set is empty.
+// An error is returned if a transport, parsing or API error occurs.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/usermacro/get
+func (c *Session) GetGlobalMacros(params UserMacroGetParams) ([]GlobalMacro, error) {
+	macros := make([]GlobalMacro, 0)
+	params.GlobalMacro = true
+
+	if err := c.Get("usermacro.get", params, &macros); err != nil {
+		return nil, err
+	}
+
+	if len(macros) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return macros, nil
+}
+
+// CreateGlobalMacros creates Global Macros.
+// Returns a list of IDs of the created Global Macros.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/usermacro/createglobal
+func (c *Session) CreateGlobalMacros(macros ...GlobalMacro) ([]string, error) {
+	return c.GetIDs("usermacro.createglobal", macros, "globalmacroids")
+}
+
+// UpdateGlobalMacros updates Global Macros.
+// Returns a list of IDs of the updated Global Macros.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/usermacro/updateglobal
+func (c *Session) UpdateGlobalMacros(macros ...GlobalMacro) ([]string, error) {
+	return c.GetIDs("usermacro.updateglobal", macros, "globalmacroids")
+}
+
+// DeleteGlobalMacros deletes Global Macros.
+// Returns a list of IDs of the deleted Global Macros.
+//
+// Zabbix API docs: https://www.zabbix.com/documentation/7.0/en/manual/api/reference/usermacro/deleteglobal
+func (c *Session) DeleteGlobalMacros(globalMacroIDs ...string) ([]string, error) {
+	return c.GetIDs("usermacro.deleteglobal", globalMacroIDs, "globalmacroids")
+}
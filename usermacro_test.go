@@ -0,0 +1,131 @@
+package zabbix_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/NexonSU/go-zabbix"
+	"github.com/NexonSU/go-zabbix/test"
+)
+
+func TestGetGlobalMacros(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("usermacro.get", func(params json.RawMessage) (interface{}, error) {
+		return json.RawMessage(`[
+			{"globalmacroid":"2","macro":"{$SNMP_COMMUNITY}","value":"public","type":"0","description":"SNMP community"},
+			{"globalmacroid":"3","macro":"{$PASSWORD}","type":"1","description":""}
+		]`), nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	macros, err := session.GetGlobalMacros(zabbix.UserMacroGetParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []zabbix.GlobalMacro{
+		{GlobalMacroID: "2", Macro: "{$SNMP_COMMUNITY}", Value: "public", Type: zabbix.MacroTypeText, Description: "SNMP community"},
+		{GlobalMacroID: "3", Macro: "{$PASSWORD}", Type: zabbix.MacroTypeSecret},
+	}
+	if !reflect.DeepEqual(macros, expect) {
+		t.Errorf("Unexpected global macros: %+v", macros)
+	}
+
+	var params map[string]interface{}
+	if err := json.Unmarshal(server.Calls()[0].Params.(json.RawMessage), &params); err != nil {
+		t.Fatal(err)
+	}
+	if params["globalmacro"] != true {
+		t.Errorf("Expected globalmacro parameter, got %v", params)
+	}
+}
+
+func TestGlobalMacroMethods(t *testing.T) {
+	server := test.NewServer(t)
+	for _, method := range []string{"createglobal", "updateglobal", "deleteglobal"} {
+		server.Handle("usermacro."+method, func(params json.RawMessage) (interface{}, error) {
+			return map[string][]string{"globalmacroids": {"4"}}, nil
+		})
+	}
+	session := server.Session(t, "7.0.0")
+
+	ids, err := session.CreateGlobalMacros(zabbix.GlobalMacro{Macro: "{$TOKEN}", Value: "secret/zabbix:token", Type: zabbix.MacroTypeVault})
+	if err != nil || !reflect.DeepEqual(ids, []string{"4"}) {
+		t.Fatalf("Unexpected create result %v: %v", ids, err)
+	}
+
+	// text macros are sent without type, which Zabbix 4.0 rejects
+	if _, err := session.UpdateGlobalMacros(zabbix.GlobalMacro{GlobalMacroID: "4", Macro: "{$TOKEN}", Value: "abc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	if string(calls[0].Params.(json.RawMessage)) != `[{"macro":"{$TOKEN}","value":"secret/zabbix:token","type":"2"}]` {
+		t.Errorf("Unexpected create params: %s", calls[0].Params)
+	}
+	if string(calls[1].Params.(json.RawMessage)) != `[{"globalmacroid":"4","macro":"{$TOKEN}","value":"abc"}]` {
+		t.Errorf("Unexpected update params: %s", calls[1].Params)
+	}
+
+	if ids, err := session.DeleteGlobalMacros("4"); err != nil || ids[0] != "4" {
+		t.Errorf("Unexpected delete result %v: %v", ids, err)
+	}
+}
+
+func TestUpdateUserMacros(t *testing.T) {
+	server := test.NewServer(t)
+	server.Handle("usermacro.update", func(params json.RawMessage) (interface{}, error) {
+		return map[string][]string{"hostmacroids": {"1"}}, nil
+	})
+	server.Handle("usermacro.delete", func(params json.RawMessage) (interface{}, error) {
+		return map[string][]string{"hostmacroids": {"1"}}, nil
+	})
+	session := server.Session(t, "7.0.0")
+
+	if _, err := session.UpdateUserMacros(zabbix.HostMacro{HostMacroID: "1", Macro: "{$A}", Value: "b", Type: zabbix.MacroTypeSecret}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.DeleteUserMacros("1"); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := server.Calls()
+	var update []zabbix.HostMacro
+	if err := json.Unmarshal(calls[0].Params.(json.RawMessage), &update); err != nil {
+		t.Fatal(err)
+	}
+	if len(update) != 1 || update[0].HostMacroID != "1" || update[0].Type != zabbix.MacroTypeSecret {
+		t.Errorf("Unexpected update params: %s", calls[0].Params)
+	}
+	if string(calls[1].Params.(json.RawMessage)) != `["1"]` {
+		t.Errorf("Unexpected delete params: %s", calls[1].Params)
+	}
+}
+
+func TestHostMacroRedacted(t *testing.T) {
+	secret := zabbix.HostMacro{Macro: "{$PASSWORD}", Value: "s3cr3t", Type: zabbix.MacroTypeSecret}
+	if s := fmt.Sprint(secret); s != "{$PASSWORD}=****** (secret)" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+	if secret.Redacted().Value != zabbix.MacroRedacted || secret.Value != "s3cr3t" {
+		t.Errorf("Unexpected redaction: %+v", secret.Redacted())
+	}
+
+	vault := zabbix.GlobalMacro{Macro: "{$TOKEN}", Value: "secret/zabbix:token", Type: zabbix.MacroTypeVault}
+	if vault.Redacted() != vault || vault.String() != "{$TOKEN}=secret/zabbix:token (vault)" {
+		t.Errorf("Unexpected vault macro redaction: %s", vault)
+	}
+
+	for typ, name := range map[zabbix.MacroType]string{
+		zabbix.MacroTypeText:   "text",
+		zabbix.MacroTypeSecret: "secret",
+		zabbix.MacroTypeVault:  "vault",
+		3:                      "MacroType(3)",
+	} {
+		if typ.String() != name {
+			t.Errorf("Expected %s but got %s", name, typ)
+		}
+	}
+}
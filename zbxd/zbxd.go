@@ -0,0 +1,206 @@
+// Package zbxd implements the ZBXD framing used by the Zabbix server, proxy,
+// agent and sender protocols.
+//
+// Each packet starts with the "ZBXD" signature, a flags byte, and the data and
+// reserved lengths as little-endian integers of 4 bytes, or 8 bytes if the
+// large packet flag is set. If the compression flag is set, the data is zlib
+// compressed and the reserved length holds the uncompressed data length.
+//
+// See: https://www.zabbix.com/documentation/current/en/manual/appendix/protocols/header_datalen
+package zbxd
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	// FlagProtocol is always set on Zabbix protocol packets.
+	FlagProtocol byte = 0x01
+
+	// FlagCompression indicates zlib compressed packet data.
+	FlagCompression byte = 0x02
+
+	// FlagLargePacket indicates 8 byte data and reserved lengths, allowing
+	// packets over 4GB.
+	FlagLargePacket byte = 0x04
+)
+
+const (
+	// HeaderSize is the size of a packet header.
+	HeaderSize = 13
+
+	// LargeHeaderSize is the size of a packet header with the large packet
+	// flag set.
+	LargeHeaderSize = 21
+
+	// DefaultMaxDataSize is the largest packet data accepted by Read, which
+	// matches the receive limit of the Zabbix server.
+	DefaultMaxDataSize = 1 << 30
+)
+
+var signature = []byte("ZBXD")
+
+// ErrSignature is returned when a packet does not start with "ZBXD".
+var ErrSignature = errors.New("Invalid ZBXD packet signature")
+
+// Header is the header of a ZBXD packet.
+type Header struct {
+	// Flags is a combination of the Flag constants.
+	Flags byte
+
+	// DataLength is the length of the packet data following the header, as
+	// sent on the wire.
+	DataLength uint64
+
+	// Reserved is the uncompressed data length if the packet is compressed
+	// and zero otherwise.
+	Reserved uint64
+}
+
+// Size returns the encoded size of the header.
+func (h *Header) Size() int {
+	if h.Flags&FlagLargePacket != 0 {
+		return LargeHeaderSize
+	}
+
+	return HeaderSize
+}
+
+// MarshalBinary encodes the header.
+func (h *Header) MarshalBinary() ([]byte, error) {
+	b := make([]byte, h.Size())
+	copy(b, signature)
+	b[4] = h.Flags
+
+	if h.Flags&FlagLargePacket != 0 {
+		binary.LittleEndian.PutUint64(b[5:], h.DataLength)
+		binary.LittleEndian.PutUint64(b[13:], h.Reserved)
+		return b, nil
+	}
+
+	if h.DataLength > math.MaxUint32 || h.Reserved > math.MaxUint32 {
+		return nil, fmt.Errorf("Packet of %d bytes requires the large packet flag", h.DataLength)
+	}
+
+	binary.LittleEndian.PutUint32(b[5:], uint32(h.DataLength))
+	binary.LittleEndian.PutUint32(b[9:], uint32(h.Reserved))
+
+	return b, nil
+}
+
+// ReadHeader reads and decodes a packet header from r.
+func ReadHeader(r io.Reader) (*Header, error) {
+	b := make([]byte, LargeHeaderSize)
+	if _, err := io.ReadFull(r, b[:5]); err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(b[:4], signature) {
+		return nil, ErrSignature
+	}
+
+	h := &Header{Flags: b[4]}
+	if _, err := io.ReadFull(r, b[5:h.Size()]); err != nil {
+		return nil, err
+	}
+
+	if h.Flags&FlagLargePacket != 0 {
+		h.DataLength = binary.LittleEndian.Uint64(b[5:])
+		h.Reserved = binary.LittleEndian.Uint64(b[13:])
+	} else {
+		h.DataLength = uint64(binary.LittleEndian.Uint32(b[5:]))
+		h.Reserved = uint64(binary.LittleEndian.Uint32(b[9:]))
+	}
+
+	return h, nil
+}
+
+// Encode returns data framed as a ZBXD packet, compressed if compress is
+// true. The large packet flag is set if the data does not fit a normal packet.
+func Encode(data []byte, compress bool) ([]byte, error) {
+	h := &Header{Flags: FlagProtocol}
+
+	if compress {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		h.Flags |= FlagCompression
+		h.Reserved = uint64(len(data))
+		data = buf.Bytes()
+	}
+
+	h.DataLength = uint64(len(data))
+	if h.DataLength > math.MaxUint32 || h.Reserved > math.MaxUint32 {
+		h.Flags |= FlagLargePacket
+	}
+
+	header, err := h.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header, data...), nil
+}
+
+// Write writes data framed as a ZBXD packet to w.
+func Write(w io.Writer, data []byte, compress bool) error {
+	packet, err := Encode(data, compress)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(packet)
+	return err
+}
+
+// Read reads a ZBXD packet from r and returns its uncompressed data. Packets
+// with data larger than maxDataSize are rejected; DefaultMaxDataSize is used
+// if maxDataSize is zero.
+func Read(r io.Reader, maxDataSize uint64) ([]byte, error) {
+	if maxDataSize == 0 {
+		maxDataSize = DefaultMaxDataSize
+	}
+
+	h, err := ReadHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.DataLength > maxDataSize || h.Reserved > maxDataSize {
+		return nil, fmt.Errorf("Packet of %d bytes exceeds the limit of %d bytes", h.DataLength, maxDataSize)
+	}
+
+	data := make([]byte, h.DataLength)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+
+	if h.Flags&FlagCompression == 0 {
+		return data, nil
+	}
+
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Error decompressing packet: %v", err)
+	}
+	defer zr.Close()
+
+	uncompressed := make([]byte, h.Reserved)
+	if _, err := io.ReadFull(zr, uncompressed); err != nil {
+		return nil, fmt.Errorf("Error decompressing packet: %v", err)
+	}
+
+	return uncompressed, nil
+}
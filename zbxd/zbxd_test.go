@@ -0,0 +1,98 @@
+package zbxd
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	packet, err := Encode([]byte("agent.ping"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := append([]byte{'Z', 'B', 'X', 'D', 0x01, 10, 0, 0, 0, 0, 0, 0, 0}, "agent.ping"...)
+	if !bytes.Equal(packet, expected) {
+		t.Errorf("Expected packet %v but got %v", expected, packet)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"request":"sender data"}`), 100)
+
+	for _, compress := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := Write(&buf, data, compress); err != nil {
+			t.Fatal(err)
+		}
+
+		if compress && buf.Len() >= len(data) {
+			t.Errorf("Expected compressed packet to be smaller than %d bytes, got %d", len(data), buf.Len())
+		}
+
+		read, err := Read(&buf, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(read, data) {
+			t.Errorf("Expected data %q but got %q", data, read)
+		}
+	}
+}
+
+func TestLargeHeader(t *testing.T) {
+	h := &Header{
+		Flags:      FlagProtocol | FlagCompression | FlagLargePacket,
+		DataLength: 5 << 30,
+		Reserved:   6 << 30,
+	}
+
+	b, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(b) != LargeHeaderSize {
+		t.Fatalf("Expected header of %d bytes but got %d", LargeHeaderSize, len(b))
+	}
+
+	parsed, err := ReadHeader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *parsed != *h {
+		t.Errorf("Expected header %+v but got %+v", h, parsed)
+	}
+
+	if _, err := Read(bytes.NewReader(b), 0); err == nil {
+		t.Errorf("Expected an error reading a packet over the size limit")
+	}
+
+	h.Flags = FlagProtocol
+	if _, err := h.MarshalBinary(); err == nil {
+		t.Errorf("Expected an error encoding a large packet without the large packet flag")
+	}
+}
+
+func TestLargePacket(t *testing.T) {
+	h := &Header{Flags: FlagProtocol | FlagLargePacket, DataLength: 4}
+	b, _ := h.MarshalBinary()
+
+	data, err := Read(bytes.NewReader(append(b, "pong"...)), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "pong" {
+		t.Errorf("Expected data %q but got %q", "pong", data)
+	}
+}
+
+func TestInvalidSignature(t *testing.T) {
+	_, err := Read(bytes.NewReader([]byte("HTTP/1.1 400 Bad Request\r\n")), 0)
+	if err != ErrSignature {
+		t.Errorf("Expected ErrSignature but got %v", err)
+	}
+}